@@ -31,11 +31,14 @@ func (bcec *BuildCollectEnvCommand) Run() error {
 	if err != nil {
 		return err
 	}
-	build, err := buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, bcec.buildConfiguration.GetProject())
+	if err = build.SaveBuildIdentity(buildName, buildNumber, bcec.buildConfiguration.GetProject()); err != nil {
+		return err
+	}
+	collectedBuild, err := buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, bcec.buildConfiguration.GetProject())
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
-	err = build.CollectEnv()
+	err = collectedBuild.CollectEnv()
 	if err != nil {
 		return errorutils.CheckError(err)
 	}
@@ -0,0 +1,147 @@
+package buildinfo
+
+import (
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Prints the module->dependency graph of a local or a published build-info.
+type BuildDependencyGraphCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	local              bool
+	graphFormat        build.GraphFormat
+	filter             build.DependencyGraphFilter
+	why                string
+}
+
+func NewBuildDependencyGraphCommand() *BuildDependencyGraphCommand {
+	return &BuildDependencyGraphCommand{graphFormat: build.TreeGraphFormat}
+}
+
+func (bdgc *BuildDependencyGraphCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildDependencyGraphCommand {
+	bdgc.buildConfiguration = buildConfiguration
+	return bdgc
+}
+
+// The server from which the published build-info is loaded. Not used for local builds.
+func (bdgc *BuildDependencyGraphCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildDependencyGraphCommand {
+	bdgc.serverDetails = serverDetails
+	return bdgc
+}
+
+// Load the build-info collected locally, instead of the published one.
+func (bdgc *BuildDependencyGraphCommand) SetLocal(local bool) *BuildDependencyGraphCommand {
+	bdgc.local = local
+	return bdgc
+}
+
+func (bdgc *BuildDependencyGraphCommand) SetGraphFormat(graphFormat build.GraphFormat) *BuildDependencyGraphCommand {
+	bdgc.graphFormat = graphFormat
+	return bdgc
+}
+
+// A wildcard pattern of the modules to include in the graph.
+func (bdgc *BuildDependencyGraphCommand) SetModule(module string) *BuildDependencyGraphCommand {
+	bdgc.filter.Module = module
+	return bdgc
+}
+
+// Include only dependencies with one of these scopes.
+func (bdgc *BuildDependencyGraphCommand) SetScopes(scopes []string) *BuildDependencyGraphCommand {
+	bdgc.filter.Scopes = scopes
+	return bdgc
+}
+
+// A wildcard pattern of a dependency ID. If set, every path to the matching dependencies is printed, instead of the whole graph.
+func (bdgc *BuildDependencyGraphCommand) SetWhy(why string) *BuildDependencyGraphCommand {
+	bdgc.why = why
+	return bdgc
+}
+
+func (bdgc *BuildDependencyGraphCommand) CommandName() string {
+	return "rt_build_dependency_graph"
+}
+
+func (bdgc *BuildDependencyGraphCommand) ServerDetails() (*config.ServerDetails, error) {
+	if bdgc.serverDetails != nil {
+		return bdgc.serverDetails, nil
+	}
+	return config.GetDefaultServerConf()
+}
+
+func (bdgc *BuildDependencyGraphCommand) Run() error {
+	buildInfo, err := bdgc.loadBuildInfo()
+	if err != nil {
+		return err
+	}
+	graph, err := build.NewDependencyGraph(buildInfo, bdgc.filter)
+	if err != nil {
+		return err
+	}
+	if len(graph.Modules) == 0 {
+		return errorutils.CheckErrorf("no modules matching '%s' were found in build %s", bdgc.filter.Module, graph.Name)
+	}
+	if bdgc.why != "" {
+		return bdgc.printPaths(graph)
+	}
+	log.Output(graph.Render(bdgc.graphFormat))
+	return nil
+}
+
+func (bdgc *BuildDependencyGraphCommand) loadBuildInfo() (*buildinfo.BuildInfo, error) {
+	buildName, err := bdgc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return nil, err
+	}
+	buildNumber, err := bdgc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return nil, err
+	}
+	projectKey := bdgc.buildConfiguration.GetProject()
+	if bdgc.local {
+		return readLocalBuildInfo(buildName, buildNumber, projectKey)
+	}
+	serverDetails, err := bdgc.ServerDetails()
+	if err != nil {
+		return nil, err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: projectKey})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckErrorf("build %s/%s was not found in Artifactory", buildName, buildNumber)
+	}
+	return &publishedBuildInfo.BuildInfo, nil
+}
+
+// Prints every path from the modules to the dependencies matching the 'why' pattern.
+func (bdgc *BuildDependencyGraphCommand) printPaths(graph *build.DependencyGraph) error {
+	var lines []string
+	for _, module := range graph.Modules {
+		paths, err := module.PathsTo(bdgc.why)
+		if err != nil {
+			return err
+		}
+		for _, path := range paths {
+			lines = append(lines, strings.Join(path, " > "))
+		}
+	}
+	if len(lines) == 0 {
+		return errorutils.CheckErrorf("no dependency matching '%s' was found in build %s", bdgc.why, graph.Name)
+	}
+	log.Output(strings.Join(lines, "\n"))
+	return nil
+}
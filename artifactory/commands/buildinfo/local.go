@@ -0,0 +1,251 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	artclientutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Lists the builds, which were collected locally and not yet published or cleaned.
+type BuildLocalListCommand struct {
+	outputFormat format.OutputFormat
+}
+
+type localBuildRow struct {
+	Name     string `col-name:"Build Name"`
+	Number   string `col-name:"Build Number"`
+	Project  string `col-name:"Project"`
+	Started  string `col-name:"Started"`
+	Partials string `col-name:"Partials"`
+}
+
+func NewBuildLocalListCommand() *BuildLocalListCommand {
+	return &BuildLocalListCommand{outputFormat: format.Table}
+}
+
+func (bllc *BuildLocalListCommand) SetOutputFormat(outputFormat format.OutputFormat) *BuildLocalListCommand {
+	bllc.outputFormat = outputFormat
+	return bllc
+}
+
+func (bllc *BuildLocalListCommand) CommandName() string {
+	return "rt_build_local_list"
+}
+
+func (bllc *BuildLocalListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return config.GetDefaultServerConf()
+}
+
+func (bllc *BuildLocalListCommand) Run() error {
+	localBuilds, err := build.ListLocalBuilds()
+	if err != nil {
+		return err
+	}
+	switch bllc.outputFormat {
+	case format.Json:
+		return printJson(localBuilds)
+	case format.Table, "":
+		var rows []localBuildRow
+		for _, localBuild := range localBuilds {
+			rows = append(rows, localBuildRow{
+				Name:     localBuild.Name,
+				Number:   localBuild.Number,
+				Project:  localBuild.Project,
+				Started:  localBuild.Started,
+				Partials: strconv.Itoa(localBuild.Partials),
+			})
+		}
+		return coreutils.PrintTable(rows, "Local Builds", "No local builds were found", false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Only '%s' and '%s' are supported", bllc.outputFormat, format.Table, format.Json)
+	}
+}
+
+// Prints the build-info that would be published, without publishing it.
+type BuildLocalShowCommand struct {
+	buildConfiguration *build.BuildConfiguration
+}
+
+func NewBuildLocalShowCommand() *BuildLocalShowCommand {
+	return &BuildLocalShowCommand{}
+}
+
+func (blsc *BuildLocalShowCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildLocalShowCommand {
+	blsc.buildConfiguration = buildConfiguration
+	return blsc
+}
+
+func (blsc *BuildLocalShowCommand) CommandName() string {
+	return "rt_build_local_show"
+}
+
+func (blsc *BuildLocalShowCommand) ServerDetails() (*config.ServerDetails, error) {
+	return config.GetDefaultServerConf()
+}
+
+func (blsc *BuildLocalShowCommand) Run() error {
+	buildName, err := blsc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := blsc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	buildInfo, err := readLocalBuildInfo(buildName, buildNumber, blsc.buildConfiguration.GetProject())
+	if err != nil {
+		return err
+	}
+	return printJson(buildInfo)
+}
+
+// Returns the build-info generated from the partial build-info files of a local build.
+func readLocalBuildInfo(buildName, buildNumber, projectKey string) (*buildinfo.BuildInfo, error) {
+	// Make sure the build exists, to avoid creating a new one.
+	if _, err := build.ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return nil, err
+	}
+	buildInfoService := build.CreateBuildInfoService()
+	bld, err := buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	bld.SetAgentName(coreutils.GetCliUserAgentName())
+	bld.SetAgentVersion(coreutils.GetCliUserAgentVersion())
+	bld.SetBuildAgentVersion(coreutils.GetClientAgentVersion())
+	buildInfo, err := bld.ToBuildInfo()
+	return buildInfo, errorutils.CheckError(err)
+}
+
+// Edits the partial build-info files of a local build, before it is published.
+type BuildLocalEditCommand struct {
+	buildConfiguration    *build.BuildConfiguration
+	removeModulePattern   string
+	removeArtifactPattern string
+	renameModuleFrom      string
+	renameModuleTo        string
+	properties            string
+}
+
+type BuildLocalEditResult struct {
+	RemovedModules   []string `json:"removedModules"`
+	RemovedArtifacts int      `json:"removedArtifacts"`
+	RenamedPartials  int      `json:"renamedPartials"`
+	AddedProperties  []string `json:"addedProperties"`
+}
+
+func NewBuildLocalEditCommand() *BuildLocalEditCommand {
+	return &BuildLocalEditCommand{}
+}
+
+func (blec *BuildLocalEditCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildLocalEditCommand {
+	blec.buildConfiguration = buildConfiguration
+	return blec
+}
+
+// Wildcard pattern of the module IDs to remove.
+func (blec *BuildLocalEditCommand) SetRemoveModulePattern(removeModulePattern string) *BuildLocalEditCommand {
+	blec.removeModulePattern = removeModulePattern
+	return blec
+}
+
+// Wildcard pattern, matched against the name and path of the artifacts to remove.
+func (blec *BuildLocalEditCommand) SetRemoveArtifactPattern(removeArtifactPattern string) *BuildLocalEditCommand {
+	blec.removeArtifactPattern = removeArtifactPattern
+	return blec
+}
+
+func (blec *BuildLocalEditCommand) SetRenameModule(from, to string) *BuildLocalEditCommand {
+	blec.renameModuleFrom = from
+	blec.renameModuleTo = to
+	return blec
+}
+
+// Properties to add to the build-info, in the "key1=value1;key2=value2" format.
+func (blec *BuildLocalEditCommand) SetProperties(properties string) *BuildLocalEditCommand {
+	blec.properties = properties
+	return blec
+}
+
+func (blec *BuildLocalEditCommand) CommandName() string {
+	return "rt_build_local_edit"
+}
+
+func (blec *BuildLocalEditCommand) ServerDetails() (*config.ServerDetails, error) {
+	return config.GetDefaultServerConf()
+}
+
+func (blec *BuildLocalEditCommand) Run() error {
+	buildName, err := blec.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := blec.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := blec.buildConfiguration.GetProject()
+	if _, err = build.ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	result := BuildLocalEditResult{RemovedModules: []string{}, AddedProperties: []string{}}
+	if blec.removeModulePattern != "" {
+		if result.RemovedModules, err = build.RemoveModules(buildName, buildNumber, projectKey, blec.removeModulePattern); err != nil {
+			return err
+		}
+	}
+	if blec.removeArtifactPattern != "" {
+		if result.RemovedArtifacts, err = build.RemoveArtifacts(buildName, buildNumber, projectKey, blec.removeArtifactPattern); err != nil {
+			return err
+		}
+	}
+	if blec.renameModuleFrom != "" {
+		if result.RenamedPartials, err = build.RenameModule(buildName, buildNumber, projectKey, blec.renameModuleFrom, blec.renameModuleTo); err != nil {
+			return err
+		}
+	}
+	if blec.properties != "" {
+		if result.AddedProperties, err = blec.addProperties(buildName, buildNumber, projectKey); err != nil {
+			return err
+		}
+	}
+	log.Info("Edited build info", buildName+"/"+buildNumber+".")
+	return printJson(result)
+}
+
+func (blec *BuildLocalEditCommand) addProperties(buildName, buildNumber, projectKey string) ([]string, error) {
+	props, err := artclientutils.ParseProperties(blec.properties)
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]string)
+	var keys []string
+	for key, values := range props.ToMap() {
+		if len(values) > 1 {
+			return nil, errorutils.CheckErrorf("property '%s' has multiple values. Build-info properties can hold a single value only", key)
+		}
+		properties[key] = values[0]
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, build.AddBuildProperties(buildName, buildNumber, projectKey, properties)
+}
+
+func printJson(output interface{}) error {
+	content, err := json.Marshal(output)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
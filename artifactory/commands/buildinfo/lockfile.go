@@ -0,0 +1,420 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/python/dependencies"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/cargo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/lockfile"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/module"
+)
+
+// The maximum number of files searched by a single AQL query.
+const aqlFilesBulkSize = 100
+
+// Collects build-info dependencies from lock files, without running the package manager.
+// Checksums which are missing in the lock files are looked up in Artifactory.
+type BuildAddLockfileDependenciesCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	repo               string
+	workingDirectory   string
+	lockfileNames      []string
+	threads            int
+}
+
+func NewBuildAddLockfileDependenciesCommand() *BuildAddLockfileDependenciesCommand {
+	return &BuildAddLockfileDependenciesCommand{threads: 3}
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) CommandName() string {
+	return "rt_build_add_lockfile_dependencies"
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) ServerDetails() (*config.ServerDetails, error) {
+	return baldc.serverDetails, nil
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildAddLockfileDependenciesCommand {
+	baldc.buildConfiguration = buildConfiguration
+	return baldc
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildAddLockfileDependenciesCommand {
+	baldc.serverDetails = serverDetails
+	return baldc
+}
+
+// The repository from which the dependencies were resolved, and in which their checksums are looked up.
+func (baldc *BuildAddLockfileDependenciesCommand) SetRepo(repo string) *BuildAddLockfileDependenciesCommand {
+	baldc.repo = repo
+	return baldc
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) SetWorkingDirectory(workingDirectory string) *BuildAddLockfileDependenciesCommand {
+	baldc.workingDirectory = workingDirectory
+	return baldc
+}
+
+// The lock files to read. If empty, all the supported lock files in the working directory are read.
+func (baldc *BuildAddLockfileDependenciesCommand) SetLockfileNames(lockfileNames []string) *BuildAddLockfileDependenciesCommand {
+	baldc.lockfileNames = lockfileNames
+	return baldc
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) SetThreads(threads int) *BuildAddLockfileDependenciesCommand {
+	baldc.threads = threads
+	return baldc
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) Run() (err error) {
+	log.Info("Running Build Add Lockfile Dependencies command...")
+	if baldc.repo == "" {
+		return errorutils.CheckErrorf("the repository from which the dependencies were resolved must be provided")
+	}
+	if baldc.workingDirectory == "" {
+		if baldc.workingDirectory, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	lockfiles, err := baldc.getLockfiles()
+	if err != nil {
+		return err
+	}
+	if _, err = build.PrepareBuildPrerequisites(baldc.buildConfiguration); err != nil {
+		return err
+	}
+	var modules []*lockfile.Module
+	for _, lf := range lockfiles {
+		log.Info("Reading build-info dependencies from " + lf.Name + "...")
+		lockfileModules, err := lf.Read(baldc.workingDirectory)
+		if err != nil {
+			return err
+		}
+		modules = append(modules, lockfileModules...)
+	}
+	// The module name of the build configuration can only identify a single module.
+	if len(modules) == 1 && baldc.buildConfiguration.GetModule() != "" {
+		renameModule(modules[0], baldc.buildConfiguration.GetModule())
+	}
+	servicesManager, err := utils.CreateServiceManager(baldc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	buildName, err := baldc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := baldc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	for _, lockfileModule := range modules {
+		moduleDependencies, err := baldc.resolveChecksums(lockfileModule, servicesManager, buildName)
+		if err != nil {
+			return err
+		}
+		sort.Slice(moduleDependencies, func(i, j int) bool {
+			return moduleDependencies[i].Id < moduleDependencies[j].Id
+		})
+		log.Debug("Collected", len(moduleDependencies), "dependencies of module", lockfileModule.Id)
+		err = build.SavePartialBuildInfo(buildName, buildNumber, baldc.buildConfiguration.GetProject(), func(partial *entities.Partial) {
+			partial.ModuleId = lockfileModule.Id
+			partial.ModuleType = lockfileModule.Type
+			partial.Dependencies = moduleDependencies
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Info("Build Add Lockfile Dependencies finished successfully.")
+	return nil
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) getLockfiles() ([]*lockfile.Lockfile, error) {
+	if len(baldc.lockfileNames) == 0 {
+		detected, err := lockfile.Detect(baldc.workingDirectory)
+		if err != nil {
+			return nil, err
+		}
+		if len(detected) == 0 {
+			return nil, errorutils.CheckErrorf("no supported lock file was found in %s. Supported lock files: %v", baldc.workingDirectory, lockfile.GetSupportedLockfiles())
+		}
+		return detected, nil
+	}
+	lockfiles := make([]*lockfile.Lockfile, 0, len(baldc.lockfileNames))
+	for _, name := range baldc.lockfileNames {
+		lf, err := lockfile.GetLockfile(name)
+		if err != nil {
+			return nil, err
+		}
+		lockfiles = append(lockfiles, lf)
+	}
+	return lockfiles, nil
+}
+
+// Sets the checksums which are missing in the lock file. Dependencies without any checksum are excluded from the build-info.
+func (baldc *BuildAddLockfileDependenciesCommand) resolveChecksums(lockfileModule *lockfile.Module, servicesManager artifactory.ArtifactoryServicesManager, buildName string) ([]entities.Dependency, error) {
+	switch lockfileModule.Type {
+	case entities.Npm:
+		return baldc.resolveNpmChecksums(lockfileModule, servicesManager, buildName)
+	case entities.Python:
+		return baldc.resolvePythonChecksums(lockfileModule, servicesManager)
+	}
+	searchFilesFunc := getDependencyFilesFunc(lockfileModule.Type)
+	if searchFilesFunc == nil {
+		return nil, errorutils.CheckErrorf("resolving the checksums of %s dependencies is not supported", lockfileModule.Type)
+	}
+	repo, err := utils.GetRepoNameForDependenciesSearch(baldc.repo, servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]dependencyFile, len(lockfileModule.Dependencies))
+	for id, dependency := range lockfileModule.Dependencies {
+		if !hasBuildInfoChecksums(dependency) {
+			files[id] = searchFilesFunc(id)
+		}
+	}
+	checksums, err := searchChecksums(servicesManager, repo, files)
+	if err != nil {
+		return nil, err
+	}
+	var missingDependencies []string
+	moduleDependencies := make([]entities.Dependency, 0, len(lockfileModule.Dependencies))
+	for id, dependency := range lockfileModule.Dependencies {
+		if _, searched := files[id]; searched {
+			if !mergeChecksum(dependency, checksums[id]) {
+				missingDependencies = append(missingDependencies, id)
+				continue
+			}
+		}
+		moduleDependencies = append(moduleDependencies, *dependency)
+	}
+	printMissingLockfileDependencies(missingDependencies, lockfileModule.Type)
+	return moduleDependencies, nil
+}
+
+func (baldc *BuildAddLockfileDependenciesCommand) resolveNpmChecksums(lockfileModule *lockfile.Module, servicesManager artifactory.ArtifactoryServicesManager, buildName string) ([]entities.Dependency, error) {
+	// Collect checksums from the last build to decrease the requests to Artifactory.
+	previousBuildDependencies, err := commandsutils.GetDependenciesFromLatestBuild(servicesManager, buildName)
+	if err != nil {
+		return nil, err
+	}
+	var missingDependencies []string
+	missingDepsChan := make(chan string)
+	missingDepsDone := make(chan struct{})
+	go func() {
+		for depId := range missingDepsChan {
+			missingDependencies = append(missingDependencies, depId)
+		}
+		close(missingDepsDone)
+	}()
+	collectChecksumsFunc := commandsutils.CreateCollectChecksumsFunc(previousBuildDependencies, servicesManager, missingDepsChan)
+	moduleDependencies, err := biutils.TraverseDependencies(lockfileModule.Dependencies, func(dependency *entities.Dependency) (bool, error) {
+		if hasBuildInfoChecksums(dependency) {
+			return true, nil
+		}
+		return collectChecksumsFunc(dependency)
+	}, baldc.threads)
+	close(missingDepsChan)
+	<-missingDepsDone
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	commandsutils.PrintMissingDependencies(missingDependencies)
+	return moduleDependencies, nil
+}
+
+// Python dependencies are looked up by their file names, using the dependencies cache of the project, as the Python commands do.
+// The cache isn't updated, since it holds the dependencies of the whole project, and only some of them are looked up here.
+func (baldc *BuildAddLockfileDependenciesCommand) resolvePythonChecksums(lockfileModule *lockfile.Module, servicesManager artifactory.ArtifactoryServicesManager) ([]entities.Dependency, error) {
+	// The cache maps the package names to the dependencies, whose IDs are the file names.
+	dependenciesByName := make(map[string]entities.Dependency)
+	for id, dependency := range lockfileModule.Dependencies {
+		if !hasBuildInfoChecksums(dependency) {
+			name, _, _ := strings.Cut(id, ":")
+			dependenciesByName[name] = entities.Dependency{Id: lockfileModule.FileNames[id]}
+		}
+	}
+	if len(dependenciesByName) > 0 {
+		if err := dependencies.ResolveDepsChecksumInfo(dependenciesByName, baldc.workingDirectory, servicesManager, baldc.repo); err != nil {
+			return nil, err
+		}
+	}
+	moduleDependencies := make([]entities.Dependency, 0, len(lockfileModule.Dependencies))
+	for id, dependency := range lockfileModule.Dependencies {
+		name, _, _ := strings.Cut(id, ":")
+		// Dependencies which were not found in Artifactory are kept if the lock file includes their sha256 checksums.
+		if mergeChecksum(dependency, dependenciesByName[name].Checksum) {
+			moduleDependencies = append(moduleDependencies, *dependency)
+		}
+	}
+	return moduleDependencies, nil
+}
+
+// Build-info dependencies are expected to have both sha1 and md5 checksums.
+func hasBuildInfoChecksums(dependency *entities.Dependency) bool {
+	return dependency.Checksum.Sha1 != "" && dependency.Checksum.Md5 != ""
+}
+
+// Adds the checksums found in Artifactory to the dependency, keeping the checksums of the lock file.
+// Returns false if the dependency has no checksum at all.
+func mergeChecksum(dependency *entities.Dependency, checksum entities.Checksum) bool {
+	if checksum.Sha1 != "" {
+		dependency.Checksum.Sha1 = checksum.Sha1
+	}
+	if checksum.Md5 != "" {
+		dependency.Checksum.Md5 = checksum.Md5
+	}
+	if dependency.Checksum.Sha256 == "" {
+		dependency.Checksum.Sha256 = checksum.Sha256
+	}
+	return !dependency.Checksum.IsEmpty()
+}
+
+func printMissingLockfileDependencies(missingDependencies []string, moduleType entities.ModuleType) {
+	if len(missingDependencies) == 0 {
+		return
+	}
+	sort.Strings(missingDependencies)
+	log.Warn(strings.Join(missingDependencies, "\n"), fmt.Sprintf("\nThe %s dependencies above could not be found in Artifactory and therefore are not included in the build-info.", moduleType))
+}
+
+// Sets the ID of the module, and replaces it in the requested-by paths of its dependencies.
+func renameModule(lockfileModule *lockfile.Module, moduleId string) {
+	for _, dependency := range lockfileModule.Dependencies {
+		for _, pathToRoot := range dependency.RequestedBy {
+			if len(pathToRoot) > 0 && pathToRoot[len(pathToRoot)-1] == lockfileModule.Id {
+				pathToRoot[len(pathToRoot)-1] = moduleId
+			}
+		}
+	}
+	lockfileModule.Id = moduleId
+}
+
+// A file in Artifactory which may be the package of a dependency. An empty path matches any path.
+type dependencyFile struct {
+	Path string
+	Name string
+}
+
+// Returns the function which returns the possible files of a dependency in Artifactory, by the dependency ID.
+func getDependencyFilesFunc(moduleType entities.ModuleType) func(id string) []dependencyFile {
+	switch moduleType {
+	case entities.Go:
+		// Go modules are stored by their escaped paths and versions, as served by the GOPROXY protocol.
+		return func(id string) []dependencyFile {
+			modulePath, version, _ := strings.Cut(id, ":")
+			escapedPath, err := module.EscapePath(modulePath)
+			if err != nil {
+				escapedPath = modulePath
+			}
+			escapedVersion, err := module.EscapeVersion(version)
+			if err != nil {
+				escapedVersion = version
+			}
+			return []dependencyFile{{Path: path.Join(escapedPath, "@v"), Name: escapedVersion + ".zip"}}
+		}
+	case entities.Nuget:
+		// NuGet remote repositories may store the packages with lower-case names.
+		return func(id string) []dependencyFile {
+			name, version, _ := strings.Cut(id, ":")
+			fileName := name + "." + version + ".nupkg"
+			return []dependencyFile{{Name: fileName}, {Name: strings.ToLower(fileName)}}
+		}
+	case cargo.ModuleType:
+		return func(id string) []dependencyFile {
+			name, version, _ := strings.Cut(id, ":")
+			return []dependencyFile{{Name: name + "-" + version + ".crate"}}
+		}
+	}
+	return nil
+}
+
+// Searches the files of the dependencies in the repository, and returns the checksums of the files found, mapped by the dependency IDs.
+func searchChecksums(servicesManager artifactory.ArtifactoryServicesManager, repo string, files map[string][]dependencyFile) (map[string]entities.Checksum, error) {
+	idsByFile := make(map[dependencyFile]string)
+	var allFiles []dependencyFile
+	for _, id := range sortedIds(files) {
+		for _, file := range files[id] {
+			if _, exists := idsByFile[file]; !exists {
+				idsByFile[file] = id
+				allFiles = append(allFiles, file)
+			}
+		}
+	}
+	checksums := make(map[string]entities.Checksum)
+	for start := 0; start < len(allFiles); start += aqlFilesBulkSize {
+		results, err := searchFilesWithAql(servicesManager, repo, allFiles[start:min(start+aqlFilesBulkSize, len(allFiles))])
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			id, found := idsByFile[dependencyFile{Path: result.Path, Name: result.Name}]
+			if !found {
+				id, found = idsByFile[dependencyFile{Name: result.Name}]
+			}
+			if found {
+				checksums[id] = entities.Checksum{Sha1: result.Actual_Sha1, Md5: result.Actual_Md5, Sha256: result.Sha256}
+			}
+		}
+	}
+	return checksums, nil
+}
+
+func searchFilesWithAql(servicesManager artifactory.ArtifactoryServicesManager, repo string, files []dependencyFile) (results []*specutils.ResultItem, err error) {
+	criteria := make([]map[string]string, 0, len(files))
+	for _, file := range files {
+		fileCriteria := map[string]string{"name": file.Name}
+		if file.Path != "" {
+			fileCriteria["path"] = file.Path
+		}
+		criteria = append(criteria, fileCriteria)
+	}
+	query, err := json.Marshal(map[string]any{"repo": repo, "$or": criteria})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	stream, err := servicesManager.Aql(fmt.Sprintf(`items.find(%s).include("path","name","actual_md5","actual_sha1","sha256")`, query))
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(stream, &err)
+	content, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	parsedResult := new(struct {
+		Results []*specutils.ResultItem `json:"results,omitempty"`
+	})
+	if err = json.Unmarshal(content, parsedResult); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parsedResult.Results, nil
+}
+
+func sortedIds(files map[string][]dependencyFile) []string {
+	ids := make([]string, 0, len(files))
+	for id := range files {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
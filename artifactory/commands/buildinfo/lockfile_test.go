@@ -0,0 +1,37 @@
+package buildinfo
+
+import (
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/cargo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/lockfile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDependencyFilesFunc(t *testing.T) {
+	assert.Equal(t, []dependencyFile{{Path: "github.com/!burnt!sushi/toml/@v", Name: "v1.4.0.zip"}},
+		getDependencyFilesFunc(entities.Go)("github.com/BurntSushi/toml:v1.4.0"))
+	assert.Equal(t, []dependencyFile{{Name: "Newtonsoft.Json.13.0.1.nupkg"}, {Name: "newtonsoft.json.13.0.1.nupkg"}},
+		getDependencyFilesFunc(entities.Nuget)("Newtonsoft.Json:13.0.1"))
+	assert.Equal(t, []dependencyFile{{Name: "serde-1.0.0.crate"}}, getDependencyFilesFunc(cargo.ModuleType)("serde:1.0.0"))
+	assert.Nil(t, getDependencyFilesFunc(entities.Docker))
+}
+
+func TestMergeChecksum(t *testing.T) {
+	dependency := &entities.Dependency{Checksum: entities.Checksum{Sha256: "lock-sha256"}}
+	assert.True(t, mergeChecksum(dependency, entities.Checksum{Sha1: "sha1", Md5: "md5", Sha256: "artifactory-sha256"}))
+	assert.Equal(t, entities.Checksum{Sha1: "sha1", Md5: "md5", Sha256: "lock-sha256"}, dependency.Checksum)
+	assert.False(t, mergeChecksum(&entities.Dependency{}, entities.Checksum{}))
+}
+
+func TestRenameModule(t *testing.T) {
+	lockfileModule := &lockfile.Module{Id: "app", Dependencies: map[string]*entities.Dependency{
+		"a:1.0.0": {Id: "a:1.0.0", RequestedBy: [][]string{{"app"}}},
+		"b:1.0.0": {Id: "b:1.0.0", RequestedBy: [][]string{{"a:1.0.0", "app"}}},
+	}}
+	renameModule(lockfileModule, "my-module")
+	assert.Equal(t, "my-module", lockfileModule.Id)
+	assert.Equal(t, [][]string{{"my-module"}}, lockfileModule.Dependencies["a:1.0.0"].RequestedBy)
+	assert.Equal(t, [][]string{{"a:1.0.0", "my-module"}}, lockfileModule.Dependencies["b:1.0.0"].RequestedBy)
+}
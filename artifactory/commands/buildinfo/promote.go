@@ -3,15 +3,21 @@ package buildinfo
 import (
 	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/promotionpolicy"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 type BuildPromotionCommand struct {
 	services.PromotionParams
-	buildConfiguration *build.BuildConfiguration
-	serverDetails      *config.ServerDetails
-	dryRun             bool
+	buildConfiguration  *build.BuildConfiguration
+	serverDetails       *config.ServerDetails
+	dryRun              bool
+	policyFilePath      string
+	issueStatusResolver promotionpolicy.IssueStatusResolver
 }
 
 func NewBuildPromotionCommand() *BuildPromotionCommand {
@@ -23,6 +29,18 @@ func (bpc *BuildPromotionCommand) SetDryRun(dryRun bool) *BuildPromotionCommand
 	return bpc
 }
 
+// A promotion policy file, evaluated against the build-info before the build is promoted.
+func (bpc *BuildPromotionCommand) SetPolicyFilePath(policyFilePath string) *BuildPromotionCommand {
+	bpc.policyFilePath = policyFilePath
+	return bpc
+}
+
+// Overrides the issue tracker configured in the promotion policy.
+func (bpc *BuildPromotionCommand) SetIssueStatusResolver(issueStatusResolver promotionpolicy.IssueStatusResolver) *BuildPromotionCommand {
+	bpc.issueStatusResolver = issueStatusResolver
+	return bpc
+}
+
 func (bpc *BuildPromotionCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildPromotionCommand {
 	bpc.serverDetails = serverDetails
 	return bpc
@@ -55,9 +73,45 @@ func (bpc *BuildPromotionCommand) Run() error {
 		return err
 	}
 	bpc.BuildName, bpc.BuildNumber, bpc.ProjectKey = buildName, buildNumber, bpc.buildConfiguration.GetProject()
+	if bpc.policyFilePath != "" {
+		if err = bpc.evaluatePolicy(servicesManager); err != nil {
+			return err
+		}
+	}
 	return servicesManager.PromoteBuild(bpc.PromotionParams)
 }
 
+// Evaluates the promotion policy against the published build-info, and returns an error if any of its rules fails.
+func (bpc *BuildPromotionCommand) evaluatePolicy(servicesManager artifactory.ArtifactoryServicesManager) error {
+	policy, err := promotionpolicy.LoadPolicy(bpc.policyFilePath)
+	if err != nil {
+		return err
+	}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: bpc.BuildName, BuildNumber: bpc.BuildNumber, ProjectKey: bpc.ProjectKey})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorutils.CheckErrorf("build %s/%s was not found in Artifactory", bpc.BuildName, bpc.BuildNumber)
+	}
+	evaluator := promotionpolicy.NewEvaluator(policy).SetDependencyRepositoriesResolver(promotionpolicy.NewAqlDependencyRepositoriesResolver(servicesManager))
+	if bpc.issueStatusResolver != nil {
+		evaluator.SetIssueStatusResolver(bpc.issueStatusResolver)
+	}
+	report, err := evaluator.Evaluate(&publishedBuildInfo.BuildInfo)
+	if err != nil {
+		return err
+	}
+	if err = report.Print(); err != nil {
+		return err
+	}
+	if err = report.Error(); err != nil {
+		return err
+	}
+	log.Info("The build satisfies the promotion policy.")
+	return nil
+}
+
 func (bpc *BuildPromotionCommand) ServerDetails() (*config.ServerDetails, error) {
 	return bpc.serverDetails, nil
 }
@@ -0,0 +1,209 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const provenanceFileSuffix = ".intoto.jsonl"
+
+type BuildProvenanceCommand struct {
+	buildConfiguration *build.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	outputDir          string
+	signingKeyPath     string
+	keyId              string
+	deploy             bool
+	targetPath         string
+	dryRun             bool
+	provenanceFilePath string
+}
+
+func NewBuildProvenanceCommand() *BuildProvenanceCommand {
+	return &BuildProvenanceCommand{}
+}
+
+func (bpc *BuildProvenanceCommand) SetBuildConfiguration(buildConfiguration *build.BuildConfiguration) *BuildProvenanceCommand {
+	bpc.buildConfiguration = buildConfiguration
+	return bpc
+}
+
+func (bpc *BuildProvenanceCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildProvenanceCommand {
+	bpc.serverDetails = serverDetails
+	return bpc
+}
+
+// The directory in which the provenance file is written. Defaults to the current working directory.
+func (bpc *BuildProvenanceCommand) SetOutputDir(outputDir string) *BuildProvenanceCommand {
+	bpc.outputDir = outputDir
+	return bpc
+}
+
+// Path to a PEM encoded private key. If not provided, the DSSE envelope is left unsigned.
+func (bpc *BuildProvenanceCommand) SetSigningKeyPath(signingKeyPath string) *BuildProvenanceCommand {
+	bpc.signingKeyPath = signingKeyPath
+	return bpc
+}
+
+func (bpc *BuildProvenanceCommand) SetKeyId(keyId string) *BuildProvenanceCommand {
+	bpc.keyId = keyId
+	return bpc
+}
+
+// Deploy the provenance file to Artifactory, next to the build artifacts, or to the target path if provided.
+func (bpc *BuildProvenanceCommand) SetDeploy(deploy bool) *BuildProvenanceCommand {
+	bpc.deploy = deploy
+	return bpc
+}
+
+func (bpc *BuildProvenanceCommand) SetTargetPath(targetPath string) *BuildProvenanceCommand {
+	bpc.targetPath = targetPath
+	return bpc
+}
+
+func (bpc *BuildProvenanceCommand) SetDryRun(dryRun bool) *BuildProvenanceCommand {
+	bpc.dryRun = dryRun
+	return bpc
+}
+
+func (bpc *BuildProvenanceCommand) ProvenanceFilePath() string {
+	return bpc.provenanceFilePath
+}
+
+func (bpc *BuildProvenanceCommand) CommandName() string {
+	return "rt_build_provenance"
+}
+
+func (bpc *BuildProvenanceCommand) ServerDetails() (*config.ServerDetails, error) {
+	if bpc.serverDetails != nil {
+		return bpc.serverDetails, nil
+	}
+	return config.GetDefaultServerConf()
+}
+
+func (bpc *BuildProvenanceCommand) Run() error {
+	buildName, err := bpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := bpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	projectKey := bpc.buildConfiguration.GetProject()
+	log.Info("Generating SLSA provenance for", buildName+"/"+buildNumber+"...")
+	generalDetails, err := build.ReadBuildInfoGeneralDetails(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	partials, err := build.ReadPartialBuildInfoFiles(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	statement := build.NewProvenanceStatement(buildName, buildNumber, projectKey, generalDetails, partials)
+	if len(statement.Subject) == 0 {
+		return errorutils.CheckErrorf("no artifacts with a sha256 checksum were found for %s/%s. A provenance must have at least one subject", buildName, buildNumber)
+	}
+	envelope, err := build.CreateDsseEnvelope(statement)
+	if err != nil {
+		return err
+	}
+	if bpc.signingKeyPath != "" {
+		signer, err := build.ReadSigningKey(bpc.signingKeyPath)
+		if err != nil {
+			return err
+		}
+		if err = envelope.Sign(signer, bpc.keyId); err != nil {
+			return err
+		}
+	} else {
+		log.Warn("No signing key was provided. The provenance envelope will not be signed.")
+	}
+	if err = bpc.writeEnvelope(buildName, buildNumber, envelope); err != nil {
+		return err
+	}
+	log.Info("Provenance file written to", bpc.provenanceFilePath)
+	if !bpc.deploy {
+		return nil
+	}
+	return bpc.deployProvenance(partials)
+}
+
+// The provenance file is written in the JSON Lines format, holding a single DSSE envelope.
+func (bpc *BuildProvenanceCommand) writeEnvelope(buildName, buildNumber string, envelope *build.DsseEnvelope) error {
+	content, err := json.Marshal(envelope)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	outputDir := bpc.outputDir
+	if outputDir == "" {
+		if outputDir, err = os.Getwd(); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	if err = os.MkdirAll(outputDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	fileName := strings.ReplaceAll(buildName+"-"+buildNumber, "/", "_") + provenanceFileSuffix
+	bpc.provenanceFilePath = filepath.Join(outputDir, fileName)
+	return errorutils.CheckError(os.WriteFile(bpc.provenanceFilePath, append(content, '\n'), 0644))
+}
+
+func (bpc *BuildProvenanceCommand) deployProvenance(partials buildinfo.Partials) error {
+	target := bpc.targetPath
+	if target == "" {
+		target = getProvenanceTarget(partials)
+		if target == "" {
+			return errorutils.CheckErrorf("could not determine the deployment path of the build artifacts. Please provide a target path for the provenance file")
+		}
+	}
+	serverDetails, err := bpc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, bpc.dryRun)
+	if err != nil {
+		return err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.Pattern = bpc.provenanceFilePath
+	uploadParams.Target = target
+	uploadParams.Flat = true
+	log.Info("Deploying the provenance file to", target)
+	_, totalFailed, err := servicesManager.UploadFiles(uploadParams)
+	if err != nil {
+		return err
+	}
+	if totalFailed > 0 {
+		return errorutils.CheckErrorf("failed to deploy the provenance file to %s", target)
+	}
+	return nil
+}
+
+// Returns the directory of the first artifact which holds its deployment repository, in the <repo>/<path>/ format.
+func getProvenanceTarget(partials buildinfo.Partials) string {
+	for _, partial := range partials {
+		for _, artifact := range partial.Artifacts {
+			if artifact.OriginalDeploymentRepo == "" || artifact.Path == "" {
+				continue
+			}
+			dir := path.Dir(artifact.Path)
+			if dir == "." {
+				return artifact.OriginalDeploymentRepo + "/"
+			}
+			return path.Join(artifact.OriginalDeploymentRepo, dir) + "/"
+		}
+	}
+	return ""
+}
@@ -31,6 +31,7 @@ type BuildPublishCommand struct {
 	config             *biconf.Configuration
 	detailedSummary    bool
 	summary            *clientutils.Sha256Summary
+	offline            bool
 }
 
 func NewBuildPublishCommand() *BuildPublishCommand {
@@ -70,6 +71,17 @@ func (bpc *BuildPublishCommand) IsDetailedSummary() bool {
 	return bpc.detailedSummary
 }
 
+// In offline mode, if Artifactory is unreachable, the build-info is kept in the offline publish queue instead of failing.
+// The queued builds are published later by the BuildPublishQueueFlushCommand.
+func (bpc *BuildPublishCommand) SetOffline(offline bool) *BuildPublishCommand {
+	bpc.offline = offline
+	return bpc
+}
+
+func (bpc *BuildPublishCommand) IsOffline() bool {
+	return bpc.offline
+}
+
 func (bpc *BuildPublishCommand) CommandName() string {
 	return "rt_build_publish"
 }
@@ -116,8 +128,15 @@ func (bpc *BuildPublishCommand) Run() error {
 	if errorutils.CheckError(err) != nil {
 		return err
 	}
+	queueIfUnreachable := bpc.offline && !bpc.config.DryRun
+	if queueIfUnreachable && !utils.IsArtifactoryReachable(servicesManager) {
+		if err = bpc.queueBuildInfo(buildInfo, bpc.buildConfiguration.IsLoadedFromConfigFile()); err != nil {
+			return err
+		}
+		return build.Clean()
+	}
 	if bpc.buildConfiguration.IsLoadedFromConfigFile() {
-		buildInfo.Number, err = bpc.getNextBuildNumber(buildInfo.Name, servicesManager)
+		buildInfo.Number, err = getNextBuildNumber(buildInfo.Name, servicesManager)
 		if errorutils.CheckError(err) != nil {
 			return err
 		}
@@ -127,6 +146,13 @@ func (bpc *BuildPublishCommand) Run() error {
 	if bpc.IsDetailedSummary() {
 		bpc.SetSummary(summary)
 	}
+	if err != nil && queueIfUnreachable && !utils.IsArtifactoryReachable(servicesManager) {
+		log.Warn("Failed publishing the build info:", err.Error())
+		if err = bpc.queueBuildInfo(buildInfo, false); err != nil {
+			return err
+		}
+		return build.Clean()
+	}
 	if err != nil || bpc.config.DryRun {
 		return err
 	}
@@ -210,9 +236,27 @@ func (bpc *BuildPublishCommand) getBuildInfoUiUrl(majorVersion int, buildTime ti
 		baseUrl, buildName, buildNumber, strconv.FormatInt(timestamp, 10)), nil
 }
 
+func (bpc *BuildPublishCommand) queueBuildInfo(buildInfo *buildinfo.BuildInfo, useNextBuildNumber bool) error {
+	publishQueue, err := build.NewPublishQueue()
+	if err != nil {
+		return err
+	}
+	queuedBuild := &build.QueuedBuildPublish{
+		BuildInfo:          buildInfo,
+		Project:            bpc.buildConfiguration.GetProject(),
+		ServerId:           bpc.serverDetails.ServerId,
+		UseNextBuildNumber: useNextBuildNumber,
+	}
+	if err = publishQueue.Add(queuedBuild); err != nil {
+		return err
+	}
+	log.Warn("Artifactory is unreachable. The build info was saved to the offline publish queue at", publishQueue.Dir(), "and will be published once the queue is flushed.")
+	return nil
+}
+
 // Return the next build number based on the previously published build.
 // Return "1" if no build is found
-func (bpc *BuildPublishCommand) getNextBuildNumber(buildName string, servicesManager artifactory.ArtifactoryServicesManager) (string, error) {
+func getNextBuildNumber(buildName string, servicesManager artifactory.ArtifactoryServicesManager) (string, error) {
 	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(services.BuildInfoParams{BuildName: buildName, BuildNumber: artclientutils.LatestBuildNumberKey})
 	if err != nil {
 		return "", err
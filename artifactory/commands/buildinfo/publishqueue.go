@@ -0,0 +1,138 @@
+package buildinfo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Publishes the builds which were queued by the BuildPublishCommand in offline mode.
+type BuildPublishQueueFlushCommand struct {
+	serverDetails *config.ServerDetails
+	force         bool
+	result        BuildPublishQueueFlushResult
+}
+
+type BuildPublishQueueFlushResult struct {
+	Published int `json:"published"`
+	Failed    int `json:"failed"`
+	// Builds which were not retried, since their next attempt is not due yet.
+	Postponed int `json:"postponed"`
+}
+
+func NewBuildPublishQueueFlushCommand() *BuildPublishQueueFlushCommand {
+	return &BuildPublishQueueFlushCommand{}
+}
+
+// If set, all the queued builds are published using this server. Otherwise, each build is published to the server it was queued for.
+func (bpqfc *BuildPublishQueueFlushCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildPublishQueueFlushCommand {
+	bpqfc.serverDetails = serverDetails
+	return bpqfc
+}
+
+// Retry all the queued builds, ignoring the backoff delay.
+func (bpqfc *BuildPublishQueueFlushCommand) SetForce(force bool) *BuildPublishQueueFlushCommand {
+	bpqfc.force = force
+	return bpqfc
+}
+
+func (bpqfc *BuildPublishQueueFlushCommand) Result() BuildPublishQueueFlushResult {
+	return bpqfc.result
+}
+
+func (bpqfc *BuildPublishQueueFlushCommand) CommandName() string {
+	return "rt_build_publish_queue_flush"
+}
+
+func (bpqfc *BuildPublishQueueFlushCommand) ServerDetails() (*config.ServerDetails, error) {
+	if bpqfc.serverDetails != nil {
+		return bpqfc.serverDetails, nil
+	}
+	return config.GetDefaultServerConf()
+}
+
+func (bpqfc *BuildPublishQueueFlushCommand) Run() error {
+	publishQueue, err := build.NewPublishQueue()
+	if err != nil {
+		return err
+	}
+	queuedBuilds, err := publishQueue.List()
+	if err != nil {
+		return err
+	}
+	if len(queuedBuilds) == 0 {
+		log.Info("The offline publish queue is empty.")
+		return nil
+	}
+	now := time.Now()
+	servicesManagers := make(map[string]artifactory.ArtifactoryServicesManager)
+	for _, queuedBuild := range queuedBuilds {
+		buildString := queuedBuild.BuildInfo.Name + "/" + queuedBuild.BuildInfo.Number
+		if !bpqfc.force && !queuedBuild.IsDue(now) {
+			log.Info("Skipping", buildString+". The next attempt is due at", queuedBuild.NextAttempt.Format(time.RFC3339))
+			bpqfc.result.Postponed++
+			continue
+		}
+		if err = bpqfc.publish(queuedBuild, servicesManagers); err != nil {
+			log.Warn("Failed publishing", buildString+":", err.Error())
+			bpqfc.result.Failed++
+			queuedBuild.RecordFailure(err, now)
+			if err = publishQueue.Update(queuedBuild); err != nil {
+				return err
+			}
+			continue
+		}
+		log.Info("Published the queued build", buildString+".")
+		bpqfc.result.Published++
+		if err = publishQueue.Remove(queuedBuild); err != nil {
+			return err
+		}
+	}
+	log.Info("Published", bpqfc.result.Published, "queued builds.", bpqfc.result.Failed, "failed and", bpqfc.result.Postponed, "were postponed.")
+	if bpqfc.result.Failed > 0 {
+		return errors.New("some of the queued builds failed to be published. They will be retried on the next flush")
+	}
+	return nil
+}
+
+func (bpqfc *BuildPublishQueueFlushCommand) publish(queuedBuild *build.QueuedBuildPublish, servicesManagers map[string]artifactory.ArtifactoryServicesManager) (err error) {
+	servicesManager, err := bpqfc.getServicesManager(queuedBuild.ServerId, servicesManagers)
+	if err != nil {
+		return
+	}
+	if queuedBuild.UseNextBuildNumber {
+		if queuedBuild.BuildInfo.Number, err = getNextBuildNumber(queuedBuild.BuildInfo.Name, servicesManager); err != nil {
+			return
+		}
+	}
+	_, err = servicesManager.PublishBuildInfo(queuedBuild.BuildInfo, queuedBuild.Project)
+	return
+}
+
+// Services managers are cached by server ID, since queued builds usually share the same server.
+func (bpqfc *BuildPublishQueueFlushCommand) getServicesManager(serverId string, servicesManagers map[string]artifactory.ArtifactoryServicesManager) (artifactory.ArtifactoryServicesManager, error) {
+	serverDetails := bpqfc.serverDetails
+	if serverDetails != nil {
+		serverId = ""
+	}
+	if servicesManager, exists := servicesManagers[serverId]; exists {
+		return servicesManager, nil
+	}
+	if serverDetails == nil {
+		var err error
+		if serverDetails, err = config.GetSpecificConfig(serverId, true, false); err != nil {
+			return nil, err
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	servicesManagers[serverId] = servicesManager
+	return servicesManager, nil
+}
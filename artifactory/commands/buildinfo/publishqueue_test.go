@@ -0,0 +1,105 @@
+package buildinfo
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/common/build"
+	commontests "github.com/jfrog/jfrog-cli-core/v2/common/tests"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	biconf "github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPublishOfflineQueue(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	buildName, buildNumber := "offline-build-"+strconv.FormatInt(time.Now().UnixNano(), 10), "1"
+	assert.NoError(t, build.SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	assert.NoError(t, build.SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildinfo.Partial) {
+		partial.ModuleType = buildinfo.Generic
+		partial.Artifacts = []buildinfo.Artifact{{Name: "a.zip"}}
+	}))
+
+	// Publish while Artifactory is down. The build info should be queued.
+	testServer, serverDetails, _ := commontests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {})
+	testServer.Close()
+	publishCommand := NewBuildPublishCommand().SetServerDetails(serverDetails).SetConfig(new(biconf.Configuration)).
+		SetBuildConfiguration(build.NewBuildConfiguration(buildName, buildNumber, "", "")).SetOffline(true)
+	assert.NoError(t, publishCommand.Run())
+
+	publishQueue, err := build.NewPublishQueue()
+	assert.NoError(t, err)
+	queuedBuilds, err := publishQueue.List()
+	assert.NoError(t, err)
+	assert.Len(t, queuedBuilds, 1)
+	assert.Equal(t, buildName, queuedBuilds[0].BuildInfo.Name)
+	assert.Len(t, queuedBuilds[0].BuildInfo.Modules, 1)
+	// The local build should be cleaned, as it is kept in the queue.
+	_, err = build.ReadBuildInfoGeneralDetails(buildName, buildNumber, "")
+	assert.Error(t, err)
+
+	// Queuing the same build again should not duplicate it.
+	assert.NoError(t, publishQueue.Add(queuedBuilds[0]))
+	queuedBuilds, err = publishQueue.List()
+	assert.NoError(t, err)
+	assert.Len(t, queuedBuilds, 1)
+
+	// Flush while publishing fails. The build should stay in the queue, and be postponed.
+	var published atomic.Int32
+	failPublish := atomic.Bool{}
+	failPublish.Store(true)
+	testServer, serverDetails, _ = commontests.CreateRtRestsMockServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/api/build" {
+			if failPublish.Load() {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			published.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	defer testServer.Close()
+	flushCommand := NewBuildPublishQueueFlushCommand().SetServerDetails(serverDetails)
+	assert.Error(t, flushCommand.Run())
+	assert.Equal(t, BuildPublishQueueFlushResult{Failed: 1}, flushCommand.Result())
+	queuedBuilds, err = publishQueue.List()
+	assert.NoError(t, err)
+	assert.Len(t, queuedBuilds, 1)
+	assert.Equal(t, 1, queuedBuilds[0].Attempts)
+	assert.True(t, queuedBuilds[0].NextAttempt.After(time.Now()))
+
+	failPublish.Store(false)
+	flushCommand = NewBuildPublishQueueFlushCommand().SetServerDetails(serverDetails)
+	assert.NoError(t, flushCommand.Run())
+	assert.Equal(t, BuildPublishQueueFlushResult{Postponed: 1}, flushCommand.Result())
+
+	// Force flush, ignoring the backoff.
+	flushCommand = NewBuildPublishQueueFlushCommand().SetServerDetails(serverDetails).SetForce(true)
+	assert.NoError(t, flushCommand.Run())
+	assert.Equal(t, BuildPublishQueueFlushResult{Published: 1}, flushCommand.Result())
+	assert.Equal(t, int32(1), published.Load())
+	queuedBuilds, err = publishQueue.List()
+	assert.NoError(t, err)
+	assert.Empty(t, queuedBuilds)
+}
+
+func TestQueuedBuildPublishBackoff(t *testing.T) {
+	now := time.Now()
+	queuedBuild := &build.QueuedBuildPublish{BuildInfo: &buildinfo.BuildInfo{Name: "name", Number: "1"}}
+	assert.True(t, queuedBuild.IsDue(now))
+	expectedDelays := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute}
+	for _, expectedDelay := range expectedDelays {
+		queuedBuild.RecordFailure(assert.AnError, now)
+		assert.Equal(t, now.Add(expectedDelay), queuedBuild.NextAttempt)
+		assert.False(t, queuedBuild.IsDue(now))
+	}
+	for i := 0; i < 20; i++ {
+		queuedBuild.RecordFailure(assert.AnError, now)
+	}
+	assert.Equal(t, now.Add(6*time.Hour), queuedBuild.NextAttempt)
+	assert.Equal(t, assert.AnError.Error(), queuedBuild.LastError)
+}
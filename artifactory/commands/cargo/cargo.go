@@ -0,0 +1,265 @@
+package cargo
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/cargo"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const publishCmdName = "publish"
+
+// cargo commands which resolve the dependencies listed in Cargo.lock.
+var dependenciesResolutionCommands = []string{"build", "b", "check", "c", "test", "t", "run", "r", "fetch", publishCmdName}
+
+// Runs cargo with Artifactory as the crates registry, and collects the build-info dependencies from Cargo.lock.
+type CargoCommand struct {
+	cmdName            string
+	cargoArgs          []string
+	configFilePath     string
+	buildConfiguration *buildUtils.BuildConfiguration
+	resolverRepo       string
+	resolverDetails    *config.ServerDetails
+	deployerRepo       string
+	deployerDetails    *config.ServerDetails
+	executablePath     string
+	workingDirectory   string
+}
+
+func NewCargoCommand(cmdName string) *CargoCommand {
+	return &CargoCommand{cmdName: cmdName}
+}
+
+func (cc *CargoCommand) SetArgs(args []string) *CargoCommand {
+	cc.cargoArgs = args
+	return cc
+}
+
+func (cc *CargoCommand) SetConfigFilePath(configFilePath string) *CargoCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *CargoCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *CargoCommand {
+	cc.buildConfiguration = buildConfiguration
+	return cc
+}
+
+func (cc *CargoCommand) SetResolver(repo string, serverDetails *config.ServerDetails) *CargoCommand {
+	cc.resolverRepo = repo
+	cc.resolverDetails = serverDetails
+	return cc
+}
+
+func (cc *CargoCommand) SetDeployer(repo string, serverDetails *config.ServerDetails) *CargoCommand {
+	cc.deployerRepo = repo
+	cc.deployerDetails = serverDetails
+	return cc
+}
+
+func (cc *CargoCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.cmdName == publishCmdName {
+		return cc.deployerDetails, nil
+	}
+	return cc.resolverDetails, nil
+}
+
+func (cc *CargoCommand) CommandName() string {
+	return "rt_cargo_" + cc.cmdName
+}
+
+// Reads the resolver and deployer details from the config file, and extracts the JFrog CLI options from the cargo args.
+// The deployer is required by 'cargo publish' only, and the resolver is optional for it.
+func (cc *CargoCommand) Init() error {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	resolverConfig, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	var missingResolverErr *project.MissingResolverErr
+	switch {
+	case err == nil:
+		cc.resolverRepo = resolverConfig.TargetRepo()
+		if cc.resolverDetails, err = resolverConfig.ServerDetails(); err != nil {
+			return err
+		}
+	case cc.cmdName != publishCmdName || !errors.As(err, &missingResolverErr):
+		return err
+	}
+	if cc.cmdName == publishCmdName {
+		deployerConfig, err := project.GetRepoConfigByPrefix(cc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+		if err != nil {
+			return err
+		}
+		cc.deployerRepo = deployerConfig.TargetRepo()
+		if cc.deployerDetails, err = deployerConfig.ServerDetails(); err != nil {
+			return err
+		}
+	}
+	_, _, _, cc.cargoArgs, cc.buildConfiguration, err = commandUtils.ExtractNpmOptionsFromArgs(cc.cargoArgs)
+	return err
+}
+
+func (cc *CargoCommand) Run() (err error) {
+	log.Info("Running cargo " + cc.cmdName + "...")
+	if cc.executablePath, err = exec.LookPath("cargo"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if cc.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	registries, env, err := cc.prepareRegistries()
+	if err != nil {
+		return
+	}
+	restoreConfigFunc, err := createTempCargoConfig(cc.workingDirectory, registries)
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, restoreConfigFunc())
+	}()
+	if err = cc.runCargo(env); err != nil {
+		return
+	}
+	log.Info("cargo " + cc.cmdName + " finished successfully.")
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return
+	}
+	if !slices.Contains(dependenciesResolutionCommands, cc.cmdName) {
+		log.Debug("Build-info dependencies are not collected by 'cargo " + cc.cmdName + "'.")
+		return
+	}
+	return cc.collectBuildInfo()
+}
+
+// Returns the registries to configure, and the environment variables which pass their credentials to cargo.
+func (cc *CargoCommand) prepareRegistries() (registries cargoRegistries, env []string, err error) {
+	for _, registry := range []struct {
+		name          string
+		repo          string
+		serverDetails *config.ServerDetails
+		index         *string
+	}{
+		{resolverRegistryName, cc.resolverRepo, cc.resolverDetails, &registries.resolverIndex},
+		{deployerRegistryName, cc.deployerRepo, cc.deployerDetails, &registries.deployerIndex},
+	} {
+		if registry.repo == "" || registry.serverDetails == nil {
+			continue
+		}
+		authDetails, err := registry.serverDetails.CreateArtAuthConfig()
+		if err != nil {
+			return registries, nil, err
+		}
+		token, err := registryToken(authDetails)
+		if err != nil {
+			return registries, nil, err
+		}
+		*registry.index = registryIndexUrl(authDetails.GetUrl(), registry.repo)
+		env = append(env, registryTokenEnvVar(registry.name)+"="+token)
+	}
+	return
+}
+
+func (cc *CargoCommand) runCargo(env []string) error {
+	command := exec.Command(cc.executablePath, append([]string{cc.cmdName}, cc.cargoArgs...)...)
+	command.Dir = cc.workingDirectory
+	command.Env = append(os.Environ(), env...)
+	command.Stdout = os.Stderr
+	command.Stderr = os.Stderr
+	log.Debug("Running:", cc.executablePath, cc.cmdName, strings.Join(cc.cargoArgs, " "))
+	err := command.Run()
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		err = errors.New(err.Error())
+	}
+	return errorutils.CheckError(err)
+}
+
+// Adds a build-info module for each crate of the workspace, with the dependencies listed in Cargo.lock.
+// Crates published by 'cargo publish' are added as the artifacts of their modules.
+func (cc *CargoCommand) collectBuildInfo() error {
+	log.Info("Collecting build-info dependencies from " + cargo.LockfileName + "...")
+	if _, err := buildUtils.PrepareBuildPrerequisites(cc.buildConfiguration); err != nil {
+		return err
+	}
+	lockfile, err := cargo.ReadLockfile(cc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	modules, err := lockfile.GetModules()
+	if err != nil {
+		return err
+	}
+	buildName, err := cc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := cc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	for _, module := range modules {
+		var artifacts []entities.Artifact
+		if cc.cmdName == publishCmdName {
+			if artifacts, err = cc.getPublishedCrate(module.Id); err != nil {
+				return err
+			}
+		}
+		moduleId := module.Id
+		if len(modules) == 1 && cc.buildConfiguration.GetModule() != "" {
+			moduleId = cc.buildConfiguration.GetModule()
+		}
+		log.Debug("Collected", len(module.Dependencies), "dependencies of module", moduleId)
+		err = buildUtils.SavePartialBuildInfo(buildName, buildNumber, cc.buildConfiguration.GetProject(), func(partial *entities.Partial) {
+			partial.ModuleId = moduleId
+			partial.ModuleType = cargo.ModuleType
+			partial.Dependencies = module.Dependencies
+			partial.Artifacts = artifacts
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the crate packaged by 'cargo publish' for the module, if it exists in the target directory.
+func (cc *CargoCommand) getPublishedCrate(moduleId string) ([]entities.Artifact, error) {
+	name, version, _ := strings.Cut(moduleId, ":")
+	crateFileName := name + "-" + version + ".crate"
+	targetDir := os.Getenv("CARGO_TARGET_DIR")
+	if targetDir == "" {
+		targetDir = filepath.Join(cc.workingDirectory, "target")
+	}
+	cratePath := filepath.Join(targetDir, "package", crateFileName)
+	exists, err := fileutils.IsFileExists(cratePath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	details, err := fileutils.GetFileDetails(cratePath, true)
+	if err != nil {
+		return nil, err
+	}
+	return []entities.Artifact{{
+		Name:                   crateFileName,
+		Type:                   "crate",
+		Path:                   "crates/" + name + "/" + crateFileName,
+		OriginalDeploymentRepo: cc.deployerRepo,
+		Checksum:               details.Checksum,
+	}}, nil
+}
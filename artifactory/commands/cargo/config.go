@@ -0,0 +1,139 @@
+package cargo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/auth"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	cargoConfigDir            = ".cargo"
+	cargoConfigFileName       = "config.toml"
+	cargoLegacyConfigFileName = "config"
+	cargoConfigBackupFileName = "jfrog.config.toml.backup"
+
+	resolverRegistryName = "artifactory"
+	deployerRegistryName = "artifactory-deploy"
+)
+
+// Cargo reads credentials.toml only from CARGO_HOME, so the registries credentials are passed through the environment of the cargo process instead.
+func registryTokenEnvVar(registryName string) string {
+	return "CARGO_REGISTRIES_" + strings.ToUpper(strings.ReplaceAll(registryName, "-", "_")) + "_TOKEN"
+}
+
+// Returns the sparse index URL of a Cargo repository in Artifactory.
+func registryIndexUrl(artifactoryUrl, repo string) string {
+	return "sparse+" + strings.TrimSuffix(artifactoryUrl, "/") + "/api/cargo/" + repo + "/index/"
+}
+
+// Returns the token cargo sends to Artifactory in the Authorization header.
+func registryToken(authDetails auth.ServiceDetails) (string, error) {
+	if authDetails.GetAccessToken() != "" {
+		return "Bearer " + authDetails.GetAccessToken(), nil
+	}
+	if authDetails.GetUser() != "" && authDetails.GetPassword() != "" {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(authDetails.GetUser()+":"+authDetails.GetPassword())), nil
+	}
+	return "", errorutils.CheckErrorf("cargo requires credentials to access Artifactory. Configure an access token or a username and password for the server")
+}
+
+// The Artifactory registries to configure in the cargo config.
+type cargoRegistries struct {
+	// The index URL of the repository from which crates are resolved.
+	resolverIndex string
+	// The index URL of the repository to which crates are published. Empty if crates are not published.
+	deployerIndex string
+}
+
+// Returns the cargo config content, which replaces crates.io with the Artifactory resolution repository,
+// and sets the Artifactory deployment repository, if any, as the default registry for publishing.
+// The existing configurations are kept, except for the ones overridden by this function.
+func createCargoConfigContent(existingConfig string, registries cargoRegistries) (string, error) {
+	cargoConfig := make(map[string]any)
+	if _, err := toml.Decode(existingConfig, &cargoConfig); err != nil {
+		return "", errorutils.CheckErrorf("failed to parse the existing cargo config: %s", err.Error())
+	}
+	registriesSection := getSection(cargoConfig, "registries")
+	registrySection := getSection(cargoConfig, "registry")
+	sourceSection := getSection(cargoConfig, "source")
+	if registries.resolverIndex != "" {
+		registriesSection[resolverRegistryName] = map[string]any{"index": registries.resolverIndex}
+		// Cargo allows replacing crates.io with a registry defined in the registries section.
+		sourceSection["crates-io"] = map[string]any{"replace-with": resolverRegistryName}
+		registrySection["default"] = resolverRegistryName
+	}
+	if registries.deployerIndex != "" {
+		registriesSection[deployerRegistryName] = map[string]any{"index": registries.deployerIndex}
+		registrySection["default"] = deployerRegistryName
+	}
+	registrySection["global-credential-providers"] = []string{"cargo:token"}
+	var content bytes.Buffer
+	if err := toml.NewEncoder(&content).Encode(cargoConfig); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return content.String(), nil
+}
+
+func getSection(cargoConfig map[string]any, name string) map[string]any {
+	if section, ok := cargoConfig[name].(map[string]any); ok {
+		return section
+	}
+	section := make(map[string]any)
+	cargoConfig[name] = section
+	return section
+}
+
+// Creates a temporary cargo config in the working directory, which configures Artifactory as the cargo registry.
+// Returns a function which restores the original cargo config.
+func createTempCargoConfig(workingDirectory string, registries cargoRegistries) (restoreConfigFunc func() error, err error) {
+	configDir := filepath.Join(workingDirectory, cargoConfigDir)
+	createdConfigDir := false
+	if _, err = os.Stat(configDir); os.IsNotExist(err) {
+		if err = os.Mkdir(configDir, 0755); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		createdConfigDir = true
+	} else if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	// Cargo prefers the legacy config file name if both files exist.
+	configPath := filepath.Join(configDir, cargoConfigFileName)
+	if _, err = os.Stat(filepath.Join(configDir, cargoLegacyConfigFileName)); err == nil {
+		configPath = filepath.Join(configDir, cargoLegacyConfigFileName)
+	}
+	existingConfig, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errorutils.CheckError(err)
+	}
+	restoreFileFunc, err := ioutils.BackupFile(configPath, cargoConfigBackupFileName)
+	if err != nil {
+		return nil, err
+	}
+	restoreConfigFunc = func() error {
+		if err := restoreFileFunc(); err != nil {
+			return err
+		}
+		if createdConfigDir {
+			return errorutils.CheckError(os.Remove(configDir))
+		}
+		return nil
+	}
+	content, err := createCargoConfigContent(string(existingConfig), registries)
+	if err != nil {
+		return nil, errors.Join(err, restoreConfigFunc())
+	}
+	log.Debug("Creating temporary cargo config file:", configPath)
+	if err = os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		return nil, errors.Join(errorutils.CheckError(err), restoreConfigFunc())
+	}
+	return restoreConfigFunc, nil
+}
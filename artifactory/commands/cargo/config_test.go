@@ -0,0 +1,46 @@
+package cargo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCargoConfigContent(t *testing.T) {
+	existingConfig := `[build]
+jobs = 4
+
+[registry]
+default = "crates-io"
+
+[source.crates-io]
+replace-with = "vendored"
+`
+	content, err := createCargoConfigContent(existingConfig, cargoRegistries{
+		resolverIndex: "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/",
+		deployerIndex: "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-local/index/",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `[build]
+  jobs = 4
+
+[registries]
+  [registries.artifactory]
+    index = "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/"
+  [registries.artifactory-deploy]
+    index = "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-local/index/"
+
+[registry]
+  default = "artifactory-deploy"
+  global-credential-providers = ["cargo:token"]
+
+[source]
+  [source.crates-io]
+    replace-with = "artifactory"
+`, content)
+}
+
+func TestRegistryTokenEnvVar(t *testing.T) {
+	assert.Equal(t, "CARGO_REGISTRIES_ARTIFACTORY_DEPLOY_TOKEN", registryTokenEnvVar(deployerRegistryName))
+}
@@ -0,0 +1,182 @@
+package cleanup
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"golang.org/x/exp/maps"
+)
+
+// A file to delete, and the rule of the policy which selected it.
+type Candidate struct {
+	servicesutils.ResultItem
+	Policy string
+	Reason string
+}
+
+// The result of evaluating a policy.
+type evaluation struct {
+	candidates []Candidate
+	// The number of files which were kept because they are referenced by a build or have a retain property.
+	protected int
+	// The number of files which aren't deleted.
+	kept int
+	// Repositories which still exceed the maximum size after the cleanup.
+	oversizedRepos []string
+}
+
+// A file of the policy, with the details required to evaluate it.
+type policyFile struct {
+	item        *servicesutils.ResultItem
+	created     time.Time
+	versionRank int
+	protected   bool
+	candidate   bool
+}
+
+// Evaluates the policy on the files which match its pattern.
+// buildReferenced holds the relative paths of the files which are the artifacts or dependencies of a build.
+func evaluatePolicy(policy *Policy, items []servicesutils.ResultItem, buildReferenced map[string]bool, now time.Time) *evaluation {
+	result := new(evaluation)
+	var files []*policyFile
+	for i := range items {
+		if items[i].Type == "folder" {
+			continue
+		}
+		file := &policyFile{item: &items[i], created: parseTime(items[i].Created)}
+		file.protected = (policy.keepBuildArtifacts() && buildReferenced[items[i].GetItemRelativePath()]) || hasRetainProperty(&items[i], policy.RetainProperties)
+		files = append(files, file)
+	}
+	rankVersions(files)
+	for _, file := range files {
+		if file.protected {
+			result.protected++
+			continue
+		}
+		reason := getRuleReason(policy, file, now)
+		if reason == "" {
+			continue
+		}
+		file.candidate = true
+		result.candidates = append(result.candidates, Candidate{ResultItem: *file.item, Policy: policy.Name, Reason: reason})
+	}
+	if policy.maxRepoSizeBytes > 0 {
+		result.candidates, result.oversizedRepos = applyMaxRepoSize(policy, files, result.candidates)
+	}
+	result.kept = len(files) - len(result.candidates)
+	return result
+}
+
+// Returns the reason to delete the file according to the age and versions rules, or an empty string if it should be kept.
+func getRuleReason(policy *Policy, file *policyFile, now time.Time) string {
+	if policy.OlderThanDays == 0 && policy.KeepLastVersions == 0 {
+		return ""
+	}
+	if policy.OlderThanDays > 0 && (file.created.IsZero() || !file.created.Before(now.AddDate(0, 0, -policy.OlderThanDays))) {
+		return ""
+	}
+	if policy.KeepLastVersions > 0 && file.versionRank < policy.KeepLastVersions {
+		return ""
+	}
+	if policy.OlderThanDays > 0 {
+		return fmt.Sprintf("older than %d days", policy.OlderThanDays)
+	}
+	return fmt.Sprintf("not in the last %d versions", policy.KeepLastVersions)
+}
+
+// Deletes the oldest files of each repository which exceeds the maximum size.
+// The last versions, the protected files and the files without a valid creation time are kept.
+func applyMaxRepoSize(policy *Policy, files []*policyFile, candidates []Candidate) ([]Candidate, []string) {
+	repoSizes := make(map[string]int64)
+	reposFiles := make(map[string][]*policyFile)
+	for _, file := range files {
+		if file.candidate {
+			continue
+		}
+		repoSizes[file.item.Repo] += file.item.Size
+		if !file.protected && !file.created.IsZero() && (policy.KeepLastVersions == 0 || file.versionRank >= policy.KeepLastVersions) {
+			reposFiles[file.item.Repo] = append(reposFiles[file.item.Repo], file)
+		}
+	}
+	var oversizedRepos []string
+	repos := maps.Keys(repoSizes)
+	slices.Sort(repos)
+	for _, repo := range repos {
+		if repoSizes[repo] <= policy.maxRepoSizeBytes {
+			continue
+		}
+		repoFiles := reposFiles[repo]
+		sort.SliceStable(repoFiles, func(i, j int) bool {
+			return repoFiles[i].created.Before(repoFiles[j].created)
+		})
+		for _, file := range repoFiles {
+			if repoSizes[repo] <= policy.maxRepoSizeBytes {
+				break
+			}
+			file.candidate = true
+			repoSizes[repo] -= file.item.Size
+			candidates = append(candidates, Candidate{ResultItem: *file.item, Policy: policy.Name, Reason: "repository size over " + policy.MaxRepoSize})
+		}
+		if repoSizes[repo] > policy.maxRepoSizeBytes {
+			oversizedRepos = append(oversizedRepos, repo)
+		}
+	}
+	return candidates, oversizedRepos
+}
+
+// Sets the rank of the version of each file in its package, where 0 is the newest version.
+// The version of a file is its directory, and the package is the parent directory of the version.
+func rankVersions(files []*policyFile) {
+	packages := make(map[string]map[string]time.Time)
+	for _, file := range files {
+		packagePath, version := getPackageAndVersion(file.item)
+		if packages[packagePath] == nil {
+			packages[packagePath] = make(map[string]time.Time)
+		}
+		if newest, ok := packages[packagePath][version]; !ok || file.created.After(newest) {
+			packages[packagePath][version] = file.created
+		}
+	}
+	ranks := make(map[string]map[string]int, len(packages))
+	for packagePath, versions := range packages {
+		names := maps.Keys(versions)
+		slices.Sort(names)
+		sort.SliceStable(names, func(i, j int) bool {
+			return versions[names[i]].After(versions[names[j]])
+		})
+		ranks[packagePath] = make(map[string]int, len(names))
+		for rank, name := range names {
+			ranks[packagePath][name] = rank
+		}
+	}
+	for _, file := range files {
+		packagePath, version := getPackageAndVersion(file.item)
+		file.versionRank = ranks[packagePath][version]
+	}
+}
+
+func getPackageAndVersion(item *servicesutils.ResultItem) (packagePath, version string) {
+	versionPath := path.Join(item.Repo, item.Path)
+	return path.Dir(versionPath), path.Base(versionPath)
+}
+
+func hasRetainProperty(item *servicesutils.ResultItem, retainProperties map[string]string) bool {
+	for _, property := range item.Properties {
+		if value, ok := retainProperties[property.Key]; ok && (value == "" || value == property.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseTime(timestamp string) time.Time {
+	parsedTime, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsedTime
+}
@@ -0,0 +1,253 @@
+package cleanup
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Finds the files to delete according to cleanup policies, and deletes them after a confirmation.
+type CleanupCommand struct {
+	serverDetails          *config.ServerDetails
+	policiesFilePath       string
+	threads                int
+	retries                int
+	retryWaitTimeMilliSecs int
+	dryRun                 bool
+	quiet                  bool
+	result                 *commandsutils.Result
+}
+
+func NewCleanupCommand() *CleanupCommand {
+	return &CleanupCommand{threads: 3, result: new(commandsutils.Result)}
+}
+
+func (cc *CleanupCommand) CommandName() string {
+	return "rt_cleanup"
+}
+
+func (cc *CleanupCommand) ServerDetails() (*config.ServerDetails, error) {
+	return cc.serverDetails, nil
+}
+
+func (cc *CleanupCommand) SetServerDetails(serverDetails *config.ServerDetails) *CleanupCommand {
+	cc.serverDetails = serverDetails
+	return cc
+}
+
+func (cc *CleanupCommand) SetPoliciesFilePath(policiesFilePath string) *CleanupCommand {
+	cc.policiesFilePath = policiesFilePath
+	return cc
+}
+
+func (cc *CleanupCommand) SetThreads(threads int) *CleanupCommand {
+	cc.threads = threads
+	return cc
+}
+
+func (cc *CleanupCommand) SetRetries(retries int) *CleanupCommand {
+	cc.retries = retries
+	return cc
+}
+
+func (cc *CleanupCommand) SetRetryWaitMilliSecs(retryWaitMilliSecs int) *CleanupCommand {
+	cc.retryWaitTimeMilliSecs = retryWaitMilliSecs
+	return cc
+}
+
+// Reports the files which would have been deleted, without deleting them.
+func (cc *CleanupCommand) SetDryRun(dryRun bool) *CleanupCommand {
+	cc.dryRun = dryRun
+	return cc
+}
+
+// Deletes the files without a confirmation.
+func (cc *CleanupCommand) SetQuiet(quiet bool) *CleanupCommand {
+	cc.quiet = quiet
+	return cc
+}
+
+func (cc *CleanupCommand) Result() *commandsutils.Result {
+	return cc.result
+}
+
+type policyRow struct {
+	Policy    string `col-name:"Policy"`
+	Pattern   string `col-name:"Pattern"`
+	Matched   string `col-name:"Files"`
+	Protected string `col-name:"Protected"`
+	ToDelete  string `col-name:"To Delete"`
+	Size      string `col-name:"Size To Delete (Bytes)"`
+}
+
+type candidateRow struct {
+	Path    string `col-name:"Path"`
+	Size    string `col-name:"Size (Bytes)"`
+	Created string `col-name:"Created"`
+	Policy  string `col-name:"Policy"`
+	Reason  string `col-name:"Reason"`
+}
+
+func (cc *CleanupCommand) Run() (err error) {
+	policiesFile, err := ReadPoliciesFile(cc.policiesFilePath)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(cc.serverDetails, cc.retries, cc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	candidates, err := cc.findCandidates(servicesManager, policiesFile.Policies)
+	if err != nil {
+		return err
+	}
+	if cc.dryRun {
+		return printCandidates(candidates)
+	}
+	if len(candidates) == 0 {
+		log.Info("No files to delete were found.")
+		return nil
+	}
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	for _, candidate := range candidates {
+		writer.Write(candidate.ResultItem)
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), writer.GetArrayKey())
+	defer ioutils.Close(reader, &err)
+	if !cc.quiet {
+		confirmed, err := utils.ConfirmDelete(reader)
+		if err != nil || !confirmed {
+			return err
+		}
+	}
+	deleteServicesManager, err := utils.CreateDeleteServiceManager(cc.serverDetails, cc.threads, cc.retries, cc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	deletedCount, err := deleteServicesManager.DeleteFiles(reader)
+	cc.result.SetSuccessCount(deletedCount)
+	cc.result.SetFailCount(len(candidates) - deletedCount)
+	log.Info(fmt.Sprintf("Cleanup finished: %d files were deleted, %d failed.", deletedCount, len(candidates)-deletedCount))
+	return err
+}
+
+// Evaluates the policies by their order. A file which matches more than one policy is reported by the first one which deletes it.
+func (cc *CleanupCommand) findCandidates(servicesManager artifactory.ArtifactoryServicesManager, policies []*Policy) ([]Candidate, error) {
+	now := time.Now()
+	var candidates []Candidate
+	var rows []policyRow
+	found := make(map[string]bool)
+	for _, policy := range policies {
+		log.Info(fmt.Sprintf("Evaluating the cleanup policy '%s'...", policy.Name))
+		items, err := searchPolicyFiles(servicesManager, policy)
+		if err != nil {
+			return nil, err
+		}
+		buildReferenced := make(map[string]bool)
+		if policy.keepBuildArtifacts() {
+			if buildReferenced, err = getBuildReferencedPaths(servicesManager, items); err != nil {
+				return nil, err
+			}
+		}
+		result := evaluatePolicy(policy, items, buildReferenced, now)
+		for _, repo := range result.oversizedRepos {
+			log.Warn(fmt.Sprintf("The files of the policy '%s' in the repository '%s' exceed %s even after the cleanup, since the rest of them are protected or in the last versions.", policy.Name, repo, policy.MaxRepoSize))
+		}
+		var toDelete int
+		var size int64
+		for _, candidate := range result.candidates {
+			if found[candidate.GetItemRelativePath()] {
+				continue
+			}
+			found[candidate.GetItemRelativePath()] = true
+			candidates = append(candidates, candidate)
+			toDelete++
+			size += candidate.Size
+		}
+		rows = append(rows, policyRow{
+			Policy:    policy.Name,
+			Pattern:   policy.Pattern,
+			Matched:   strconv.Itoa(result.kept + len(result.candidates)),
+			Protected: strconv.Itoa(result.protected),
+			ToDelete:  strconv.Itoa(toDelete),
+			Size:      strconv.FormatInt(size, 10),
+		})
+	}
+	return candidates, coreutils.PrintTable(rows, "Cleanup Policies", "", false)
+}
+
+func searchPolicyFiles(servicesManager artifactory.ArtifactoryServicesManager, policy *Policy) (items []servicesutils.ResultItem, err error) {
+	searchSpec := spec.NewBuilder().Pattern(policy.Pattern).Exclusions(policy.Exclusions).Recursive(true).BuildSpec()
+	readers, closeReaders, err := utils.SearchFiles(servicesManager, searchSpec)
+	defer func() {
+		err = errors.Join(err, closeReaders())
+	}()
+	if err != nil {
+		return nil, err
+	}
+	for _, reader := range readers {
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			items = append(items, *item)
+		}
+		if err = reader.GetError(); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// Returns the relative paths of the files of the items which are the artifacts or dependencies of a build.
+// The files are searched by their paths in batches, so that only the build references of the items are fetched.
+func getBuildReferencedPaths(servicesManager artifactory.ArtifactoryServicesManager, items []servicesutils.ResultItem) (map[string]bool, error) {
+	var itemPaths []string
+	for _, item := range items {
+		if item.Type != "folder" {
+			itemPaths = append(itemPaths, item.GetItemRelativePath())
+		}
+	}
+	buildCriterion := map[string]any{"$or": []map[string]any{
+		{"artifact.module.build.name": map[string]string{"$match": "*"}},
+		{"dependency.module.build.name": map[string]string{"$match": "*"}},
+	}}
+	referencedItems, err := utils.SearchItemsByPaths(servicesManager, itemPaths, buildCriterion)
+	if err != nil {
+		return nil, err
+	}
+	paths := make(map[string]bool, len(referencedItems))
+	for itemPath := range referencedItems {
+		paths[itemPath] = true
+	}
+	return paths, nil
+}
+
+func printCandidates(candidates []Candidate) error {
+	var rows []candidateRow
+	for _, candidate := range candidates {
+		rows = append(rows, candidateRow{
+			Path:    candidate.GetItemRelativePath(),
+			Size:    strconv.FormatInt(candidate.Size, 10),
+			Created: candidate.Created,
+			Policy:  candidate.Policy,
+			Reason:  candidate.Reason,
+		})
+	}
+	return coreutils.PrintTable(rows, "Files To Delete (dry run)", "No files to delete were found", false)
+}
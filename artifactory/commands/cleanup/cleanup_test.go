@@ -0,0 +1,203 @@
+package cleanup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestReadPoliciesFile(t *testing.T) {
+	policiesPath := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(policiesPath, []byte(`policies:
+  - name: snapshots
+    pattern: libs-snapshot-local/*
+    keepLastVersions: 3
+    maxRepoSize: 1.5GB
+  - name: temp
+    pattern: temp-local/*
+    olderThanDays: 7
+    keepBuildArtifacts: false
+    retainProperties:
+      keep: ""
+`), 0644))
+	policiesFile, err := ReadPoliciesFile(policiesPath)
+	require.NoError(t, err)
+	require.Len(t, policiesFile.Policies, 2)
+
+	snapshots := policiesFile.Policies[0]
+	assert.Equal(t, int64(3<<29), snapshots.maxRepoSizeBytes)
+	assert.True(t, snapshots.keepBuildArtifacts())
+	assert.Equal(t, map[string]string{"retain": "true"}, snapshots.RetainProperties)
+
+	temp := policiesFile.Policies[1]
+	assert.False(t, temp.keepBuildArtifacts())
+	assert.Equal(t, map[string]string{"keep": ""}, temp.RetainProperties)
+}
+
+func TestReadPoliciesFileUnknownKey(t *testing.T) {
+	policiesPath := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(policiesPath, []byte(`policies:
+  - name: snapshots
+    pattern: libs-snapshot-local/*
+    olderThanDays: 30
+    keepLastVersion: 5
+`), 0644))
+	_, err := ReadPoliciesFile(policiesPath)
+	assert.ErrorContains(t, err, "field keepLastVersion not found")
+}
+
+func TestValidatePoliciesFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		policies      []*Policy
+		expectedError string
+	}{
+		{"no policies", nil, "no policies are listed"},
+		{"missing name", []*Policy{{Pattern: "a/*", OlderThanDays: 1}}, "the name of policy #1 is missing"},
+		{"duplicate name", []*Policy{{Name: "a", Pattern: "a/*", OlderThanDays: 1}, {Name: "a", Pattern: "b/*", OlderThanDays: 1}}, "used more than once"},
+		{"missing pattern", []*Policy{{Name: "a", OlderThanDays: 1}}, "the pattern is missing"},
+		{"no rules", []*Policy{{Name: "a", Pattern: "a/*"}}, "at least one of"},
+		{"negative days", []*Policy{{Name: "a", Pattern: "a/*", OlderThanDays: -1}}, "can't be negative"},
+		{"invalid size", []*Policy{{Name: "a", Pattern: "a/*", MaxRepoSize: "big"}}, "invalid size 'big'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := (&PoliciesFile{Policies: test.policies}).validate()
+			assert.ErrorContains(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		size     string
+		expected int64
+	}{
+		{"100", 100},
+		{"100B", 100},
+		{"2KB", 2048},
+		{"500 mb", 500 << 20},
+		{"0.5GB", 1 << 29},
+		{"1TB", 1 << 40},
+	}
+	for _, test := range tests {
+		t.Run(test.size, func(t *testing.T) {
+			size, err := parseSize(test.size)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, size)
+		})
+	}
+	for _, size := range []string{"", "GB", "-1MB", "1XB"} {
+		_, err := parseSize(size)
+		assert.Error(t, err, size)
+	}
+}
+
+func newTestItem(repo, itemPath, name string, size int64, daysAgo int, props ...servicesutils.Property) servicesutils.ResultItem {
+	return servicesutils.ResultItem{
+		Repo:       repo,
+		Path:       itemPath,
+		Name:       name,
+		Type:       "file",
+		Size:       size,
+		Created:    testNow.AddDate(0, 0, -daysAgo).Format(time.RFC3339),
+		Properties: props,
+	}
+}
+
+func getCandidatePaths(result *evaluation) []string {
+	var paths []string
+	for _, candidate := range result.candidates {
+		paths = append(paths, candidate.GetItemRelativePath())
+	}
+	return paths
+}
+
+func TestEvaluatePolicyOlderThan(t *testing.T) {
+	policy := &Policy{Name: "temp", Pattern: "temp/*", OlderThanDays: 30, RetainProperties: map[string]string{"retain": "true"}}
+	items := []servicesutils.ResultItem{
+		newTestItem("temp", "a", "old.bin", 10, 60),
+		newTestItem("temp", "a", "new.bin", 10, 5),
+		newTestItem("temp", "a", "retained.bin", 10, 60, servicesutils.Property{Key: "retain", Value: "true"}),
+		newTestItem("temp", "a", "built.bin", 10, 60),
+		{Repo: "temp", Path: ".", Name: "a", Type: "folder"},
+	}
+	result := evaluatePolicy(policy, items, map[string]bool{"temp/a/built.bin": true}, testNow)
+	assert.Equal(t, []string{"temp/a/old.bin"}, getCandidatePaths(result))
+	assert.Equal(t, "older than 30 days", result.candidates[0].Reason)
+	assert.Equal(t, 2, result.protected)
+	assert.Equal(t, 3, result.kept)
+
+	// Build artifacts aren't protected when keepBuildArtifacts is false.
+	keepBuildArtifacts := false
+	policy.KeepBuildArtifacts = &keepBuildArtifacts
+	result = evaluatePolicy(policy, items, map[string]bool{"temp/a/built.bin": true}, testNow)
+	assert.ElementsMatch(t, []string{"temp/a/old.bin", "temp/a/built.bin"}, getCandidatePaths(result))
+}
+
+func TestEvaluatePolicyKeepLastVersions(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		newTestItem("libs", "org/app/1.0", "app-1.0.jar", 10, 100),
+		newTestItem("libs", "org/app/1.1", "app-1.1.jar", 10, 50),
+		newTestItem("libs", "org/app/1.2", "app-1.2.jar", 10, 40),
+		newTestItem("libs", "org/app/2.0", "app-2.0.jar", 10, 1),
+		newTestItem("libs", "org/lib/1.0", "lib-1.0.jar", 10, 100),
+	}
+
+	policy := &Policy{Name: "libs", Pattern: "libs/*", KeepLastVersions: 2}
+	result := evaluatePolicy(policy, items, nil, testNow)
+	assert.Equal(t, []string{"libs/org/app/1.0/app-1.0.jar", "libs/org/app/1.1/app-1.1.jar"}, getCandidatePaths(result))
+	assert.Equal(t, "not in the last 2 versions", result.candidates[0].Reason)
+
+	// Both rules must apply for a file to be deleted.
+	policy.OlderThanDays = 60
+	result = evaluatePolicy(policy, items, nil, testNow)
+	assert.Equal(t, []string{"libs/org/app/1.0/app-1.0.jar"}, getCandidatePaths(result))
+	assert.Equal(t, "older than 60 days", result.candidates[0].Reason)
+}
+
+func TestEvaluatePolicyMaxRepoSize(t *testing.T) {
+	items := []servicesutils.ResultItem{
+		newTestItem("libs", "org/app/1.0", "app-1.0.jar", 40, 100),
+		newTestItem("libs", "org/app/1.1", "app-1.1.jar", 40, 50, servicesutils.Property{Key: "retain", Value: "true"}),
+		newTestItem("libs", "org/app/1.2", "app-1.2.jar", 40, 40),
+		newTestItem("libs", "org/app/2.0", "app-2.0.jar", 40, 1),
+		newTestItem("other", "org/app/1.0", "app-1.0.jar", 40, 100),
+	}
+	policy := &Policy{Name: "libs", Pattern: "*", KeepLastVersions: 1, MaxRepoSize: "100B", RetainProperties: map[string]string{"retain": "true"}}
+	require.NoError(t, policy.validate())
+
+	result := evaluatePolicy(policy, items, nil, testNow)
+	// 1.0 and 1.2 aren't in the last version. 1.1 is retained and 2.0 is the last version, so they are kept.
+	// After deleting 1.0 and 1.2 by the version rule, the repository is below the maximum size.
+	assert.Equal(t, []string{"libs/org/app/1.0/app-1.0.jar", "libs/org/app/1.2/app-1.2.jar"}, getCandidatePaths(result))
+	assert.Empty(t, result.oversizedRepos)
+
+	// Without the version rule, the oldest files are deleted until the repository is below the maximum size.
+	policy.KeepLastVersions = 0
+	result = evaluatePolicy(policy, items, nil, testNow)
+	assert.Equal(t, []string{"libs/org/app/1.0/app-1.0.jar", "libs/org/app/1.2/app-1.2.jar"}, getCandidatePaths(result))
+	assert.Equal(t, "repository size over 100B", result.candidates[0].Reason)
+
+	// The retained file alone exceeds the maximum size of the 'libs' repository.
+	policy.MaxRepoSize = "10B"
+	require.NoError(t, policy.validate())
+	result = evaluatePolicy(policy, items, nil, testNow)
+	assert.Len(t, result.candidates, 4)
+	assert.Equal(t, []string{"libs"}, result.oversizedRepos)
+
+	// A file without a valid creation time isn't deleted, although it would sort as the oldest.
+	policy.MaxRepoSize = "100B"
+	require.NoError(t, policy.validate())
+	unknownCreation := newTestItem("libs", "org/app/0.9", "app-0.9.jar", 40, 0)
+	unknownCreation.Created = "unknown"
+	result = evaluatePolicy(policy, append(items, unknownCreation), nil, testNow)
+	assert.Equal(t, []string{"libs/org/app/1.0/app-1.0.jar", "libs/org/app/1.2/app-1.2.jar", "libs/org/app/2.0/app-2.0.jar"}, getCandidatePaths(result))
+}
@@ -0,0 +1,151 @@
+package cleanup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultRetainProperty = "retain"
+
+// A cleanup policies file.
+// Example:
+//
+//	policies:
+//	  - name: snapshots
+//	    pattern: libs-snapshot-local/org/acme/*
+//	    exclusions: ["*.pom"]
+//	    keepLastVersions: 5
+//	    olderThanDays: 90
+//	    maxRepoSize: 50GB
+//	  - name: temp
+//	    pattern: temp-local/*
+//	    olderThanDays: 7
+//	    keepBuildArtifacts: false
+type PoliciesFile struct {
+	Policies []*Policy `yaml:"policies"`
+}
+
+// A cleanup policy of the files which match a pattern.
+// A file is deleted if it's older than OlderThanDays and isn't in the last KeepLastVersions versions.
+// When only one of them is set, it alone decides. Then, if the files left in a repository exceed MaxRepoSize,
+// the oldest files which aren't in the last versions are deleted too.
+// Files which are referenced by a build, or have one of the retain properties, are never deleted.
+type Policy struct {
+	Name       string   `yaml:"name"`
+	Pattern    string   `yaml:"pattern"`
+	Exclusions []string `yaml:"exclusions"`
+	// The number of versions to keep of each package. The versions of a package are the directories in the package directory,
+	// and are ordered by the creation time of their newest file. For example, 'org/acme/app/1.0/app-1.0.jar' is of the
+	// version '1.0' of the package 'org/acme/app'.
+	KeepLastVersions int `yaml:"keepLastVersions"`
+	// Files which were created more than this number of days ago are deleted.
+	OlderThanDays int `yaml:"olderThanDays"`
+	// The maximum total size of the policy files in each repository, for example '500MB' or '50GB'.
+	MaxRepoSize string `yaml:"maxRepoSize"`
+	// Whether files which are the artifacts or dependencies of a build are kept. Defaults to true.
+	KeepBuildArtifacts *bool `yaml:"keepBuildArtifacts"`
+	// Files with one of these properties are kept. Defaults to 'retain=true'.
+	RetainProperties map[string]string `yaml:"retainProperties"`
+
+	maxRepoSizeBytes int64
+}
+
+func (p *Policy) keepBuildArtifacts() bool {
+	return p.KeepBuildArtifacts == nil || *p.KeepBuildArtifacts
+}
+
+func ReadPoliciesFile(filePath string) (*PoliciesFile, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	policiesFile := new(PoliciesFile)
+	// Unknown keys are rejected, since a misspelled rule would otherwise be ignored, and more artifacts would be deleted.
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(policiesFile); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errorutils.CheckErrorf("failed to parse the cleanup policies file %s: %s", filePath, err.Error())
+	}
+	if err = policiesFile.validate(); err != nil {
+		return nil, errorutils.CheckErrorf("invalid cleanup policies file %s: %s", filePath, err.Error())
+	}
+	return policiesFile, nil
+}
+
+func (pf *PoliciesFile) validate() error {
+	if len(pf.Policies) == 0 {
+		return fmt.Errorf("no policies are listed")
+	}
+	names := make(map[string]bool, len(pf.Policies))
+	for i, policy := range pf.Policies {
+		if policy == nil || policy.Name == "" {
+			return fmt.Errorf("the name of policy #%d is missing", i+1)
+		}
+		if names[policy.Name] {
+			return fmt.Errorf("the policy name '%s' is used more than once", policy.Name)
+		}
+		names[policy.Name] = true
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("policy '%s': %w", policy.Name, err)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) validate() (err error) {
+	if p.Pattern == "" {
+		return fmt.Errorf("the pattern is missing")
+	}
+	if p.KeepLastVersions < 0 || p.OlderThanDays < 0 {
+		return fmt.Errorf("keepLastVersions and olderThanDays can't be negative")
+	}
+	if p.MaxRepoSize != "" {
+		if p.maxRepoSizeBytes, err = parseSize(p.MaxRepoSize); err != nil {
+			return err
+		}
+	}
+	if p.KeepLastVersions == 0 && p.OlderThanDays == 0 && p.maxRepoSizeBytes == 0 {
+		return fmt.Errorf("at least one of keepLastVersions, olderThanDays and maxRepoSize must be set")
+	}
+	if p.RetainProperties == nil {
+		p.RetainProperties = map[string]string{defaultRetainProperty: "true"}
+	}
+	return nil
+}
+
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"TB", 1 << 40},
+	{"GB", 1 << 30},
+	{"MB", 1 << 20},
+	{"KB", 1 << 10},
+	{"B", 1},
+}
+
+// Parses a size such as '500MB' or '50GB'. The units are binary, meaning 1KB is 1024 bytes. A number without a unit is in bytes.
+func parseSize(size string) (int64, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(size, " ", ""))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(normalized, unit.suffix) {
+			normalized = strings.TrimSuffix(normalized, unit.suffix)
+			multiplier = unit.multiplier
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(normalized, 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid size '%s'. Expected a positive size such as '500MB' or '50GB'", size)
+	}
+	return int64(value * float64(multiplier)), nil
+}
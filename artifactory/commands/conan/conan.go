@@ -0,0 +1,347 @@
+package conan
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	conanutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/conan"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const uploadCmdName = "upload"
+
+// Conan commands which print the dependency graph with '--format=json'.
+var graphCommands = []string{"install", "create", "build"}
+
+// Runs Conan 2 with an Artifactory repository as the only remote, and collects build-info modules from the Conan output.
+type ConanCommand struct {
+	cmdName            string
+	conanArgs          []string
+	configFilePath     string
+	buildConfiguration *buildUtils.BuildConfiguration
+	resolverRepo       string
+	resolverDetails    *config.ServerDetails
+	deployerRepo       string
+	deployerDetails    *config.ServerDetails
+	executablePath     string
+	workingDirectory   string
+}
+
+func NewConanCommand(cmdName string) *ConanCommand {
+	return &ConanCommand{cmdName: cmdName}
+}
+
+func (cc *ConanCommand) SetArgs(args []string) *ConanCommand {
+	cc.conanArgs = args
+	return cc
+}
+
+func (cc *ConanCommand) SetConfigFilePath(configFilePath string) *ConanCommand {
+	cc.configFilePath = configFilePath
+	return cc
+}
+
+func (cc *ConanCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *ConanCommand {
+	cc.buildConfiguration = buildConfiguration
+	return cc
+}
+
+func (cc *ConanCommand) SetResolver(repo string, serverDetails *config.ServerDetails) *ConanCommand {
+	cc.resolverRepo = repo
+	cc.resolverDetails = serverDetails
+	return cc
+}
+
+func (cc *ConanCommand) SetDeployer(repo string, serverDetails *config.ServerDetails) *ConanCommand {
+	cc.deployerRepo = repo
+	cc.deployerDetails = serverDetails
+	return cc
+}
+
+func (cc *ConanCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.cmdName == uploadCmdName {
+		return cc.deployerDetails, nil
+	}
+	return cc.resolverDetails, nil
+}
+
+func (cc *ConanCommand) CommandName() string {
+	return "rt_conan_" + cc.cmdName
+}
+
+// Reads the resolver details, or the deployer details for 'conan upload', from the config file,
+// and extracts the build-info options from the Conan args.
+func (cc *ConanCommand) Init() (err error) {
+	log.Debug("Preparing to read the config file", cc.configFilePath)
+	vConfig, err := project.ReadConfigFile(cc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	prefix := project.ProjectConfigResolverPrefix
+	if cc.cmdName == uploadCmdName {
+		prefix = project.ProjectConfigDeployerPrefix
+	}
+	repoConfig, err := project.GetRepoConfigByPrefix(cc.configFilePath, prefix, vConfig)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := repoConfig.ServerDetails()
+	if err != nil {
+		return err
+	}
+	if cc.cmdName == uploadCmdName {
+		cc.SetDeployer(repoConfig.TargetRepo(), serverDetails)
+	} else {
+		cc.SetResolver(repoConfig.TargetRepo(), serverDetails)
+	}
+	cc.conanArgs, cc.buildConfiguration, err = buildUtils.ExtractBuildDetailsFromArgs(cc.conanArgs)
+	return
+}
+
+func (cc *ConanCommand) Run() (err error) {
+	log.Info("Running conan " + cc.cmdName + "...")
+	if cc.executablePath, err = exec.LookPath("conan"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if cc.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	repo, serverDetails := cc.resolverRepo, cc.resolverDetails
+	if cc.cmdName == uploadCmdName {
+		repo, serverDetails = cc.deployerRepo, cc.deployerDetails
+	}
+	conanHome, err := prepareConanHome(cc.executablePath, remoteUrl(serverDetails.GetArtifactoryUrl(), repo), serverDetails.InsecureTls)
+	if err != nil {
+		return
+	}
+	collectBuildInfo, err := cc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return
+	}
+	collectBuildInfo = collectBuildInfo && (cc.cmdName == uploadCmdName || slices.Contains(graphCommands, cc.cmdName))
+	args := append([]string{cc.cmdName}, cc.conanArgs...)
+	if cc.cmdName == uploadCmdName && !hasFlag(cc.conanArgs, "-r", "--remote") {
+		args = append(args, "--remote", remoteName)
+	}
+	// The JSON output is parsed to collect the build-info, so it's requested only if the user didn't choose another format.
+	if collectBuildInfo && hasFlag(cc.conanArgs, "-f", "--format") {
+		log.Warn("Build-info is not collected, because the output format of 'conan " + cc.cmdName + "' was set.")
+		collectBuildInfo = false
+	}
+	if collectBuildInfo {
+		args = append(args, "--format", "json")
+	}
+	username, password := container.GetLoginCredentials(serverDetails)
+	output, err := cc.runConan(conanHome, remoteCredentialsEnv(username, password), collectBuildInfo, args...)
+	if err != nil {
+		return
+	}
+	log.Info("conan " + cc.cmdName + " finished successfully.")
+	if !collectBuildInfo {
+		return
+	}
+	if _, err = buildUtils.PrepareBuildPrerequisites(cc.buildConfiguration); err != nil {
+		return
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, 0, false)
+	if err != nil {
+		return
+	}
+	if cc.cmdName == uploadCmdName {
+		return cc.saveUploadedArtifacts(servicesManager, output)
+	}
+	return cc.saveDependencies(servicesManager, output)
+}
+
+// Runs Conan with the isolated Conan home. If captureOutput is true, the standard output is returned instead of being printed.
+func (cc *ConanCommand) runConan(conanHome string, env []string, captureOutput bool, args ...string) ([]byte, error) {
+	command := exec.Command(cc.executablePath, args...)
+	command.Dir = cc.workingDirectory
+	command.Env = append(append(os.Environ(), conanHomeEnv+"="+conanHome), env...)
+	var output bytes.Buffer
+	command.Stdout = os.Stderr
+	if captureOutput {
+		command.Stdout = &output
+	}
+	command.Stderr = os.Stderr
+	log.Debug("Running:", cc.executablePath, strings.Join(args, " "))
+	err := command.Run()
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		err = errors.New(err.Error())
+	}
+	return output.Bytes(), errorutils.CheckError(err)
+}
+
+// Adds a build-info module for each package built by the command, with its dependencies from the Conan graph.
+// The checksums of the dependencies are taken from their package binaries in Artifactory.
+func (cc *ConanCommand) saveDependencies(servicesManager artifactory.ArtifactoryServicesManager, output []byte) error {
+	graph, err := conanutils.ParseGraph(output)
+	if err != nil {
+		return err
+	}
+	checksums, err := cc.getPackageChecksums(servicesManager, graph.PackagePaths())
+	if err != nil {
+		return err
+	}
+	moduleNodeIds := graph.ModuleNodeIds()
+	for _, nodeId := range moduleNodeIds {
+		dependencies, err := graph.GetDependencies(nodeId)
+		if err != nil {
+			return err
+		}
+		for i := range dependencies {
+			dependencies[i].Checksum = checksums[dependencies[i].Id]
+		}
+		moduleId := graph.Graph.Nodes[nodeId].Reference()
+		if moduleId == "" || moduleId == "conanfile" || (len(moduleNodeIds) == 1 && cc.buildConfiguration.GetModule() != "") {
+			moduleId = cc.getDefaultModuleId()
+		}
+		log.Debug("Collected", len(dependencies), "dependencies of module", moduleId)
+		if err = cc.savePartial(moduleId, dependencies, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cc *ConanCommand) getDefaultModuleId() string {
+	if cc.buildConfiguration.GetModule() != "" {
+		return cc.buildConfiguration.GetModule()
+	}
+	return filepath.Base(cc.workingDirectory)
+}
+
+// Returns the checksums of the package binaries found in the repository, mapped by the references of their recipes.
+func (cc *ConanCommand) getPackageChecksums(servicesManager artifactory.ArtifactoryServicesManager, packagePaths map[string]string) (map[string]entities.Checksum, error) {
+	checksums := make(map[string]entities.Checksum, len(packagePaths))
+	for reference, packagePath := range packagePaths {
+		items, err := searchItems(servicesManager, cc.resolverRepo+"/"+packagePath)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			log.Debug("The package of", reference, "was not found in", cc.resolverRepo)
+			continue
+		}
+		checksums[reference] = entities.Checksum{Sha1: items[0].Actual_Sha1, Md5: items[0].Actual_Md5, Sha256: items[0].Sha256}
+	}
+	return checksums, nil
+}
+
+// Adds a build-info module for each recipe uploaded by 'conan upload', with the recipe and package files as its artifacts.
+// The build properties are set on the uploaded files.
+func (cc *ConanCommand) saveUploadedArtifacts(servicesManager artifactory.ArtifactoryServicesManager, output []byte) error {
+	packageList, err := conanutils.ParsePackageList(output)
+	if err != nil {
+		return err
+	}
+	recipes, err := packageList.UploadedRecipes(remoteName)
+	if err != nil {
+		return err
+	}
+	buildProps, err := buildUtils.CreateBuildPropsFromConfiguration(cc.buildConfiguration)
+	if err != nil {
+		return err
+	}
+	for _, recipe := range recipes {
+		var artifacts []entities.Artifact
+		for _, path := range recipe.Paths {
+			pathArtifacts, err := setPropsAndGetArtifacts(servicesManager, cc.deployerRepo+"/"+path+"/*", buildProps)
+			if err != nil {
+				return err
+			}
+			artifacts = append(artifacts, pathArtifacts...)
+		}
+		moduleId := recipe.Reference
+		if len(recipes) == 1 && cc.buildConfiguration.GetModule() != "" {
+			moduleId = cc.buildConfiguration.GetModule()
+		}
+		log.Debug("Collected", len(artifacts), "artifacts of module", moduleId)
+		if err = cc.savePartial(moduleId, nil, artifacts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cc *ConanCommand) savePartial(moduleId string, dependencies []entities.Dependency, artifacts []entities.Artifact) error {
+	buildName, err := cc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := cc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return buildUtils.SavePartialBuildInfo(buildName, buildNumber, cc.buildConfiguration.GetProject(), func(partial *entities.Partial) {
+		partial.ModuleId = moduleId
+		partial.ModuleType = conanutils.ModuleType
+		partial.Dependencies = dependencies
+		partial.Artifacts = artifacts
+	})
+}
+
+func searchItems(servicesManager artifactory.ArtifactoryServicesManager, pattern string) (items []specutils.ResultItem, err error) {
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = pattern
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(specutils.ResultItem); reader.NextRecord(item) == nil; item = new(specutils.ResultItem) {
+		items = append(items, *item)
+	}
+	return items, reader.GetError()
+}
+
+func setPropsAndGetArtifacts(servicesManager artifactory.ArtifactoryServicesManager, pattern, props string) (artifacts []entities.Artifact, err error) {
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = pattern
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(specutils.ResultItem); reader.NextRecord(item) == nil; item = new(specutils.ResultItem) {
+		artifacts = append(artifacts, item.ToArtifact())
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	if _, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props}); err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+func hasFlag(args []string, flags ...string) bool {
+	for _, arg := range args {
+		name, _, _ := strings.Cut(arg, "=")
+		if slices.Contains(flags, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package conan
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	remoteName          = "artifactory"
+	remotesFileName     = "remotes.json"
+	profilesDirName     = "profiles"
+	defaultProfileName  = "default"
+	conanHomeEnv        = "CONAN_HOME"
+	defaultConanHomeDir = ".conan2"
+)
+
+type conanRemotes struct {
+	Remotes []conanRemote `json:"remotes"`
+}
+
+type conanRemote struct {
+	Name      string `json:"name"`
+	Url       string `json:"url"`
+	VerifySsl bool   `json:"verify_ssl"`
+}
+
+// Returns the URL of a Conan repository in Artifactory.
+func remoteUrl(artifactoryUrl, repo string) string {
+	return strings.TrimSuffix(artifactoryUrl, "/") + "/api/conan/" + repo
+}
+
+// Returns the environment variables which pass the remote credentials to Conan, so that they are not stored in the Conan home.
+func remoteCredentialsEnv(username, password string) []string {
+	envSuffix := strings.ToUpper(remoteName)
+	return []string{"CONAN_LOGIN_USERNAME_" + envSuffix + "=" + username, "CONAN_PASSWORD_" + envSuffix + "=" + password}
+}
+
+// Prepares the Conan home used by the Conan commands, which is isolated from the user's Conan home.
+// The Artifactory repository is configured as the only remote, and the default profile is copied from the user's Conan home, or detected if it doesn't exist.
+// The packages cache is kept between runs, so that packages created by 'conan create' can be uploaded by 'conan upload'.
+func prepareConanHome(executablePath, url string, insecureTls bool) (conanHome string, err error) {
+	if conanHome, err = coreutils.GetJfrogConanHomeDir(); err != nil {
+		return
+	}
+	if err = fileutils.CreateDirIfNotExist(conanHome); err != nil {
+		return
+	}
+	content, err := json.MarshalIndent(conanRemotes{Remotes: []conanRemote{{Name: remoteName, Url: url, VerifySsl: !insecureTls}}}, "", "  ")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	log.Debug("Configuring the Conan remote", url, "in", conanHome)
+	if err = os.WriteFile(filepath.Join(conanHome, remotesFileName), content, 0600); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return conanHome, prepareDefaultProfile(executablePath, conanHome)
+}
+
+func prepareDefaultProfile(executablePath, conanHome string) error {
+	profilePath := filepath.Join(conanHome, profilesDirName, defaultProfileName)
+	exists, err := fileutils.IsFileExists(profilePath, false)
+	if err != nil || exists {
+		return err
+	}
+	userConanHome := os.Getenv(conanHomeEnv)
+	if userConanHome == "" {
+		userConanHome = filepath.Join(fileutils.GetHomeDir(), defaultConanHomeDir)
+	}
+	userProfilePath := filepath.Join(userConanHome, profilesDirName, defaultProfileName)
+	if exists, err = fileutils.IsFileExists(userProfilePath, false); err != nil {
+		return err
+	}
+	if exists {
+		log.Debug("Copying the default Conan profile from", userProfilePath)
+		if err = fileutils.CreateDirIfNotExist(filepath.Dir(profilePath)); err != nil {
+			return err
+		}
+		return ioutils.CopyFile(filepath.Dir(profilePath), userProfilePath)
+	}
+	log.Debug("Detecting the default Conan profile.")
+	command := exec.Command(executablePath, "profile", "detect")
+	command.Env = append(os.Environ(), conanHomeEnv+"="+conanHome)
+	command.Stdout = os.Stderr
+	command.Stderr = os.Stderr
+	return errorutils.CheckError(command.Run())
+}
@@ -0,0 +1,31 @@
+package conan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareConanHome(t *testing.T) {
+	t.Setenv(coreutils.HomeDir, t.TempDir())
+	userConanHome := t.TempDir()
+	t.Setenv(conanHomeEnv, userConanHome)
+	require.NoError(t, os.MkdirAll(filepath.Join(userConanHome, profilesDirName), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(userConanHome, profilesDirName, defaultProfileName), []byte("[settings]\nos=Linux\n"), 0644))
+
+	url := remoteUrl("https://acme.jfrog.io/artifactory/", "conan-virtual")
+	assert.Equal(t, "https://acme.jfrog.io/artifactory/api/conan/conan-virtual", url)
+	conanHome, err := prepareConanHome("conan", url, false)
+	require.NoError(t, err)
+
+	remotes, err := os.ReadFile(filepath.Join(conanHome, remotesFileName))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"remotes": [{"name": "artifactory", "url": "https://acme.jfrog.io/artifactory/api/conan/conan-virtual", "verify_ssl": true}]}`, string(remotes))
+	profile, err := os.ReadFile(filepath.Join(conanHome, profilesDirName, defaultProfileName))
+	require.NoError(t, err)
+	assert.Equal(t, "[settings]\nos=Linux\n", string(profile))
+}
@@ -0,0 +1,293 @@
+package dotnet
+
+import (
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	commandsutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	dotnetutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/dotnet"
+	commonBuild "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/maps"
+)
+
+// A NuGet package to publish, with its symbol package if one was created beside it.
+type nugetPackage struct {
+	metadata          *dotnetutils.NuspecMetadata
+	path              string
+	symbolPackagePath string
+}
+
+// Returns the local path and the target path in the repository of each file of the package.
+func (np *nugetPackage) files(repo string) map[string]string {
+	files := map[string]string{np.path: np.target(repo, dotnetutils.PackageExtension)}
+	if np.symbolPackagePath != "" {
+		files[np.symbolPackagePath] = np.target(repo, dotnetutils.SymbolPackageExtension)
+	}
+	return files
+}
+
+// Packages are deployed to <id>/<id>.<version>.nupkg, which matches the default NuGet repository layout.
+func (np *nugetPackage) target(repo, extension string) string {
+	return path.Join(repo, np.metadata.Id, np.metadata.FileName(extension))
+}
+
+// Deploys NuGet packages and their symbol packages to Artifactory, with properties taken from their .nuspec files.
+type NugetPublishCommand struct {
+	packagePatterns    []string
+	repoName           string
+	dryRun             bool
+	buildConfiguration *commonBuild.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	result             *commandsutils.Result
+}
+
+func NewNugetPublishCommand() *NugetPublishCommand {
+	return &NugetPublishCommand{result: new(commandsutils.Result)}
+}
+
+func (npc *NugetPublishCommand) CommandName() string {
+	return "rt_nuget_publish"
+}
+
+func (npc *NugetPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return npc.serverDetails, nil
+}
+
+func (npc *NugetPublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *NugetPublishCommand {
+	npc.serverDetails = serverDetails
+	return npc
+}
+
+func (npc *NugetPublishCommand) SetRepoName(repoName string) *NugetPublishCommand {
+	npc.repoName = repoName
+	return npc
+}
+
+// The paths of the .nupkg files to publish. Wildcards are supported, for example 'bin/Release/*.nupkg'.
+func (npc *NugetPublishCommand) SetPackagePatterns(packagePatterns []string) *NugetPublishCommand {
+	npc.packagePatterns = packagePatterns
+	return npc
+}
+
+func (npc *NugetPublishCommand) SetDryRun(dryRun bool) *NugetPublishCommand {
+	npc.dryRun = dryRun
+	return npc
+}
+
+func (npc *NugetPublishCommand) SetBuildConfiguration(buildConfiguration *commonBuild.BuildConfiguration) *NugetPublishCommand {
+	npc.buildConfiguration = buildConfiguration
+	return npc
+}
+
+func (npc *NugetPublishCommand) Result() *commandsutils.Result {
+	return npc.result
+}
+
+func (npc *NugetPublishCommand) Run() error {
+	log.Info("Running NuGet publish...")
+	if npc.repoName == "" {
+		return errorutils.CheckErrorf("the repository to publish to must be provided")
+	}
+	packages, err := findNugetPackages(npc.packagePatterns)
+	if err != nil {
+		return err
+	}
+	if npc.dryRun {
+		npc.listPackages(packages)
+		return nil
+	}
+	if err = npc.deploy(packages); err != nil {
+		return err
+	}
+	log.Info("NuGet publish finished successfully.")
+	return nil
+}
+
+// Lists the files which would have been deployed, with their target paths and properties.
+func (npc *NugetPublishCommand) listPackages(packages []*nugetPackage) {
+	for _, nugetPackage := range packages {
+		files := nugetPackage.files(npc.repoName)
+		localPaths := maps.Keys(files)
+		slices.Sort(localPaths)
+		for _, localPath := range localPaths {
+			log.Output(localPath + " -> " + files[localPath])
+		}
+		properties := nugetPackage.metadata.Properties()
+		keys := maps.Keys(properties)
+		slices.Sort(keys)
+		for _, key := range keys {
+			log.Output("  " + key + "=" + properties[key])
+		}
+	}
+	npc.result.SetSuccessCount(len(packages))
+}
+
+func (npc *NugetPublishCommand) deploy(packages []*nugetPackage) (err error) {
+	collectBuildInfo, err := npc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	var buildProps string
+	if collectBuildInfo {
+		if buildProps, err = commonBuild.CreateBuildPropsFromConfiguration(npc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	var uploadParamsList []services.UploadParams
+	for _, nugetPackage := range packages {
+		properties := specutils.NewProperties()
+		for key, value := range nugetPackage.metadata.Properties() {
+			properties.AddProperty(key, value)
+		}
+		files := nugetPackage.files(npc.repoName)
+		localPaths := maps.Keys(files)
+		slices.Sort(localPaths)
+		for _, localPath := range localPaths {
+			uploadParams := services.NewUploadParams()
+			uploadParams.CommonParams = &specutils.CommonParams{Pattern: localPath, Target: files[localPath], TargetProps: properties}
+			uploadParams.BuildProps = buildProps
+			uploadParams.Flat = true
+			uploadParamsList = append(uploadParamsList, uploadParams)
+		}
+	}
+	servicesManager, err := utils.CreateServiceManager(npc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(uploadParamsList...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.Close())
+	}()
+	npc.result.SetSuccessCount(summary.TotalSucceeded)
+	npc.result.SetFailCount(summary.TotalFailed)
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to upload %d of the NuGet packages files to Artifactory. See Artifactory logs for more details", summary.TotalFailed)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	return npc.saveBuildInfo(packages, artifacts)
+}
+
+// Adds a build-info module for each package, with the package and its symbol package as artifacts.
+func (npc *NugetPublishCommand) saveBuildInfo(packages []*nugetPackage, artifacts []buildinfo.Artifact) error {
+	buildName, err := npc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := npc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	artifactsByPath := make(map[string]buildinfo.Artifact, len(artifacts))
+	for _, artifact := range artifacts {
+		artifactsByPath[path.Join(artifact.OriginalDeploymentRepo, artifact.Path)] = artifact
+	}
+	for _, nugetPackage := range packages {
+		var moduleArtifacts []buildinfo.Artifact
+		files := nugetPackage.files(npc.repoName)
+		localPaths := maps.Keys(files)
+		slices.Sort(localPaths)
+		for _, localPath := range localPaths {
+			if artifact, ok := artifactsByPath[files[localPath]]; ok {
+				artifact.Type = strings.TrimPrefix(filepath.Ext(localPath), ".")
+				moduleArtifacts = append(moduleArtifacts, artifact)
+			}
+		}
+		moduleId := nugetPackage.metadata.Id + ":" + nugetPackage.metadata.Version
+		// The module name of the build configuration can only identify a single module.
+		if len(packages) == 1 && npc.buildConfiguration.GetModule() != "" {
+			moduleId = npc.buildConfiguration.GetModule()
+		}
+		err = commonBuild.SavePartialBuildInfo(buildName, buildNumber, npc.buildConfiguration.GetProject(), func(partial *buildinfo.Partial) {
+			partial.ModuleId = moduleId
+			partial.ModuleType = buildinfo.Nuget
+			partial.Artifacts = moduleArtifacts
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the packages which match the patterns. The symbol package of each package is looked for beside it.
+func findNugetPackages(patterns []string) ([]*nugetPackage, error) {
+	var packagePaths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, match := range matches {
+			if filepath.Ext(match) == dotnetutils.PackageExtension && !strings.HasSuffix(match, ".symbols"+dotnetutils.PackageExtension) {
+				packagePaths = append(packagePaths, match)
+			}
+		}
+	}
+	if len(packagePaths) == 0 {
+		return nil, errorutils.CheckErrorf("no NuGet packages (%s files) match the patterns: %s", dotnetutils.PackageExtension, strings.Join(patterns, ", "))
+	}
+	sort.Strings(packagePaths)
+	var packages []*nugetPackage
+	targets := make(map[string]string)
+	for _, packagePath := range packagePaths {
+		nugetPackage, err := readNugetPackage(packagePath)
+		if err != nil {
+			return nil, err
+		}
+		target := nugetPackage.target("", dotnetutils.PackageExtension)
+		if otherPath, exists := targets[target]; exists {
+			if otherPath == packagePath {
+				continue
+			}
+			return nil, errorutils.CheckErrorf("the packages %s and %s have the same id and version", otherPath, packagePath)
+		}
+		targets[target] = packagePath
+		packages = append(packages, nugetPackage)
+	}
+	return packages, nil
+}
+
+func readNugetPackage(packagePath string) (*nugetPackage, error) {
+	nuspec, err := dotnetutils.ReadNuspec(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	nugetPackage := &nugetPackage{metadata: &nuspec.Metadata, path: packagePath}
+	symbolPackagePath := strings.TrimSuffix(packagePath, dotnetutils.PackageExtension) + dotnetutils.SymbolPackageExtension
+	if _, err = os.Stat(symbolPackagePath); err != nil {
+		if os.IsNotExist(err) {
+			return nugetPackage, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	symbolNuspec, err := dotnetutils.ReadNuspec(symbolPackagePath)
+	if err != nil {
+		return nil, err
+	}
+	if !symbolNuspec.Metadata.IsSymbolsPackage() || !strings.EqualFold(symbolNuspec.Metadata.Id, nuspec.Metadata.Id) || symbolNuspec.Metadata.Version != nuspec.Metadata.Version {
+		return nil, errorutils.CheckErrorf("%s is not the symbol package of %s %s", symbolPackagePath, nuspec.Metadata.Id, nuspec.Metadata.Version)
+	}
+	nugetPackage.symbolPackagePath = symbolPackagePath
+	return nugetPackage, nil
+}
@@ -0,0 +1,82 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNuspec = `<?xml version="1.0" encoding="utf-8"?>
+<package xmlns="http://schemas.microsoft.com/packaging/2013/05/nuspec.xsd">
+  <metadata>
+    <id>%s</id>
+    <version>%s</version>
+    <authors>JFrog</authors>
+    <tags>build ci</tags>
+    %s
+  </metadata>
+</package>`
+
+func createTestPackage(t *testing.T, packagePath, id, version string, symbols bool) {
+	packageTypes := ""
+	if symbols {
+		packageTypes = `<packageTypes><packageType name="SymbolsPackage" /></packageTypes>`
+	}
+	file, err := os.Create(packagePath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, file.Close())
+	}()
+	writer := zip.NewWriter(file)
+	nuspecWriter, err := writer.Create(id + ".nuspec")
+	require.NoError(t, err)
+	_, err = nuspecWriter.Write([]byte(fmt.Sprintf(testNuspec, id, version, packageTypes)))
+	require.NoError(t, err)
+	libWriter, err := writer.Create("lib/net8.0/" + id + ".dll")
+	require.NoError(t, err)
+	_, err = libWriter.Write([]byte("dll"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+}
+
+func TestFindNugetPackages(t *testing.T) {
+	dir := t.TempDir()
+	createTestPackage(t, filepath.Join(dir, "Foo.1.0.0.nupkg"), "Foo", "1.0.0", false)
+	createTestPackage(t, filepath.Join(dir, "Foo.1.0.0.snupkg"), "Foo", "1.0.0", true)
+	createTestPackage(t, filepath.Join(dir, "Bar.2.1.0.nupkg"), "Bar", "2.1.0", false)
+
+	packages, err := findNugetPackages([]string{filepath.Join(dir, "*.nupkg"), filepath.Join(dir, "Foo.*")})
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+
+	assert.Equal(t, map[string]string{filepath.Join(dir, "Bar.2.1.0.nupkg"): "nuget-local/Bar/Bar.2.1.0.nupkg"}, packages[0].files("nuget-local"))
+	assert.Equal(t, map[string]string{
+		filepath.Join(dir, "Foo.1.0.0.nupkg"):  "nuget-local/Foo/Foo.1.0.0.nupkg",
+		filepath.Join(dir, "Foo.1.0.0.snupkg"): "nuget-local/Foo/Foo.1.0.0.snupkg",
+	}, packages[1].files("nuget-local"))
+	assert.Equal(t, map[string]string{"nuget.id": "Foo", "nuget.version": "1.0.0", "nuget.authors": "JFrog", "nuget.tags": "build ci"}, packages[1].metadata.Properties())
+}
+
+func TestFindNugetPackagesErrors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := findNugetPackages([]string{filepath.Join(dir, "*.nupkg")})
+	assert.ErrorContains(t, err, "no NuGet packages")
+
+	// The symbol package belongs to another version.
+	createTestPackage(t, filepath.Join(dir, "Foo.1.0.0.nupkg"), "Foo", "1.0.0", false)
+	createTestPackage(t, filepath.Join(dir, "Foo.1.0.0.snupkg"), "Foo", "1.0.1", true)
+	_, err = findNugetPackages([]string{filepath.Join(dir, "*.nupkg")})
+	assert.ErrorContains(t, err, "is not the symbol package of Foo 1.0.0")
+
+	// Two files of the same package.
+	otherDir := t.TempDir()
+	createTestPackage(t, filepath.Join(otherDir, "a.nupkg"), "Foo", "1.0.0", false)
+	createTestPackage(t, filepath.Join(otherDir, "b.nupkg"), "Foo", "1.0.0", false)
+	_, err = findNugetPackages([]string{filepath.Join(otherDir, "*.nupkg")})
+	assert.ErrorContains(t, err, "have the same id and version")
+}
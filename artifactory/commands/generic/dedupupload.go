@@ -0,0 +1,230 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// The maximum number of checksums in a single AQL query.
+const checksumsQueryBatchSize = 100
+
+// A file to upload in a deduplicated upload.
+type dedupUploadFile struct {
+	data    services.UploadData
+	params  *services.UploadParams
+	details *fileutils.FileDetails
+	exists  bool
+}
+
+// Prepares the deduplicated upload of the files which match the upload params.
+// The SHA-256 of all the files is calculated in advance, and the files with content which already exists in Artifactory
+// are deployed by checksum, without sending it. Large files with new content are uploaded in resumable parts.
+// Returns the params to upload the rest of the files with, one per file, and the number of files which failed, either in a resumable
+// upload or since their paths contain wildcard characters.
+// Archives, exploded archives, symlinks and directories are uploaded as usual.
+func (uc *UploadCommand) prepareDeduplicatedUpload(servicesManager artifactory.ArtifactoryServicesManager, uploadParamsArray []services.UploadParams) ([]services.UploadParams, int, error) {
+	var files []*dedupUploadFile
+	var result []services.UploadParams
+	vcsCache := clientutils.NewVcsDetails()
+	for i := range uploadParamsArray {
+		params := &uploadParamsArray[i]
+		if params.Archive != "" || params.IsExplodeArchive() || params.IsSymlink() || params.IsIncludeDirs() {
+			result = append(result, *params)
+			continue
+		}
+		err := services.CollectFilesForUpload(*params, nil, vcsCache, func(data services.UploadData) {
+			files = append(files, &dedupUploadFile{data: data, params: params})
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	if len(files) == 0 {
+		return result, 0, nil
+	}
+	log.Info(fmt.Sprintf("Calculating the checksums of %d files...", len(files)))
+	if err := calcDedupUploadChecksums(files, uc.uploadConfiguration.Threads); err != nil {
+		return nil, 0, err
+	}
+	if err := markExistingChecksums(servicesManager, files); err != nil {
+		return nil, 0, err
+	}
+	logDeduplicationSummary(files)
+
+	failed := 0
+	var resumableUploader *utils.ResumableUploader
+	if !uc.DryRun() {
+		var err error
+		if resumableUploader, err = uc.createResumableUploader(servicesManager); err != nil {
+			return nil, 0, err
+		}
+	}
+	for _, file := range files {
+		exact, err := isExactUploadPattern(file.data.Artifact.LocalPath, file.data.Artifact.TargetPath)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !exact {
+			log.Error(fmt.Sprintf("Failed uploading %s: its path contains wildcard characters, so it can't be uploaded separately.", file.data.Artifact.LocalPath))
+			failed++
+			continue
+		}
+		if !file.exists && resumableUploader != nil && file.details.Size >= file.params.MinSplitSize {
+			if err := resumableUploader.Upload(file.data.Artifact.LocalPath, file.data.Artifact.TargetPath, file.details); err != nil {
+				log.Error(fmt.Sprintf("Failed uploading %s: %s", file.data.Artifact.LocalPath, err.Error()))
+				failed++
+				continue
+			}
+			// The uploaded file is deployed again by its checksum, to set its properties and include it in the upload results.
+			file.exists = true
+		}
+		result = append(result, createSingleFileUploadParams(file))
+	}
+	return result, failed, nil
+}
+
+// Returns nil if multipart uploads are disabled or unsupported, in which case the large files are uploaded as usual.
+func (uc *UploadCommand) createResumableUploader(servicesManager artifactory.ArtifactoryServicesManager) (*utils.ResumableUploader, error) {
+	if uc.uploadConfiguration.SplitCount == 0 {
+		return nil, nil
+	}
+	resumableUploader, err := utils.NewResumableUploader(servicesManager, uc.uploadConfiguration.SplitCount, uc.uploadConfiguration.ChunkSizeMB*serviceutils.SizeMiB)
+	if err != nil {
+		return nil, err
+	}
+	supported, err := resumableUploader.IsSupported()
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		log.Info("Artifactory doesn't support multipart uploads. Large files are uploaded without resuming.")
+		return nil, nil
+	}
+	return resumableUploader.SetProgress(uc.progress), resumableUploader.RemoveExpiredJournals()
+}
+
+func calcDedupUploadChecksums(files []*dedupUploadFile, threads int) error {
+	errGroup := new(errgroup.Group)
+	errGroup.SetLimit(max(threads, 1))
+	for _, file := range files {
+		errGroup.Go(func() (err error) {
+			file.details, err = fileutils.GetFileDetails(file.data.Artifact.LocalPath, true)
+			return err
+		})
+	}
+	return errGroup.Wait()
+}
+
+// Marks the files with content which already exists in Artifactory, by querying their SHA-256 checksums in batches.
+func markExistingChecksums(servicesManager artifactory.ArtifactoryServicesManager, files []*dedupUploadFile) error {
+	var checksums []string
+	found := make(map[string]bool)
+	for _, file := range files {
+		if !found[file.details.Checksum.Sha256] {
+			found[file.details.Checksum.Sha256] = false
+			checksums = append(checksums, file.details.Checksum.Sha256)
+		}
+	}
+	for start := 0; start < len(checksums); start += checksumsQueryBatchSize {
+		existing, err := searchExistingChecksums(servicesManager, checksums[start:min(start+checksumsQueryBatchSize, len(checksums))])
+		if err != nil {
+			return err
+		}
+		for _, checksum := range existing {
+			found[checksum] = true
+		}
+	}
+	for _, file := range files {
+		file.exists = found[file.details.Checksum.Sha256]
+	}
+	return nil
+}
+
+func searchExistingChecksums(servicesManager artifactory.ArtifactoryServicesManager, checksums []string) (existing []string, err error) {
+	criteria := make([]map[string]string, len(checksums))
+	for i, checksum := range checksums {
+		criteria[i] = map[string]string{"sha256": checksum}
+	}
+	query, err := json.Marshal(map[string]any{"$or": criteria})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	stream, err := servicesManager.Aql(fmt.Sprintf(`items.find(%s).include("sha256")`, query))
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(stream, &err)
+	content, err := io.ReadAll(stream)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	parsedResult := new(struct {
+		Results []*serviceutils.ResultItem `json:"results,omitempty"`
+	})
+	if err = json.Unmarshal(content, parsedResult); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, result := range parsedResult.Results {
+		existing = append(existing, result.Sha256)
+	}
+	return existing, nil
+}
+
+func logDeduplicationSummary(files []*dedupUploadFile) {
+	var existingCount int
+	var existingSize, totalSize int64
+	for _, file := range files {
+		totalSize += file.details.Size
+		if file.exists {
+			existingCount++
+			existingSize += file.details.Size
+		}
+	}
+	log.Info(fmt.Sprintf("The content of %d of %d files (%s of %s) already exists in Artifactory, and is deployed by checksum.",
+		existingCount, len(files), serviceutils.ConvertIntToStorageSizeString(existingSize), serviceutils.ConvertIntToStorageSizeString(totalSize)))
+}
+
+// Returns true if uploading the local path as a wildcard pattern, to the target path, matches only the file itself.
+// The pattern can't be escaped, so a path with wildcard characters could match other files, or none.
+func isExactUploadPattern(localPath, targetPath string) (bool, error) {
+	rootPath, err := fspatterns.GetRootPath(localPath, targetPath, "", clientutils.WildCardPattern, false)
+	if err != nil {
+		return false, err
+	}
+	return rootPath == localPath, nil
+}
+
+// Creates the params to upload a single collected file, keeping its target path and properties.
+// Files with existing content are deployed by checksum regardless of their size, and the checksum deploy is skipped for the rest.
+func createSingleFileUploadParams(file *dedupUploadFile) services.UploadParams {
+	params := services.DeepCopyUploadParams(file.params)
+	params.CommonParams = &serviceutils.CommonParams{
+		Pattern:     file.data.Artifact.LocalPath,
+		Target:      file.data.Artifact.TargetPath,
+		TargetProps: file.data.TargetProps,
+	}
+	params.Flat = true
+	params.BuildProps = file.data.BuildProps
+	// The VCS properties were already added to the build properties when the files were collected.
+	params.AddVcsProps = false
+	if file.exists {
+		params.MinChecksumDeploy = 0
+	} else {
+		params.MinChecksumDeploy = math.MaxInt64
+	}
+	return params
+}
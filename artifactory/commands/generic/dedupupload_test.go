@@ -0,0 +1,59 @@
+package generic
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSingleFileUploadParams(t *testing.T) {
+	params := services.NewUploadParams()
+	params.CommonParams = &serviceutils.CommonParams{Pattern: "dir/*.zip", Target: "repo/{1}/", Recursive: true, Exclusions: []string{"*.tmp"}}
+	params.AddVcsProps = true
+	params.Deb = "bionic/main/amd64"
+	props := serviceutils.NewProperties()
+	props.AddProperty("key", "value")
+	file := &dedupUploadFile{
+		data: services.UploadData{
+			Artifact:    clientutils.Artifact{LocalPath: "dir/a.zip", TargetPath: "repo/a/a.zip"},
+			TargetProps: props,
+			BuildProps:  "build.name=name;vcs.revision=abc",
+		},
+		params: &params,
+		exists: true,
+	}
+
+	singleFileParams := createSingleFileUploadParams(file)
+	assert.Equal(t, &serviceutils.CommonParams{Pattern: "dir/a.zip", Target: "repo/a/a.zip", TargetProps: props}, singleFileParams.CommonParams)
+	assert.True(t, singleFileParams.Flat)
+	assert.False(t, singleFileParams.AddVcsProps)
+	assert.Equal(t, "build.name=name;vcs.revision=abc", singleFileParams.BuildProps)
+	assert.Equal(t, "bionic/main/amd64", singleFileParams.Deb)
+	assert.Equal(t, int64(0), singleFileParams.MinChecksumDeploy)
+	// The original params aren't changed.
+	assert.Equal(t, "dir/*.zip", params.Pattern)
+
+	file.exists = false
+	assert.Equal(t, int64(math.MaxInt64), createSingleFileUploadParams(file).MinChecksumDeploy)
+}
+
+func TestIsExactUploadPattern(t *testing.T) {
+	dir := t.TempDir()
+	localPath := filepath.Join(dir, "a.zip")
+	assert.NoError(t, os.WriteFile(localPath, []byte("content"), 0644))
+
+	exact, err := isExactUploadPattern(localPath, "repo/a.zip")
+	assert.NoError(t, err)
+	assert.True(t, exact)
+
+	// The pattern of a path with an asterisk matches the other files of its directory too.
+	exact, err = isExactUploadPattern(filepath.Join(dir, "a*.zip"), "repo/a*.zip")
+	assert.NoError(t, err)
+	assert.False(t, exact)
+}
@@ -2,6 +2,7 @@ package generic
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -27,6 +28,8 @@ type DownloadCommand struct {
 	GenericCommand
 	configuration *utils.DownloadConfiguration
 	progress      ioUtils.ProgressMgr
+	lockFilePath  string
+	lockMode      DownloadLockMode
 }
 
 func NewDownloadCommand() *DownloadCommand {
@@ -47,6 +50,13 @@ func (dc *DownloadCommand) SetConfiguration(configuration *utils.DownloadConfigu
 	return dc
 }
 
+// Sets the download lock file, which is either written after the download, or used to download the files it lists instead of the spec.
+func (dc *DownloadCommand) SetLockFile(lockFilePath string, lockMode DownloadLockMode) *DownloadCommand {
+	dc.lockFilePath = lockFilePath
+	dc.lockMode = lockMode
+	return dc
+}
+
 func (dc *DownloadCommand) SetProgress(progress ioUtils.ProgressMgr) {
 	dc.progress = progress
 }
@@ -75,6 +85,22 @@ func (dc *DownloadCommand) download() (err error) {
 		return err
 	}
 
+	// Download the files of the lock file, if they didn't change since it was written.
+	var lockFile *DownloadLockFile
+	if dc.lockMode == DownloadLockInstall {
+		if lockFile, err = ReadDownloadLockFile(dc.lockFilePath); err != nil {
+			return err
+		}
+		if err = lockFile.verifyRemote(servicesManager); err != nil {
+			return err
+		}
+		var lockSpec *spec.SpecFiles
+		if lockSpec, err = lockFile.toSpec(); err != nil {
+			return err
+		}
+		dc.SetSpec(lockSpec)
+	}
+
 	// Build Info Collection:
 	toCollect, err := dc.buildConfiguration.IsCollectBuildInfo()
 	if err != nil {
@@ -109,11 +135,11 @@ func (dc *DownloadCommand) download() (err error) {
 		downloadParamsArray = append(downloadParamsArray, downParams)
 	}
 	// Perform download.
-	// In case of build-info collection/sync-deletes operation/a detailed summary is required, we use the download service which provides results file reader,
+	// In case of build-info collection/sync-deletes operation/a detailed summary/a lock file is required, we use the download service which provides results file reader,
 	// otherwise we use the download service which provides only general counters.
 	var totalDownloaded, totalFailed int
 	var summary *serviceutils.OperationSummary
-	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() {
+	if toCollect || dc.SyncDeletesPath() != "" || dc.DetailedSummary() || dc.lockMode == DownloadLockWrite {
 		summary, err = servicesManager.DownloadFilesWithSummary(downloadParamsArray...)
 		if err != nil {
 			errorOccurred = true
@@ -175,6 +201,18 @@ func (dc *DownloadCommand) download() (err error) {
 	}
 	log.Debug("Downloaded", strconv.Itoa(totalDownloaded), "artifacts.")
 
+	// Lock file
+	switch dc.lockMode {
+	case DownloadLockWrite:
+		if err = dc.writeLockFile(summary, totalFailed); err != nil {
+			return err
+		}
+	case DownloadLockInstall:
+		if err = lockFile.verifyLocal(); err != nil {
+			return err
+		}
+	}
+
 	// Build Info
 	if toCollect {
 		var buildName, buildNumber string
@@ -202,6 +240,21 @@ func (dc *DownloadCommand) download() (err error) {
 	return err
 }
 
+func (dc *DownloadCommand) writeLockFile(summary *serviceutils.OperationSummary, totalFailed int) error {
+	if totalFailed > 0 {
+		return errorutils.CheckErrorf("the download lock file %s wasn't written, since %d files failed to download", dc.lockFilePath, totalFailed)
+	}
+	lockFile, err := createDownloadLockFile(summary.TransferDetailsReader, summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	if err = lockFile.Write(dc.lockFilePath); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Wrote %d files to the download lock file %s.", len(lockFile.Files), dc.lockFilePath))
+	return nil
+}
+
 func getDownloadParams(f *spec.File, configuration *utils.DownloadConfiguration) (downParams services.DownloadParams, err error) {
 	downParams = services.NewDownloadParams()
 	downParams.CommonParams, err = f.ToCommonParams()
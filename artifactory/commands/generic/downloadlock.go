@@ -0,0 +1,175 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+type DownloadLockMode string
+
+const (
+	// Downloads the files of the spec, and writes their paths and checksums to the lock file.
+	DownloadLockWrite DownloadLockMode = "write"
+	// Downloads exactly the files of the lock file, and fails if any of them changed in Artifactory.
+	DownloadLockInstall DownloadLockMode = "install"
+
+	downloadLockFileVersion = 1
+)
+
+// The files downloaded by a spec, which can be downloaded again as they are.
+type DownloadLockFile struct {
+	Version int                 `json:"version"`
+	Files   []DownloadLockEntry `json:"files"`
+}
+
+type DownloadLockEntry struct {
+	// The path in Artifactory, in the form of 'repo/path/to/file'.
+	Path string `json:"path"`
+	// The local path the file is downloaded to, separated by slashes.
+	Target string `json:"target"`
+	Sha256 string `json:"sha256"`
+	Sha1   string `json:"sha1,omitempty"`
+	Size   int64  `json:"size"`
+}
+
+func ReadDownloadLockFile(lockFilePath string) (*DownloadLockFile, error) {
+	data, err := os.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	lockFile := new(DownloadLockFile)
+	if err = json.Unmarshal(data, lockFile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the download lock file %s: %s", lockFilePath, err.Error())
+	}
+	if lockFile.Version != downloadLockFileVersion {
+		return nil, errorutils.CheckErrorf("unsupported download lock file version %d in %s", lockFile.Version, lockFilePath)
+	}
+	return lockFile, nil
+}
+
+func (dlf *DownloadLockFile) Write(lockFilePath string) error {
+	data, err := json.MarshalIndent(dlf, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(lockFilePath, append(data, '\n'), 0644))
+}
+
+// Creates the lock file from the summary of a download.
+// The downloading threads write the readers independently, so their records are matched by their paths in Artifactory.
+func createDownloadLockFile(transferDetailsReader, artifactsDetailsReader *content.ContentReader) (*DownloadLockFile, error) {
+	sha1ByPath := make(map[string]string)
+	for artifactDetails := new(serviceutils.ArtifactDetails); artifactsDetailsReader.NextRecord(artifactDetails) == nil; artifactDetails = new(serviceutils.ArtifactDetails) {
+		sha1ByPath[artifactDetails.ArtifactoryPath] = artifactDetails.Checksums.Sha1
+	}
+	if err := artifactsDetailsReader.GetError(); err != nil {
+		return nil, err
+	}
+
+	lockFile := &DownloadLockFile{Version: downloadLockFileVersion, Files: []DownloadLockEntry{}}
+	for transferDetails := new(clientutils.FileTransferDetails); transferDetailsReader.NextRecord(transferDetails) == nil; transferDetails = new(clientutils.FileTransferDetails) {
+		sha1, found := sha1ByPath[transferDetails.SourcePath]
+		if !found {
+			return nil, errorutils.CheckErrorf("the download summary doesn't include the checksums of %s", transferDetails.SourcePath)
+		}
+		// The download summary doesn't include the SHA-256 of the files, so it's always calculated from the downloaded file.
+		details, err := fileutils.GetFileDetails(transferDetails.TargetPath, true)
+		if err != nil {
+			return nil, err
+		}
+		lockFile.Files = append(lockFile.Files, DownloadLockEntry{
+			Path:   transferDetails.SourcePath,
+			Target: filepath.ToSlash(transferDetails.TargetPath),
+			Sha256: details.Checksum.Sha256,
+			Sha1:   sha1,
+			Size:   details.Size,
+		})
+	}
+	if err := transferDetailsReader.GetError(); err != nil {
+		return nil, err
+	}
+	transferDetailsReader.Reset()
+	artifactsDetailsReader.Reset()
+	sort.Slice(lockFile.Files, func(i, j int) bool {
+		return lockFile.Files[i].Path < lockFile.Files[j].Path
+	})
+	return lockFile, nil
+}
+
+// Creates a spec which downloads each file of the lock file to its target.
+// The files are searched by their exact paths using AQL, since wildcard characters in their names would match other files in a pattern.
+func (dlf *DownloadLockFile) toSpec() (*spec.SpecFiles, error) {
+	specFiles := new(spec.SpecFiles)
+	for _, entry := range dlf.Files {
+		itemsFind, err := json.Marshal(utils.GetItemPathCriterion(entry.Path))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		specFiles.Files = append(specFiles.Files, spec.File{
+			Aql:    serviceutils.Aql{ItemsFind: string(itemsFind)},
+			Target: filepath.FromSlash(entry.Target),
+			Flat:   "true",
+		})
+	}
+	return specFiles, nil
+}
+
+// Verifies that the files of the lock file still exist in Artifactory with the same content.
+func (dlf *DownloadLockFile) verifyRemote(servicesManager artifactory.ArtifactoryServicesManager) error {
+	lockedPaths := make([]string, len(dlf.Files))
+	for i, entry := range dlf.Files {
+		lockedPaths[i] = entry.Path
+	}
+	remoteItems, err := utils.SearchItemsByPaths(servicesManager, lockedPaths, nil, "actual_sha1", "sha256")
+	if err != nil {
+		return err
+	}
+
+	var changes []string
+	for _, entry := range dlf.Files {
+		item, found := remoteItems[entry.Path]
+		switch {
+		case !found:
+			changes = append(changes, entry.Path+": not found")
+		case item.Sha256 != "" && item.Sha256 != entry.Sha256:
+			changes = append(changes, fmt.Sprintf("%s: the SHA-256 changed from %s to %s", entry.Path, entry.Sha256, item.Sha256))
+		case item.Sha256 == "" && entry.Sha1 != "" && item.Actual_Sha1 != entry.Sha1:
+			changes = append(changes, fmt.Sprintf("%s: the SHA-1 changed from %s to %s", entry.Path, entry.Sha1, item.Actual_Sha1))
+		}
+	}
+	if len(changes) > 0 {
+		return errorutils.CheckErrorf("the following files changed in Artifactory since the download lock file was written:\n  %s", strings.Join(changes, "\n  "))
+	}
+	return nil
+}
+
+// Verifies the content of the downloaded files, in case they changed in Artifactory while downloading.
+func (dlf *DownloadLockFile) verifyLocal() error {
+	var changes []string
+	for _, entry := range dlf.Files {
+		details, err := fileutils.GetFileDetails(filepath.FromSlash(entry.Target), true)
+		if err != nil {
+			return err
+		}
+		if details.Checksum.Sha256 != entry.Sha256 {
+			changes = append(changes, fmt.Sprintf("%s: expected SHA-256 %s, but got %s", entry.Target, entry.Sha256, details.Checksum.Sha256))
+		}
+	}
+	if len(changes) > 0 {
+		return errorutils.CheckErrorf("the following downloaded files don't match the download lock file:\n  %s", strings.Join(changes, "\n  "))
+	}
+	return nil
+}
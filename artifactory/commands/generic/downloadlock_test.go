@@ -0,0 +1,125 @@
+package generic
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// The SHA-256 of "content".
+	testContentSha256 = "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+	// The SHA-256 of "other".
+	testOtherSha256 = "d9298a10d1b0735837dc4bd85dac641b0f3cef27a47e5d53a54f2f3f5b2fcffa"
+)
+
+func createTestContentReader(t *testing.T, records ...any) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	require.NoError(t, err)
+	for _, record := range records {
+		writer.Write(record)
+	}
+	require.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), writer.GetArrayKey())
+	t.Cleanup(func() {
+		assert.NoError(t, reader.Close())
+	})
+	return reader
+}
+
+func TestCreateDownloadLockFile(t *testing.T) {
+	tempDir := t.TempDir()
+	first, second := filepath.Join(tempDir, "b.txt"), filepath.Join(tempDir, "a.txt")
+	require.NoError(t, os.WriteFile(first, []byte("content"), 0644))
+	require.NoError(t, os.WriteFile(second, []byte("other"), 0644))
+
+	transferDetailsReader := createTestContentReader(t,
+		clientutils.FileTransferDetails{SourcePath: "repo/dir/b.txt", TargetPath: first},
+		clientutils.FileTransferDetails{SourcePath: "repo/a.txt", TargetPath: second})
+	// The readers are written by different threads, so their records may be in a different order.
+	artifactsDetailsReader := createTestContentReader(t,
+		serviceutils.ArtifactDetails{ArtifactoryPath: "repo/a.txt", Checksums: buildinfo.Checksum{Sha1: "sha1-other"}},
+		serviceutils.ArtifactDetails{ArtifactoryPath: "repo/dir/b.txt", Checksums: buildinfo.Checksum{Sha1: "sha1"}})
+
+	lockFile, err := createDownloadLockFile(transferDetailsReader, artifactsDetailsReader)
+	require.NoError(t, err)
+	assert.Equal(t, []DownloadLockEntry{
+		{Path: "repo/a.txt", Target: filepath.ToSlash(second), Sha256: testOtherSha256, Sha1: "sha1-other", Size: 5},
+		{Path: "repo/dir/b.txt", Target: filepath.ToSlash(first), Sha256: testContentSha256, Sha1: "sha1", Size: 7},
+	}, lockFile.Files)
+	assert.NoError(t, lockFile.verifyLocal())
+
+	// The readers are reset, to be read again by the rest of the download command.
+	transferDetails := new(clientutils.FileTransferDetails)
+	assert.NoError(t, transferDetailsReader.NextRecord(transferDetails))
+	assert.Equal(t, "repo/dir/b.txt", transferDetails.SourcePath)
+
+	// Writing and reading the lock file.
+	lockFilePath := filepath.Join(tempDir, "download.lock.json")
+	require.NoError(t, lockFile.Write(lockFilePath))
+	readLockFile, err := ReadDownloadLockFile(lockFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, lockFile, readLockFile)
+	lockSpec, err := readLockFile.toSpec()
+	require.NoError(t, err)
+	assert.Equal(t, &spec.SpecFiles{Files: []spec.File{
+		{Aql: serviceutils.Aql{ItemsFind: `{"name":"a.txt","path":".","repo":"repo"}`}, Target: second, Flat: "true"},
+		{Aql: serviceutils.Aql{ItemsFind: `{"name":"b.txt","path":"dir","repo":"repo"}`}, Target: first, Flat: "true"},
+	}}, lockSpec)
+
+	// A downloaded file which doesn't match the lock file.
+	require.NoError(t, os.WriteFile(first, []byte("changed"), 0644))
+	assert.ErrorContains(t, lockFile.verifyLocal(), filepath.ToSlash(first)+": expected SHA-256 "+testContentSha256)
+}
+
+func TestReadDownloadLockFileUnsupportedVersion(t *testing.T) {
+	lockFilePath := filepath.Join(t.TempDir(), "download.lock.json")
+	require.NoError(t, os.WriteFile(lockFilePath, []byte(`{"version":2,"files":[]}`), 0644))
+	_, err := ReadDownloadLockFile(lockFilePath)
+	assert.ErrorContains(t, err, "unsupported download lock file version 2")
+}
+
+func TestDownloadLockFileVerifyRemote(t *testing.T) {
+	var query string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		query = string(body)
+		_, err = w.Write([]byte(`{"results":[
+			{"repo":"repo","path":".","name":"a.txt","sha256":"` + testOtherSha256 + `"},
+			{"repo":"repo","path":"dir","name":"b.txt","sha256":"` + testOtherSha256 + `"},
+			{"repo":"repo","path":"old","name":"c.txt","actual_sha1":"sha1"}
+		]}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+
+	lockFile := &DownloadLockFile{Version: downloadLockFileVersion, Files: []DownloadLockEntry{
+		{Path: "repo/a.txt", Sha256: testOtherSha256},
+		{Path: "repo/old/c.txt", Sha256: testContentSha256, Sha1: "sha1"},
+	}}
+	assert.NoError(t, lockFile.verifyRemote(servicesManager))
+	assert.Equal(t, `items.find({"$or":[{"name":"a.txt","path":"."},{"name":"c.txt","path":"old"}],"repo":"repo"}).include("repo","path","name","actual_sha1","sha256")`, query)
+
+	lockFile.Files = append(lockFile.Files,
+		DownloadLockEntry{Path: "repo/dir/b.txt", Sha256: testContentSha256},
+		DownloadLockEntry{Path: "repo/deleted.txt", Sha256: testContentSha256})
+	err = lockFile.verifyRemote(servicesManager)
+	assert.ErrorContains(t, err, "repo/dir/b.txt: the SHA-256 changed from "+testContentSha256+" to "+testOtherSha256)
+	assert.ErrorContains(t, err, "repo/deleted.txt: not found")
+}
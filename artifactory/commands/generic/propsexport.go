@@ -0,0 +1,63 @@
+package generic
+
+import (
+	"fmt"
+
+	gofrog "github.com/jfrog/gofrog/io"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Exports the properties of the items which match the spec to a CSV or JSON file, by the extension of the output path.
+// The exported file can be edited and applied with the props import command.
+type PropsExportCommand struct {
+	PropsCommand
+	outputPath string
+}
+
+func NewPropsExportCommand() *PropsExportCommand {
+	return &PropsExportCommand{PropsCommand: *NewPropsCommand()}
+}
+
+func (pec *PropsExportCommand) SetOutputPath(outputPath string) *PropsExportCommand {
+	pec.outputPath = outputPath
+	return pec
+}
+
+func (pec *PropsExportCommand) CommandName() string {
+	return "rt_props_export"
+}
+
+func (pec *PropsExportCommand) Run() (err error) {
+	if _, err = getPropsFileFormat(pec.outputPath); err != nil {
+		return err
+	}
+	serverDetails, err := pec.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	servicesManager, err := createPropsServiceManager(pec.threads, pec.retries, pec.retryWaitTimeMilliSecs, serverDetails)
+	if err != nil {
+		return err
+	}
+	reader, err := searchItems(pec.Spec(), servicesManager)
+	if err != nil {
+		return err
+	}
+	defer gofrog.Close(reader, &err)
+
+	var entries []*PropsEntry
+	for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+		entries = append(entries, &PropsEntry{Path: item.GetItemRelativePath(), Set: getResultItemProps(item)})
+	}
+	if err = reader.GetError(); err != nil {
+		return err
+	}
+	if err = WritePropsFile(pec.outputPath, entries); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Exported the properties of %d items to %s.", len(entries), pec.outputPath))
+	pec.Result().SetSuccessCount(len(entries))
+	return nil
+}
@@ -0,0 +1,169 @@
+package generic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+var propsFileCsvHeader = []string{"path", "set", "delete"}
+
+// The properties to set and delete on an item.
+// In the CSV format, the properties to set are in the form of 'key1=value1,value2;key2=value3', and the keys to delete are separated by commas.
+type PropsEntry struct {
+	// The path of the item, in the form of 'repo/path/to/item'.
+	Path string `json:"path"`
+	// The properties to set. The existing values of each key are replaced.
+	Set map[string][]string `json:"set,omitempty"`
+	// The keys of the properties to delete.
+	Delete []string `json:"delete,omitempty"`
+}
+
+// Returns the format of a properties file by its extension.
+func getPropsFileFormat(filePath string) (format.OutputFormat, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv":
+		return format.Csv, nil
+	case ".json":
+		return format.Json, nil
+	}
+	return "", errorutils.CheckErrorf("unsupported properties file %s. The supported file extensions are .csv and .json", filePath)
+}
+
+func ReadPropsFile(filePath string) ([]*PropsEntry, error) {
+	fileFormat, err := getPropsFileFormat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var entries []*PropsEntry
+	if fileFormat == format.Json {
+		err = errorutils.CheckError(json.Unmarshal(data, &entries))
+	} else {
+		entries, err = parsePropsCsv(data)
+	}
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the properties file %s: %s", filePath, err.Error())
+	}
+	paths := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		entry.Path = strings.Trim(entry.Path, "/")
+		if !strings.Contains(entry.Path, "/") {
+			return nil, errorutils.CheckErrorf("invalid path '%s' in entry #%d of the properties file %s. Expected a path in the form of 'repo/path'", entry.Path, i+1, filePath)
+		}
+		if paths[entry.Path] {
+			return nil, errorutils.CheckErrorf("the path '%s' appears more than once in the properties file %s", entry.Path, filePath)
+		}
+		paths[entry.Path] = true
+		for _, key := range entry.Delete {
+			if _, ok := entry.Set[key]; ok {
+				return nil, errorutils.CheckErrorf("the property '%s' of the path '%s' is both set and deleted in the properties file %s", key, entry.Path, filePath)
+			}
+		}
+	}
+	return entries, nil
+}
+
+func parsePropsCsv(data []byte) ([]*PropsEntry, error) {
+	csvReader := csv.NewReader(strings.NewReader(string(data)))
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || !strings.EqualFold(strings.TrimSpace(records[0][0]), propsFileCsvHeader[0]) {
+		return nil, errorutils.CheckErrorf("the first row must be the header '%s'", strings.Join(propsFileCsvHeader, ","))
+	}
+	var entries []*PropsEntry
+	for _, record := range records[1:] {
+		entry := &PropsEntry{Path: strings.TrimSpace(record[0])}
+		if len(record) > 1 && record[1] != "" {
+			props, err := serviceutils.ParseProperties(record[1])
+			if err != nil {
+				return nil, err
+			}
+			entry.Set = props.ToMap()
+		}
+		if len(record) > 2 && record[2] != "" {
+			for _, key := range strings.Split(record[2], ",") {
+				entry.Delete = append(entry.Delete, strings.TrimSpace(key))
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func WritePropsFile(filePath string, entries []*PropsEntry) error {
+	fileFormat, err := getPropsFileFormat(filePath)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if fileFormat == format.Json {
+		if entries == nil {
+			entries = []*PropsEntry{}
+		}
+		if data, err = json.MarshalIndent(entries, "", "  "); err != nil {
+			return errorutils.CheckError(err)
+		}
+		data = append(data, '\n')
+	} else if data, err = formatPropsCsv(entries); err != nil {
+		return err
+	}
+	return errorutils.CheckError(os.WriteFile(filePath, data, 0644))
+}
+
+func formatPropsCsv(entries []*PropsEntry) ([]byte, error) {
+	builder := new(strings.Builder)
+	csvWriter := csv.NewWriter(builder)
+	records := [][]string{propsFileCsvHeader}
+	for _, entry := range entries {
+		records = append(records, []string{entry.Path, formatProps(entry.Set), strings.Join(entry.Delete, ",")})
+	}
+	if err := csvWriter.WriteAll(records); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return []byte(builder.String()), nil
+}
+
+// Formats properties as in the --props flag, 'key1=value1,value2;key2=value3', escaping the separators in the values.
+func formatProps(props map[string][]string) string {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	escaper := strings.NewReplacer(",", "\\,", ";", "\\;")
+	formatted := make([]string, len(keys))
+	for i, key := range keys {
+		values := make([]string, len(props[key]))
+		for j, value := range props[key] {
+			values[j] = escaper.Replace(value)
+		}
+		formatted[i] = key + "=" + strings.Join(values, ",")
+	}
+	return strings.Join(formatted, ";")
+}
+
+// Returns the properties of a search result by their keys.
+func getResultItemProps(item *serviceutils.ResultItem) map[string][]string {
+	props := make(map[string][]string)
+	for _, property := range item.Properties {
+		props[property.Key] = append(props[property.Key], property.Value)
+	}
+	for key := range props {
+		sort.Strings(props[key])
+	}
+	return props
+}
@@ -0,0 +1,66 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropsFileRoundTrip(t *testing.T) {
+	entries := []*PropsEntry{
+		{Path: "repo/dir/a.txt", Set: map[string][]string{"build": {"1"}, "tags": {"x,y", "z;w"}}, Delete: []string{"old"}},
+		{Path: "repo/b.txt", Delete: []string{"k1", "k2"}},
+	}
+	for _, fileName := range []string{"props.csv", "props.json"} {
+		t.Run(fileName, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), fileName)
+			require.NoError(t, WritePropsFile(filePath, entries))
+			readEntries, err := ReadPropsFile(filePath)
+			require.NoError(t, err)
+			assert.Equal(t, entries, readEntries)
+		})
+	}
+}
+
+func TestReadPropsFileCsv(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "props.csv")
+	require.NoError(t, os.WriteFile(filePath, []byte("path,set,delete\n/repo/a.txt/,\"a=1;b=2\\,3\",\"c, d\"\nrepo/b.txt\n"), 0644))
+	entries, err := ReadPropsFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, []*PropsEntry{
+		{Path: "repo/a.txt", Set: map[string][]string{"a": {"1"}, "b": {"2,3"}}, Delete: []string{"c", "d"}},
+		{Path: "repo/b.txt"},
+	}, entries)
+}
+
+func TestReadPropsFileInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		content  string
+		expected string
+	}{
+		{"extension", "props.txt", "", "unsupported properties file"},
+		{"header", "props.csv", "repo/a.txt,a=1\n", "the first row must be the header"},
+		{"path", "props.csv", "path,set\nrepo,a=1\n", "invalid path 'repo'"},
+		{"duplicate", "props.json", `[{"path":"repo/a.txt"},{"path":"repo/a.txt/"}]`, "appears more than once"},
+		{"set and delete", "props.json", `[{"path":"repo/a.txt","set":{"a":["1"]},"delete":["a"]}]`, "is both set and deleted"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), test.fileName)
+			require.NoError(t, os.WriteFile(filePath, []byte(test.content), 0644))
+			_, err := ReadPropsFile(filePath)
+			assert.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestGetResultItemProps(t *testing.T) {
+	item := &serviceutils.ResultItem{Properties: []serviceutils.Property{{Key: "a", Value: "2"}, {Key: "b", Value: "x"}, {Key: "a", Value: "1"}}}
+	assert.Equal(t, map[string][]string{"a": {"1", "2"}, "b": {"x"}}, getResultItemProps(item))
+}
@@ -0,0 +1,251 @@
+package generic
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gofrog "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// Applies a CSV or JSON properties file, in which each entry specifies the properties to set and delete on an item.
+// The changes are compared to the current properties and printed before applying them, and a rollback file,
+// which restores the current properties, is written before anything changes.
+type PropsImportCommand struct {
+	PropsCommand
+	filePath         string
+	rollbackFilePath string
+}
+
+func NewPropsImportCommand() *PropsImportCommand {
+	return &PropsImportCommand{PropsCommand: *NewPropsCommand()}
+}
+
+func (pic *PropsImportCommand) SetFilePath(filePath string) *PropsImportCommand {
+	pic.filePath = filePath
+	return pic
+}
+
+// The rollback file is written next to the properties file by default.
+func (pic *PropsImportCommand) SetRollbackFilePath(rollbackFilePath string) *PropsImportCommand {
+	pic.rollbackFilePath = rollbackFilePath
+	return pic
+}
+
+func (pic *PropsImportCommand) CommandName() string {
+	return "rt_props_import"
+}
+
+// A change of a single property of an item. Old is nil if the property is added, and New is nil if it's deleted.
+type propsChange struct {
+	Path string
+	Key  string
+	Old  []string
+	New  []string
+}
+
+type propsChangeRow struct {
+	Path     string `col-name:"Path"`
+	Key      string `col-name:"Property"`
+	OldValue string `col-name:"Old Value"`
+	NewValue string `col-name:"New Value"`
+}
+
+func (pic *PropsImportCommand) Run() error {
+	entries, err := ReadPropsFile(pic.filePath)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := pic.ServerDetails()
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	// The command applies the groups of items with the same changes in parallel, so each group is applied sequentially.
+	servicesManager, err := createPropsServiceManager(1, pic.retries, pic.retryWaitTimeMilliSecs, serverDetails)
+	if err != nil {
+		return err
+	}
+	paths := make([]string, len(entries))
+	for i, entry := range entries {
+		paths[i] = entry.Path
+	}
+	items, err := utils.SearchItemsByPaths(servicesManager, paths, map[string]any{"type": "any"}, "type", "property")
+	if err != nil {
+		return err
+	}
+	var missing []string
+	for _, itemPath := range paths {
+		if _, ok := items[itemPath]; !ok {
+			missing = append(missing, itemPath)
+		}
+	}
+	if len(missing) > 0 {
+		return errorutils.CheckErrorf("the following paths of the properties file weren't found in Artifactory:\n  %s", strings.Join(missing, "\n  "))
+	}
+
+	changes := createPropsChanges(entries, items)
+	if err = printPropsChanges(changes); err != nil {
+		return err
+	}
+	if pic.DryRun() || len(changes) == 0 {
+		return nil
+	}
+	rollbackFilePath := pic.getRollbackFilePath()
+	if err = WritePropsFile(rollbackFilePath, createRollbackEntries(changes)); err != nil {
+		return err
+	}
+	log.Info("The current properties were saved to the rollback file " + rollbackFilePath)
+
+	success, total, err := pic.applyPropsChanges(servicesManager, changes, items)
+	pic.Result().SetSuccessCount(success)
+	pic.Result().SetFailCount(total - success)
+	return err
+}
+
+func (pic *PropsImportCommand) getRollbackFilePath() string {
+	if pic.rollbackFilePath != "" {
+		return pic.rollbackFilePath
+	}
+	ext := filepath.Ext(pic.filePath)
+	return strings.TrimSuffix(pic.filePath, ext) + ".rollback-" + time.Now().Format("20060102150405") + ext
+}
+
+// Compares the entries of a properties file to the current properties of their items, and returns the properties which change.
+func createPropsChanges(entries []*PropsEntry, items map[string]*serviceutils.ResultItem) []propsChange {
+	var changes []propsChange
+	for _, entry := range entries {
+		currentProps := getResultItemProps(items[entry.Path])
+		keys := make([]string, 0, len(entry.Set))
+		for key := range entry.Set {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			newValues := slices.Clone(entry.Set[key])
+			sort.Strings(newValues)
+			if oldValues, ok := currentProps[key]; !ok || !slices.Equal(oldValues, newValues) {
+				changes = append(changes, propsChange{Path: entry.Path, Key: key, Old: currentProps[key], New: newValues})
+			}
+		}
+		for _, key := range entry.Delete {
+			if oldValues, ok := currentProps[key]; ok {
+				changes = append(changes, propsChange{Path: entry.Path, Key: key, Old: oldValues})
+			}
+		}
+	}
+	return changes
+}
+
+// Creates the entries which restore the properties before the changes: the changed and deleted properties are set
+// to their old values, and the added properties are deleted.
+func createRollbackEntries(changes []propsChange) []*PropsEntry {
+	var entries []*PropsEntry
+	entriesByPath := make(map[string]*PropsEntry)
+	for _, change := range changes {
+		entry, ok := entriesByPath[change.Path]
+		if !ok {
+			entry = &PropsEntry{Path: change.Path}
+			entriesByPath[change.Path] = entry
+			entries = append(entries, entry)
+		}
+		if change.Old == nil {
+			entry.Delete = append(entry.Delete, change.Key)
+			continue
+		}
+		if entry.Set == nil {
+			entry.Set = make(map[string][]string)
+		}
+		entry.Set[change.Key] = change.Old
+	}
+	return entries
+}
+
+func printPropsChanges(changes []propsChange) error {
+	rows := make([]propsChangeRow, len(changes))
+	for i, change := range changes {
+		rows[i] = propsChangeRow{Path: change.Path, Key: change.Key, OldValue: strings.Join(change.Old, ","), NewValue: strings.Join(change.New, ",")}
+	}
+	return coreutils.PrintTable(rows, "Properties Changes", "The properties are up to date", false)
+}
+
+// Applies the changes, setting and deleting the properties of all the items with the same changes in a single operation.
+// Returns the number of successful operations on items, and the total number of operations.
+func (pic *PropsImportCommand) applyPropsChanges(servicesManager artifactory.ArtifactoryServicesManager, changes []propsChange, items map[string]*serviceutils.ResultItem) (success, total int, err error) {
+	setProps := make(map[string]map[string][]string)
+	deleteKeys := make(map[string][]string)
+	for _, change := range changes {
+		if change.New == nil {
+			deleteKeys[change.Path] = append(deleteKeys[change.Path], change.Key)
+			continue
+		}
+		if setProps[change.Path] == nil {
+			setProps[change.Path] = make(map[string][]string)
+		}
+		setProps[change.Path][change.Key] = change.New
+	}
+	setGroups := make(map[string][]string)
+	for itemPath, props := range setProps {
+		setGroups[formatProps(props)] = append(setGroups[formatProps(props)], itemPath)
+	}
+	deleteGroups := make(map[string][]string)
+	for itemPath, keys := range deleteKeys {
+		deleteGroups[strings.Join(keys, ",")] = append(deleteGroups[strings.Join(keys, ",")], itemPath)
+	}
+
+	var mutex sync.Mutex
+	var errs []error
+	errGroup := new(errgroup.Group)
+	errGroup.SetLimit(max(pic.threads, 1))
+	apply := func(props string, itemPaths []string, isDelete bool) {
+		total += len(itemPaths)
+		errGroup.Go(func() error {
+			applied, applyErr := applyPropsToItems(servicesManager, props, itemPaths, items, isDelete)
+			mutex.Lock()
+			defer mutex.Unlock()
+			success += applied
+			if applyErr != nil {
+				errs = append(errs, applyErr)
+			}
+			return nil
+		})
+	}
+	for props, itemPaths := range setGroups {
+		apply(props, itemPaths, false)
+	}
+	for keys, itemPaths := range deleteGroups {
+		apply(keys, itemPaths, true)
+	}
+	_ = errGroup.Wait()
+	return success, total, errors.Join(errs...)
+}
+
+func applyPropsToItems(servicesManager artifactory.ArtifactoryServicesManager, props string, itemPaths []string, items map[string]*serviceutils.ResultItem, isDelete bool) (applied int, err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return 0, err
+	}
+	for _, itemPath := range itemPaths {
+		writer.Write(*items[itemPath])
+	}
+	if err = writer.Close(); err != nil {
+		return 0, err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), writer.GetArrayKey())
+	defer gofrog.Close(reader, &err)
+	if isDelete {
+		return servicesManager.DeleteProps(GetPropsParams(reader, props))
+	}
+	return servicesManager.SetProps(GetPropsParams(reader, props))
+}
@@ -0,0 +1,32 @@
+package generic
+
+import (
+	"testing"
+
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePropsChanges(t *testing.T) {
+	items := map[string]*serviceutils.ResultItem{
+		"repo/a.txt": {Properties: []serviceutils.Property{{Key: "keep", Value: "1"}, {Key: "change", Value: "old"}, {Key: "remove", Value: "x"}, {Key: "remove", Value: "y"}}},
+		"repo/b.txt": {},
+	}
+	entries := []*PropsEntry{
+		{Path: "repo/a.txt", Set: map[string][]string{"keep": {"1"}, "change": {"new"}, "add": {"2", "1"}}, Delete: []string{"remove", "missing"}},
+		{Path: "repo/b.txt", Set: map[string][]string{"add": {"v"}}},
+	}
+	changes := createPropsChanges(entries, items)
+	assert.Equal(t, []propsChange{
+		{Path: "repo/a.txt", Key: "add", New: []string{"1", "2"}},
+		{Path: "repo/a.txt", Key: "change", Old: []string{"old"}, New: []string{"new"}},
+		{Path: "repo/a.txt", Key: "remove", Old: []string{"x", "y"}},
+		{Path: "repo/b.txt", Key: "add", New: []string{"v"}},
+	}, changes)
+
+	// The rollback restores the changed and deleted properties, and deletes the added properties.
+	assert.Equal(t, []*PropsEntry{
+		{Path: "repo/a.txt", Set: map[string][]string{"change": {"old"}, "remove": {"x", "y"}}, Delete: []string{"add"}},
+		{Path: "repo/b.txt", Delete: []string{"add"}},
+	}, createRollbackEntries(changes))
+}
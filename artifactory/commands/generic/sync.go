@@ -0,0 +1,431 @@
+package generic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	gofrog "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Syncs a local directory and a repository path in both directions.
+// The files are compared by their checksums, and the checksums of the last sync are kept in the JFrog home directory,
+// to tell which side changed or deleted a file since.
+type SyncCommand struct {
+	GenericCommand
+	localPath      string
+	remotePath     string
+	conflictPolicy SyncConflictPolicy
+	threads        int
+	progress       ioUtils.ProgressMgr
+}
+
+func NewSyncCommand() *SyncCommand {
+	return &SyncCommand{GenericCommand: *NewGenericCommand(), conflictPolicy: SyncFail, threads: 3}
+}
+
+func (sc *SyncCommand) SetLocalPath(localPath string) *SyncCommand {
+	sc.localPath = localPath
+	return sc
+}
+
+// The repository path to sync, in the form of 'repo/path'.
+func (sc *SyncCommand) SetRemotePath(remotePath string) *SyncCommand {
+	sc.remotePath = remotePath
+	return sc
+}
+
+func (sc *SyncCommand) SetConflictPolicy(conflictPolicy SyncConflictPolicy) *SyncCommand {
+	sc.conflictPolicy = conflictPolicy
+	return sc
+}
+
+func (sc *SyncCommand) SetThreads(threads int) *SyncCommand {
+	sc.threads = threads
+	return sc
+}
+
+func (sc *SyncCommand) SetProgress(progress ioUtils.ProgressMgr) {
+	sc.progress = progress
+}
+
+func (sc *SyncCommand) CommandName() string {
+	return "rt_sync"
+}
+
+type syncPlanRow struct {
+	Action string `col-name:"Action"`
+	Path   string `col-name:"Path"`
+	Reason string `col-name:"Reason"`
+}
+
+func (sc *SyncCommand) Run() (err error) {
+	localRoot, err := filepath.Abs(sc.localPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	remoteRoot := strings.Trim(sc.remotePath, "/")
+	if remoteRoot == "" {
+		return errorutils.CheckErrorf("the repository path to sync is missing")
+	}
+	statePath, err := sc.getStatePath(localRoot, remoteRoot)
+	if err != nil {
+		return err
+	}
+	state, err := readSyncState(statePath)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		log.Info("No previous sync was found. Files which exist on one side only are copied to the other side.")
+	}
+
+	localFiles, err := getLocalSyncFiles(localRoot)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(sc.serverDetails, sc.retries, sc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return err
+	}
+	remoteFiles, remoteItems, err := getRemoteSyncFiles(servicesManager, remoteRoot)
+	if err != nil {
+		return err
+	}
+	plan := createSyncPlan(localFiles, remoteFiles, state, sc.conflictPolicy)
+	if err = printSyncPlan(plan); err != nil {
+		return err
+	}
+	if conflicts := plan.Paths(SyncConflict); len(conflicts) > 0 {
+		return errorutils.CheckErrorf("the sync was aborted due to %d conflicts. Resolve them, or choose a conflict policy other than '%s'", len(conflicts), SyncFail)
+	}
+	if sc.DryRun() || len(plan.Entries) == 0 {
+		return nil
+	}
+	if !sc.Quiet() && plan.Count(SyncDeleteLocal)+plan.Count(SyncDeleteRemote) > 0 {
+		if !coreutils.AskYesNo("The sync deletes files. Are you sure you want to continue?", false) {
+			return nil
+		}
+	}
+	if err = fileutils.CreateDirIfNotExist(localRoot); err != nil {
+		return err
+	}
+
+	transferred, success, failed, err := sc.applySyncPlan(plan, localRoot, remoteRoot, remoteItems)
+	sc.result.SetSuccessCount(success)
+	sc.result.SetFailCount(failed)
+	if err != nil || failed > 0 {
+		// The state of the last successful sync is kept, so that the next sync completes this one.
+		return errors.Join(err, errors.New("sync finished with errors, please review the logs"))
+	}
+	return writeSyncState(statePath, createSyncState(plan, localFiles, remoteFiles, transferred))
+}
+
+// Applies the plan. Files are transferred before deleting, so that a failed transfer doesn't follow a deletion on the other side.
+// Returns the relative paths of the files which were transferred according to the transfer summaries.
+func (sc *SyncCommand) applySyncPlan(plan *SyncPlan, localRoot, remoteRoot string, remoteItems map[string]serviceutils.ResultItem) (transferred map[string]bool, success, failed int, err error) {
+	if sc.progress != nil {
+		sc.progress.SetHeadlineMsg("Syncing")
+		sc.progress.InitProgressReaders()
+	}
+	transferred = make(map[string]bool)
+	if downloads := plan.Paths(SyncDownload); len(downloads) > 0 {
+		downloaded, downloadFailed, downloadErr := sc.download(downloads, localRoot, remoteRoot)
+		success, failed, err = success+len(downloaded), failed+downloadFailed, errors.Join(err, downloadErr, verifySyncTransfers(SyncDownload, downloads, downloaded))
+		addSyncTransfers(transferred, downloaded)
+	}
+	if uploads := plan.Paths(SyncUpload); len(uploads) > 0 {
+		uploaded, uploadFailed, uploadErr := sc.upload(uploads, localRoot, remoteRoot)
+		success, failed, err = success+len(uploaded), failed+uploadFailed, errors.Join(err, uploadErr, verifySyncTransfers(SyncUpload, uploads, uploaded))
+		addSyncTransfers(transferred, uploaded)
+	}
+	if err != nil || failed > 0 {
+		return
+	}
+	if deletes := plan.Paths(SyncDeleteRemote); len(deletes) > 0 {
+		deleted, deleteErr := sc.deleteRemote(deletes, remoteItems)
+		success, failed, err = success+deleted, failed+len(deletes)-deleted, errors.Join(err, deleteErr)
+	}
+	for _, relativePath := range plan.Paths(SyncDeleteLocal) {
+		log.Info("Deleting:", filepath.Join(localRoot, filepath.FromSlash(relativePath)))
+		if deleteErr := os.Remove(filepath.Join(localRoot, filepath.FromSlash(relativePath))); deleteErr != nil {
+			failed++
+			err = errors.Join(err, errorutils.CheckError(deleteErr))
+			continue
+		}
+		success++
+	}
+	return
+}
+
+// Downloads the files by their exact paths using AQL, since wildcard characters in their names would match other files in a pattern.
+// Returns the relative paths of the downloaded files, and the number of failed downloads.
+func (sc *SyncCommand) download(relativePaths []string, localRoot, remoteRoot string) (downloaded []string, failed int, err error) {
+	servicesManager, err := utils.CreateDownloadServiceManager(sc.serverDetails, sc.threads, sc.retries, sc.retryWaitTimeMilliSecs, false, sc.progress)
+	if err != nil {
+		return nil, 0, err
+	}
+	downloadParamsArray := make([]services.DownloadParams, 0, len(relativePaths))
+	for _, relativePath := range relativePaths {
+		itemsFind, err := json.Marshal(utils.GetItemPathCriterion(path.Join(remoteRoot, relativePath)))
+		if err != nil {
+			return nil, 0, errorutils.CheckError(err)
+		}
+		downloadParams := services.NewDownloadParams()
+		downloadParams.CommonParams = &serviceutils.CommonParams{
+			Aql:    serviceutils.Aql{ItemsFind: string(itemsFind)},
+			Target: filepath.Join(localRoot, filepath.FromSlash(relativePath)),
+		}
+		downloadParams.Flat = true
+		downloadParamsArray = append(downloadParamsArray, downloadParams)
+	}
+	summary, err := servicesManager.DownloadFilesWithSummary(downloadParamsArray...)
+	if summary == nil {
+		return nil, len(relativePaths), err
+	}
+	defer gofrog.Close(summary, &err)
+	for details := new(clientutils.FileTransferDetails); summary.TransferDetailsReader.NextRecord(details) == nil; details = new(clientutils.FileTransferDetails) {
+		downloaded = append(downloaded, strings.TrimPrefix(details.SourcePath, remoteRoot+"/"))
+	}
+	return downloaded, summary.TotalFailed, errors.Join(err, summary.TransferDetailsReader.GetError())
+}
+
+// Uploads the files by their local paths. A file with wildcard characters in its path fails, since its pattern could match other files.
+// Returns the relative paths of the uploaded files, and the number of failed uploads.
+func (sc *SyncCommand) upload(relativePaths []string, localRoot, remoteRoot string) (uploaded []string, failed int, err error) {
+	servicesManager, err := utils.CreateUploadServiceManager(sc.serverDetails, sc.threads, sc.retries, sc.retryWaitTimeMilliSecs, false, sc.progress)
+	if err != nil {
+		return nil, 0, err
+	}
+	uploadParamsArray := make([]services.UploadParams, 0, len(relativePaths))
+	for _, relativePath := range relativePaths {
+		localPath, targetPath := filepath.Join(localRoot, filepath.FromSlash(relativePath)), path.Join(remoteRoot, relativePath)
+		exact, err := isExactUploadPattern(localPath, targetPath)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !exact {
+			log.Error(fmt.Sprintf("Failed uploading %s: its path contains wildcard characters, so it can't be uploaded separately.", localPath))
+			failed++
+			continue
+		}
+		uploadParams := services.NewUploadParams()
+		uploadParams.CommonParams = &serviceutils.CommonParams{Pattern: localPath, Target: targetPath}
+		uploadParams.Flat = true
+		uploadParamsArray = append(uploadParamsArray, uploadParams)
+	}
+	if len(uploadParamsArray) == 0 {
+		return nil, failed, nil
+	}
+	summary, err := servicesManager.UploadFilesWithSummary(uploadParamsArray...)
+	if summary == nil {
+		return nil, failed + len(uploadParamsArray), err
+	}
+	defer gofrog.Close(summary, &err)
+	for details := new(clientutils.FileTransferDetails); summary.TransferDetailsReader.NextRecord(details) == nil; details = new(clientutils.FileTransferDetails) {
+		relativePath, relErr := filepath.Rel(localRoot, details.SourcePath)
+		if relErr != nil {
+			return nil, failed, errorutils.CheckError(relErr)
+		}
+		uploaded = append(uploaded, filepath.ToSlash(relativePath))
+	}
+	return uploaded, failed + summary.TotalFailed, errors.Join(err, summary.TransferDetailsReader.GetError())
+}
+
+// Verifies that exactly the planned files were transferred, according to the transfer summary.
+func verifySyncTransfers(action SyncAction, planned, transferred []string) error {
+	plannedPaths := make(map[string]bool, len(planned))
+	for _, relativePath := range planned {
+		plannedPaths[relativePath] = true
+	}
+	var unexpected []string
+	for _, relativePath := range transferred {
+		if !plannedPaths[relativePath] {
+			unexpected = append(unexpected, relativePath)
+		}
+	}
+	if len(unexpected) > 0 {
+		return errorutils.CheckErrorf("the following files weren't planned to %s, but were transferred:\n  %s", action, strings.Join(unexpected, "\n  "))
+	}
+	if len(transferred) != len(planned) {
+		return errorutils.CheckErrorf("%d files were planned to %s, but %d were transferred", len(planned), action, len(transferred))
+	}
+	return nil
+}
+
+func addSyncTransfers(transferred map[string]bool, relativePaths []string) {
+	for _, relativePath := range relativePaths {
+		transferred[relativePath] = true
+	}
+}
+
+func (sc *SyncCommand) deleteRemote(relativePaths []string, remoteItems map[string]serviceutils.ResultItem) (deleted int, err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return 0, err
+	}
+	for _, relativePath := range relativePaths {
+		writer.Write(remoteItems[relativePath])
+	}
+	if err = writer.Close(); err != nil {
+		return 0, err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), writer.GetArrayKey())
+	defer gofrog.Close(reader, &err)
+	servicesManager, err := utils.CreateDeleteServiceManager(sc.serverDetails, sc.threads, sc.retries, sc.retryWaitTimeMilliSecs, false)
+	if err != nil {
+		return 0, err
+	}
+	return servicesManager.DeleteFiles(reader)
+}
+
+// The state file is unique to the server, the local directory and the repository path.
+func (sc *SyncCommand) getStatePath(localRoot, remoteRoot string) (string, error) {
+	syncDir, err := coreutils.GetJfrogSyncDir()
+	if err != nil {
+		return "", err
+	}
+	key := sha256.Sum256([]byte(strings.Join([]string{sc.serverDetails.ArtifactoryUrl, localRoot, remoteRoot}, "\n")))
+	return filepath.Join(syncDir, hex.EncodeToString(key[:])+".json"), nil
+}
+
+// Returns nil if no sync was completed yet.
+func readSyncState(statePath string) (*syncState, error) {
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	state := new(syncState)
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the sync state file %s: %s", statePath, err.Error())
+	}
+	return state, nil
+}
+
+func writeSyncState(statePath string, state *syncState) error {
+	if err := fileutils.CreateDirIfNotExist(filepath.Dir(statePath)); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(statePath, data, 0600))
+}
+
+// Returns the files which exist on both sides after the plan is applied.
+// The files to upload or download are included only if they were transferred, according to the transfer summaries.
+func createSyncState(plan *SyncPlan, local, remote map[string]*syncFile, transferred map[string]bool) *syncState {
+	state := &syncState{Files: make(map[string]*syncFile)}
+	for _, relativePath := range plan.Unchanged {
+		state.Files[relativePath] = local[relativePath]
+	}
+	for _, entry := range plan.Entries {
+		if !transferred[entry.Path] {
+			continue
+		}
+		switch entry.Action {
+		case SyncUpload:
+			state.Files[entry.Path] = local[entry.Path]
+		case SyncDownload:
+			state.Files[entry.Path] = remote[entry.Path]
+		}
+	}
+	return state
+}
+
+// Returns the regular files in the directory by their slash separated relative paths. Symlinks aren't synced.
+// A directory which doesn't exist yet has no files.
+func getLocalSyncFiles(localRoot string) (map[string]*syncFile, error) {
+	files := make(map[string]*syncFile)
+	exists, err := fileutils.IsDirExists(localRoot, false)
+	if err != nil || !exists {
+		return files, err
+	}
+	err = filepath.WalkDir(localRoot, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		details, err := fileutils.GetFileDetails(filePath, true)
+		if err != nil {
+			return err
+		}
+		relativePath, err := filepath.Rel(localRoot, filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(relativePath)] = &syncFile{Sha1: details.Checksum.Sha1, Sha256: details.Checksum.Sha256, Size: details.Size, Modified: info.ModTime()}
+		return nil
+	})
+	return files, errorutils.CheckError(err)
+}
+
+// Returns the files under the repository path by their relative paths, and the search results to delete them by.
+func getRemoteSyncFiles(servicesManager artifactory.ArtifactoryServicesManager, remoteRoot string) (files map[string]*syncFile, items map[string]serviceutils.ResultItem, err error) {
+	searchSpec := spec.NewBuilder().Pattern(remoteRoot + "/").Recursive(true).
+		Include([]string{"type", "size", "modified", "actual_sha1", "sha256"}).BuildSpec()
+	readers, closeReaders, err := utils.SearchFiles(servicesManager, searchSpec)
+	defer func() {
+		err = errors.Join(err, closeReaders())
+	}()
+	if err != nil {
+		return nil, nil, err
+	}
+	files = make(map[string]*syncFile)
+	items = make(map[string]serviceutils.ResultItem)
+	for _, reader := range readers {
+		for item := new(serviceutils.ResultItem); reader.NextRecord(item) == nil; item = new(serviceutils.ResultItem) {
+			if item.Type == string(serviceutils.Folder) {
+				continue
+			}
+			relativePath := strings.TrimPrefix(item.GetItemRelativePath(), remoteRoot+"/")
+			modified, _ := time.Parse(time.RFC3339, item.Modified)
+			files[relativePath] = &syncFile{Sha1: item.Actual_Sha1, Sha256: item.Sha256, Size: item.Size, Modified: modified}
+			items[relativePath] = *item
+		}
+		if err = reader.GetError(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return files, items, nil
+}
+
+func printSyncPlan(plan *SyncPlan) error {
+	rows := make([]syncPlanRow, 0, len(plan.Entries))
+	for _, entry := range plan.Entries {
+		rows = append(rows, syncPlanRow{Action: string(entry.Action), Path: entry.Path, Reason: entry.Reason})
+	}
+	if err := coreutils.PrintTable(rows, "Sync Plan", "The local directory and the repository path are in sync", false); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Upload: %d, download: %d, delete locally: %d, delete from Artifactory: %d, conflicts: %d, unchanged: %d.",
+		plan.Count(SyncUpload), plan.Count(SyncDownload), plan.Count(SyncDeleteLocal), plan.Count(SyncDeleteRemote), plan.Count(SyncConflict), len(plan.Unchanged)))
+	return nil
+}
@@ -0,0 +1,188 @@
+package generic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type SyncConflictPolicy string
+
+const (
+	// The side which was modified last wins.
+	SyncNewerWins  SyncConflictPolicy = "newer-wins"
+	SyncLocalWins  SyncConflictPolicy = "local-wins"
+	SyncRemoteWins SyncConflictPolicy = "remote-wins"
+	// The sync fails without applying any change.
+	SyncFail SyncConflictPolicy = "fail"
+)
+
+var SyncConflictPolicies = []SyncConflictPolicy{SyncNewerWins, SyncLocalWins, SyncRemoteWins, SyncFail}
+
+func GetSyncConflictPolicy(policy string) (SyncConflictPolicy, error) {
+	for _, supported := range SyncConflictPolicies {
+		if string(supported) == policy {
+			return supported, nil
+		}
+	}
+	supportedPolicies := make([]string, len(SyncConflictPolicies))
+	for i, supported := range SyncConflictPolicies {
+		supportedPolicies[i] = string(supported)
+	}
+	return "", errorutils.CheckErrorf("unsupported conflict policy '%s'. The supported policies are: %s", policy, strings.Join(supportedPolicies, ", "))
+}
+
+type SyncAction string
+
+const (
+	SyncUpload       SyncAction = "upload"
+	SyncDownload     SyncAction = "download"
+	SyncDeleteLocal  SyncAction = "delete-local"
+	SyncDeleteRemote SyncAction = "delete-remote"
+	// An unresolved conflict, when the conflict policy is 'fail'.
+	SyncConflict SyncAction = "conflict"
+)
+
+// The details of a file on one side of the sync.
+type syncFile struct {
+	Sha1     string    `json:"sha1,omitempty"`
+	Sha256   string    `json:"sha256,omitempty"`
+	Size     int64     `json:"size,omitempty"`
+	Modified time.Time `json:"-"`
+}
+
+// Compares the content of two files by SHA-256, or by SHA-1 if the SHA-256 of one of them is unknown.
+// Artifactory doesn't hold the SHA-256 of some of the files which were deployed by older versions.
+func (sf *syncFile) sameContent(other *syncFile) bool {
+	if sf.Sha256 != "" && other.Sha256 != "" {
+		return sf.Sha256 == other.Sha256
+	}
+	return sf.Sha1 != "" && sf.Sha1 == other.Sha1
+}
+
+// The files, by their paths relative to the synced directory, as they were on both sides at the end of the last sync.
+type syncState struct {
+	Files map[string]*syncFile `json:"files"`
+}
+
+type SyncPlanEntry struct {
+	// The path relative to the synced directory, separated by slashes.
+	Path   string
+	Action SyncAction
+	Reason string
+}
+
+type SyncPlan struct {
+	Entries   []SyncPlanEntry
+	Unchanged []string
+}
+
+func (sp *SyncPlan) Count(action SyncAction) int {
+	count := 0
+	for _, entry := range sp.Entries {
+		if entry.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+func (sp *SyncPlan) Paths(action SyncAction) []string {
+	var paths []string
+	for _, entry := range sp.Entries {
+		if entry.Action == action {
+			paths = append(paths, entry.Path)
+		}
+	}
+	return paths
+}
+
+// Compares the local and remote files to decide how to sync each of them.
+// The state of the last sync tells a file which was deleted on one side from a new file on the other side,
+// and which side changed a file since. Without a state, nothing is deleted and every difference is a conflict.
+func createSyncPlan(local, remote map[string]*syncFile, state *syncState, policy SyncConflictPolicy) *SyncPlan {
+	paths := make(map[string]bool, len(local)+len(remote))
+	for filePath := range local {
+		paths[filePath] = true
+	}
+	for filePath := range remote {
+		paths[filePath] = true
+	}
+	sortedPaths := make([]string, 0, len(paths))
+	for filePath := range paths {
+		sortedPaths = append(sortedPaths, filePath)
+	}
+	sort.Strings(sortedPaths)
+
+	plan := new(SyncPlan)
+	for _, filePath := range sortedPaths {
+		localFile, remoteFile := local[filePath], remote[filePath]
+		var synced *syncFile
+		if state != nil {
+			synced = state.Files[filePath]
+		}
+		entry := SyncPlanEntry{Path: filePath}
+		switch {
+		case localFile != nil && remoteFile != nil:
+			if localFile.sameContent(remoteFile) {
+				plan.Unchanged = append(plan.Unchanged, filePath)
+				continue
+			}
+			switch {
+			case synced != nil && synced.sameContent(localFile):
+				entry.Action, entry.Reason = SyncDownload, "changed in Artifactory"
+			case synced != nil && synced.sameContent(remoteFile):
+				entry.Action, entry.Reason = SyncUpload, "changed locally"
+			default:
+				entry.Action, entry.Reason = resolveSyncConflict(policy, localFile.Modified.After(remoteFile.Modified), SyncUpload, SyncDownload)
+			}
+		case localFile != nil:
+			switch {
+			case synced == nil:
+				entry.Action, entry.Reason = SyncUpload, "new local file"
+			case synced.sameContent(localFile):
+				entry.Action, entry.Reason = SyncDeleteLocal, "deleted from Artifactory"
+			default:
+				entry.Action, entry.Reason = resolveSyncConflict(policy, true, SyncUpload, SyncDeleteLocal)
+			}
+		default:
+			switch {
+			case synced == nil:
+				entry.Action, entry.Reason = SyncDownload, "new file in Artifactory"
+			case synced.sameContent(remoteFile):
+				entry.Action, entry.Reason = SyncDeleteRemote, "deleted locally"
+			default:
+				entry.Action, entry.Reason = resolveSyncConflict(policy, false, SyncDeleteRemote, SyncDownload)
+			}
+		}
+		plan.Entries = append(plan.Entries, entry)
+	}
+	return plan
+}
+
+// Resolves a conflict between a local and a remote change by the policy.
+// When one of the sides deleted the file, the side which modified it is considered newer.
+func resolveSyncConflict(policy SyncConflictPolicy, localIsNewer bool, localWins, remoteWins SyncAction) (SyncAction, string) {
+	reason := "changed on both sides"
+	if localWins == SyncUpload && remoteWins == SyncDeleteLocal {
+		reason = "changed locally and deleted from Artifactory"
+	} else if localWins == SyncDeleteRemote {
+		reason = "deleted locally and changed in Artifactory"
+	}
+	switch policy {
+	case SyncLocalWins:
+		return localWins, fmt.Sprintf("%s, the local side wins", reason)
+	case SyncRemoteWins:
+		return remoteWins, fmt.Sprintf("%s, Artifactory wins", reason)
+	case SyncNewerWins:
+		if localIsNewer {
+			return localWins, fmt.Sprintf("%s, the local side is newer", reason)
+		}
+		return remoteWins, fmt.Sprintf("%s, Artifactory is newer", reason)
+	default:
+		return SyncConflict, reason
+	}
+}
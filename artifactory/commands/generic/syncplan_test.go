@@ -0,0 +1,134 @@
+package generic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var syncTestTime = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newSyncTestFile(checksum string, hoursAgo int) *syncFile {
+	return &syncFile{Sha1: "sha1-" + checksum, Sha256: "sha256-" + checksum, Modified: syncTestTime.Add(-time.Duration(hoursAgo) * time.Hour)}
+}
+
+func TestSyncFileSameContent(t *testing.T) {
+	assert.True(t, (&syncFile{Sha1: "a", Sha256: "b"}).sameContent(&syncFile{Sha1: "a", Sha256: "b"}))
+	assert.False(t, (&syncFile{Sha1: "a", Sha256: "b"}).sameContent(&syncFile{Sha1: "a", Sha256: "c"}))
+	// Without the SHA-256 of one of the files, the SHA-1 checksums are compared.
+	assert.True(t, (&syncFile{Sha1: "a", Sha256: "b"}).sameContent(&syncFile{Sha1: "a"}))
+	assert.False(t, (&syncFile{Sha1: "a", Sha256: "b"}).sameContent(&syncFile{Sha1: "c"}))
+	assert.False(t, (&syncFile{}).sameContent(&syncFile{}))
+}
+
+func TestCreateSyncPlanFirstSync(t *testing.T) {
+	local := map[string]*syncFile{
+		"same.txt":    newSyncTestFile("same", 1),
+		"local.txt":   newSyncTestFile("local", 1),
+		"changed.txt": newSyncTestFile("new", 1),
+	}
+	remote := map[string]*syncFile{
+		"same.txt":    newSyncTestFile("same", 2),
+		"dir/remote":  newSyncTestFile("remote", 1),
+		"changed.txt": newSyncTestFile("old", 2),
+	}
+	plan := createSyncPlan(local, remote, nil, SyncFail)
+	assert.Equal(t, []SyncPlanEntry{
+		{Path: "changed.txt", Action: SyncConflict, Reason: "changed on both sides"},
+		{Path: "dir/remote", Action: SyncDownload, Reason: "new file in Artifactory"},
+		{Path: "local.txt", Action: SyncUpload, Reason: "new local file"},
+	}, plan.Entries)
+	assert.Equal(t, []string{"same.txt"}, plan.Unchanged)
+
+	plan = createSyncPlan(local, remote, nil, SyncNewerWins)
+	assert.Equal(t, SyncPlanEntry{Path: "changed.txt", Action: SyncUpload, Reason: "changed on both sides, the local side is newer"}, plan.Entries[0])
+	plan = createSyncPlan(local, remote, nil, SyncRemoteWins)
+	assert.Equal(t, SyncPlanEntry{Path: "changed.txt", Action: SyncDownload, Reason: "changed on both sides, Artifactory wins"}, plan.Entries[0])
+}
+
+func TestCreateSyncPlanWithState(t *testing.T) {
+	state := &syncState{Files: map[string]*syncFile{
+		"changed-locally.txt":     newSyncTestFile("base", 10),
+		"changed-remotely.txt":    newSyncTestFile("base", 10),
+		"deleted-locally.txt":     newSyncTestFile("base", 10),
+		"deleted-remotely.txt":    newSyncTestFile("base", 10),
+		"modified-and-deleted":    newSyncTestFile("base", 10),
+		"deleted-and-modified":    newSyncTestFile("base", 10),
+		"changed-on-both-sides":   newSyncTestFile("base", 10),
+		"deleted-on-both-sides":   newSyncTestFile("base", 10),
+		"unchanged-on-both-sides": newSyncTestFile("base", 10),
+	}}
+	local := map[string]*syncFile{
+		"changed-locally.txt":     newSyncTestFile("local", 1),
+		"changed-remotely.txt":    newSyncTestFile("base", 10),
+		"deleted-remotely.txt":    newSyncTestFile("base", 10),
+		"modified-and-deleted":    newSyncTestFile("local", 1),
+		"changed-on-both-sides":   newSyncTestFile("local", 5),
+		"unchanged-on-both-sides": newSyncTestFile("base", 10),
+	}
+	remote := map[string]*syncFile{
+		"changed-locally.txt":     newSyncTestFile("base", 10),
+		"changed-remotely.txt":    newSyncTestFile("remote", 1),
+		"deleted-locally.txt":     newSyncTestFile("base", 10),
+		"deleted-and-modified":    newSyncTestFile("remote", 1),
+		"changed-on-both-sides":   newSyncTestFile("remote", 2),
+		"unchanged-on-both-sides": newSyncTestFile("base", 10),
+	}
+	plan := createSyncPlan(local, remote, state, SyncNewerWins)
+	assert.Equal(t, []SyncPlanEntry{
+		{Path: "changed-locally.txt", Action: SyncUpload, Reason: "changed locally"},
+		{Path: "changed-on-both-sides", Action: SyncDownload, Reason: "changed on both sides, Artifactory is newer"},
+		{Path: "changed-remotely.txt", Action: SyncDownload, Reason: "changed in Artifactory"},
+		{Path: "deleted-and-modified", Action: SyncDownload, Reason: "deleted locally and changed in Artifactory, Artifactory is newer"},
+		{Path: "deleted-locally.txt", Action: SyncDeleteRemote, Reason: "deleted locally"},
+		{Path: "deleted-remotely.txt", Action: SyncDeleteLocal, Reason: "deleted from Artifactory"},
+		{Path: "modified-and-deleted", Action: SyncUpload, Reason: "changed locally and deleted from Artifactory, the local side is newer"},
+	}, plan.Entries)
+	assert.Equal(t, []string{"unchanged-on-both-sides"}, plan.Unchanged)
+
+	plan = createSyncPlan(local, remote, state, SyncLocalWins)
+	assert.Equal(t, []string{"deleted-and-modified", "deleted-locally.txt"}, plan.Paths(SyncDeleteRemote))
+	assert.Equal(t, []string{"changed-locally.txt", "changed-on-both-sides", "modified-and-deleted"}, plan.Paths(SyncUpload))
+
+	plan = createSyncPlan(local, remote, state, SyncRemoteWins)
+	assert.Equal(t, []string{"deleted-remotely.txt", "modified-and-deleted"}, plan.Paths(SyncDeleteLocal))
+	assert.Equal(t, 3, plan.Count(SyncDownload))
+
+	plan = createSyncPlan(local, remote, state, SyncFail)
+	assert.Equal(t, []string{"changed-on-both-sides", "deleted-and-modified", "modified-and-deleted"}, plan.Paths(SyncConflict))
+}
+
+func TestCreateSyncState(t *testing.T) {
+	local := map[string]*syncFile{"a": newSyncTestFile("a", 1), "b": newSyncTestFile("b", 1), "c": newSyncTestFile("c", 1)}
+	remote := map[string]*syncFile{"a": newSyncTestFile("a", 1), "d": newSyncTestFile("d", 1), "e": newSyncTestFile("e", 1)}
+	plan := &SyncPlan{
+		Entries: []SyncPlanEntry{
+			{Path: "b", Action: SyncUpload},
+			{Path: "c", Action: SyncDeleteLocal},
+			{Path: "d", Action: SyncDownload},
+			{Path: "e", Action: SyncDeleteRemote},
+		},
+		Unchanged: []string{"a"},
+	}
+	state := createSyncState(plan, local, remote, map[string]bool{"b": true, "d": true})
+	assert.Equal(t, map[string]*syncFile{"a": local["a"], "b": local["b"], "d": remote["d"]}, state.Files)
+
+	// A file which isn't in the transfer summary isn't recorded.
+	state = createSyncState(plan, local, remote, map[string]bool{"d": true})
+	assert.Equal(t, map[string]*syncFile{"a": local["a"], "d": remote["d"]}, state.Files)
+}
+
+func TestVerifySyncTransfers(t *testing.T) {
+	assert.NoError(t, verifySyncTransfers(SyncUpload, []string{"a", "b"}, []string{"b", "a"}))
+	assert.EqualError(t, verifySyncTransfers(SyncDownload, []string{"a", "b"}, []string{"a"}), "2 files were planned to download, but 1 were transferred")
+	assert.EqualError(t, verifySyncTransfers(SyncUpload, []string{"a*"}, []string{"a*", "ab"}), "the following files weren't planned to upload, but were transferred:\n  ab")
+}
+
+func TestGetSyncConflictPolicy(t *testing.T) {
+	policy, err := GetSyncConflictPolicy("newer-wins")
+	assert.NoError(t, err)
+	assert.Equal(t, SyncNewerWins, policy)
+	_, err = GetSyncConflictPolicy("mine")
+	assert.ErrorContains(t, err, "unsupported conflict policy 'mine'")
+}
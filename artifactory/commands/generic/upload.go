@@ -130,6 +130,14 @@ func (uc *UploadCommand) upload() (err error) {
 		uploadParamsArray = append(uploadParamsArray, uploadParams)
 	}
 
+	dedupFailCount := 0
+	if uc.uploadConfiguration.Deduplicate {
+		uploadParamsArray, dedupFailCount, err = uc.prepareDeduplicatedUpload(servicesManager, uploadParamsArray)
+		if err != nil {
+			return
+		}
+	}
+
 	// Perform upload.
 	// In case of build-info collection or a detailed summary request, we use the upload service which provides results file reader,
 	// otherwise we use the upload service which provides only general counters.
@@ -170,6 +178,7 @@ func (uc *UploadCommand) upload() (err error) {
 			log.Error(err)
 		}
 	}
+	failCount += dedupFailCount
 	uc.result.SetSuccessCount(successCount)
 	uc.result.SetFailCount(failCount)
 	if errorOccurred {
@@ -2,6 +2,7 @@ package golang
 
 import (
 	"os/exec"
+	"time"
 
 	"github.com/jfrog/build-info-go/build"
 	commandutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
@@ -9,8 +10,10 @@ import (
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
 	goutils "github.com/jfrog/jfrog-cli-core/v2/utils/golang"
+	"github.com/jfrog/jfrog-client-go/artifactory"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 const minSupportedArtifactoryVersion = "6.2.0"
@@ -18,6 +21,7 @@ const minSupportedArtifactoryVersion = "6.2.0"
 type GoPublishCommandArgs struct {
 	buildConfiguration *buildUtils.BuildConfiguration
 	version            string
+	versionFromGitTag  bool
 	detailedSummary    bool
 	excludedPatterns   []string
 	result             *commandutils.Result
@@ -111,8 +115,16 @@ func (gpc *GoPublishCommand) Run() error {
 		}
 	}
 
+	version := gpc.version
+	var versionTime time.Time
+	if gpc.versionFromGitTag {
+		if version, versionTime, err = gpc.getVersionFromGitTag(serviceManager); err != nil {
+			return err
+		}
+	}
+
 	// Publish the package to Artifactory.
-	summary, artifacts, err := publishPackage(gpc.version, gpc.TargetRepo(), buildName, buildNumber, project, gpc.GetExcludedPatterns(), serviceManager)
+	summary, artifacts, err := publishPackage(version, versionTime, gpc.TargetRepo(), buildName, buildNumber, project, gpc.GetExcludedPatterns(), serviceManager)
 	if err != nil {
 		return err
 	}
@@ -140,6 +152,29 @@ func (gpc *GoPublishCommand) Run() error {
 	return err
 }
 
+// Returns the version from the git tag of the current commit, after validating it can be published.
+func (gpc *GoPublishCommand) getVersionFromGitTag(serviceManager artifactory.ArtifactoryServicesManager) (version string, versionTime time.Time, err error) {
+	if gpc.version != "" {
+		return "", time.Time{}, errorutils.CheckErrorf("a version can't be provided when the version is taken from the git tag")
+	}
+	projectPath, err := goutils.GetProjectRoot()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	moduleName, err := goutils.GetModuleName(projectPath)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if version, versionTime, err = getVersionFromGitTag(projectPath); err != nil {
+		return "", time.Time{}, err
+	}
+	log.Info("Publishing version", version, "from the git tag of the current commit.")
+	if err = validateModuleVersion(moduleName, version); err != nil {
+		return "", time.Time{}, err
+	}
+	return version, versionTime, validateVersionNotPublished(moduleName, version, gpc.TargetRepo(), serviceManager)
+}
+
 func (gpc *GoPublishCommandArgs) Result() *commandutils.Result {
 	return gpc.result
 }
@@ -149,6 +184,12 @@ func (gpc *GoPublishCommandArgs) SetVersion(version string) *GoPublishCommandArg
 	return gpc
 }
 
+// Takes the version from the git tag of the current commit, instead of the version set by SetVersion.
+func (gpc *GoPublishCommandArgs) SetVersionFromGitTag(versionFromGitTag bool) *GoPublishCommandArgs {
+	gpc.versionFromGitTag = versionFromGitTag
+	return gpc
+}
+
 func (gpc *GoPublishCommandArgs) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *GoPublishCommandArgs {
 	gpc.buildConfiguration = buildConfiguration
 	return gpc
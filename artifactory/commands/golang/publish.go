@@ -27,7 +27,8 @@ import (
 )
 
 // Publish go project to Artifactory.
-func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectKey string, excludedPatterns []string, servicesManager artifactory.ArtifactoryServicesManager) (summary *servicesutils.OperationSummary, artifacts []buildinfo.Artifact, err error) {
+// The time of the version is written to the info file. If it's zero, the current time is used.
+func publishPackage(packageVersion string, versionTime time.Time, targetRepo, buildName, buildNumber, projectKey string, excludedPatterns []string, servicesManager artifactory.ArtifactoryServicesManager) (summary *servicesutils.OperationSummary, artifacts []buildinfo.Artifact, err error) {
 	projectPath, err := goutils.GetProjectRoot()
 	if err != nil {
 		return nil, nil, errorutils.CheckError(err)
@@ -87,7 +88,7 @@ func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectK
 	if version.AtLeast(_go.ArtifactoryMinSupportedVersion) {
 		log.Debug("Creating info file", projectPath)
 		var pathToInfo string
-		pathToInfo, err = createInfoFile(packageVersion)
+		pathToInfo, err = createInfoFile(packageVersion, versionTime)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -111,9 +112,11 @@ func publishPackage(packageVersion, targetRepo, buildName, buildNumber, projectK
 
 // Creates the info file.
 // Returns the path to that file.
-func createInfoFile(packageVersion string) (path string, err error) {
-	currentTime := time.Now().Format("2006-01-02T15:04:05Z")
-	goInfoContent := goInfo{Version: packageVersion, Time: currentTime}
+func createInfoFile(packageVersion string, versionTime time.Time) (path string, err error) {
+	if versionTime.IsZero() {
+		versionTime = time.Now()
+	}
+	goInfoContent := goInfo{Version: packageVersion, Time: versionTime.Format("2006-01-02T15:04:05Z")}
 	content, err := json.Marshal(&goInfoContent)
 	if err != nil {
 		return "", errorutils.CheckError(err)
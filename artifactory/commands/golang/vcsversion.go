@@ -0,0 +1,140 @@
+package golang
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+const incompatibleSuffix = "+incompatible"
+
+// Returns the version of the module from the git tag of the current commit, and the commit time.
+// Modules in subdirectories of the repository are tagged with the subdirectory as a prefix, for example 'sub/v1.2.3'.
+// If the commit has several version tags, the highest version is returned.
+func getVersionFromGitTag(projectPath string) (version string, commitTime time.Time, err error) {
+	repoRoot, err := runGit(projectPath, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	tagPrefix, err := getModuleTagPrefix(repoRoot, projectPath)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	tags, err := runGit(projectPath, "tag", "--points-at", "HEAD")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	version = selectVersionTag(strings.Fields(tags), tagPrefix)
+	if version == "" {
+		return "", time.Time{}, errorutils.CheckErrorf("the current commit has no semantic version tag with the prefix '%s'. Tag the commit with '%sv<major>.<minor>.<patch>' to publish it", tagPrefix, tagPrefix)
+	}
+	timestamp, err := runGit(projectPath, "log", "-1", "--format=%ct", "HEAD")
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return "", time.Time{}, errorutils.CheckError(err)
+	}
+	return version, time.Unix(seconds, 0).UTC(), nil
+}
+
+// Returns the tag prefix of the module, which is its directory relative to the repository root, followed by a slash.
+func getModuleTagPrefix(repoRoot, projectPath string) (string, error) {
+	// Resolve symlinks, since git returns the real path of the repository root.
+	realProjectPath, err := filepath.EvalSymlinks(projectPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	realRepoRoot, err := filepath.EvalSymlinks(repoRoot)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	moduleDir, err := filepath.Rel(realRepoRoot, realProjectPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if moduleDir == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(moduleDir) + "/", nil
+}
+
+// Returns the highest canonical semantic version of the tags with the prefix, without the prefix.
+// Versions with the '+incompatible' suffix are selected too, so that validateModuleVersion reports why they can't be published.
+func selectVersionTag(tags []string, tagPrefix string) (version string) {
+	for _, tag := range tags {
+		tagVersion, found := strings.CutPrefix(tag, tagPrefix)
+		// Module versions must be canonical, for example v1.2.0 and not v1.2. Canonical versions don't include the '+incompatible' build suffix.
+		semanticVersion := strings.TrimSuffix(tagVersion, incompatibleSuffix)
+		if !found || semver.Canonical(semanticVersion) != semanticVersion {
+			continue
+		}
+		if version == "" || semver.Compare(tagVersion, version) > 0 {
+			version = tagVersion
+		}
+	}
+	return
+}
+
+// Validates that the version can be used by the module, as the go command requires.
+// A version with a major version of 2 or above requires a major-version suffix in the module path, for example 'example.com/mod/v2'.
+// The '+incompatible' suffix allows skipping it only for modules without a go.mod file, and therefore can't be published here.
+func validateModuleVersion(modulePath, version string) error {
+	if strings.HasSuffix(version, incompatibleSuffix) {
+		return errorutils.CheckErrorf("the version %s can't be published, since the %s suffix is valid only for modules without a go.mod file. "+
+			"Add the major-version suffix '/%s' to the module path in go.mod instead", version, incompatibleSuffix, semver.Major(version))
+	}
+	if err := module.Check(modulePath, version); err != nil {
+		if _, pathMajor, ok := module.SplitPathVersion(modulePath); ok && pathMajor == "" {
+			return errorutils.CheckErrorf("the version %s of %s requires the major-version suffix '/%s' in the module path of go.mod", version, modulePath, semver.Major(version))
+		}
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
+
+// Returns an error if the version of the module was already published to the repository.
+// Published versions must never change, since their checksums are recorded in go.sum files and in the checksum database.
+func validateVersionNotPublished(modulePath, version, repo string, servicesManager artifactory.ArtifactoryServicesManager) (err error) {
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = path.Join(repo, modulePath, "@v", version+".*")
+	searchParams.Recursive = false
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+		return errorutils.CheckErrorf("%s@%s was already published to %s. Published versions can't be overwritten, tag a new version instead", modulePath, version, item.GetItemRelativePath())
+	}
+	return reader.GetError()
+}
+
+func runGit(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	log.Debug("Running: git", strings.Join(args, " "))
+	if err := cmd.Run(); err != nil {
+		return "", errorutils.CheckErrorf("'git %s' failed: %s %s", strings.Join(args, " "), err.Error(), strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
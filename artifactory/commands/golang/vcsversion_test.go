@@ -0,0 +1,85 @@
+package golang
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectVersionTag(t *testing.T) {
+	tags := []string{"v1.2.0", "v1.10.0", "v1.3", "sub/v2.0.0", "sub/v2.1.0-rc.1", "v1.11.0+build", "release"}
+	assert.Equal(t, "v1.10.0", selectVersionTag(tags, ""))
+	assert.Equal(t, "v2.1.0-rc.1", selectVersionTag(tags, "sub/"))
+	assert.Empty(t, selectVersionTag(tags, "other/"))
+	assert.Equal(t, "v2.0.0+incompatible", selectVersionTag(append(tags, "v2.0.0+incompatible"), ""))
+}
+
+func TestValidateModuleVersion(t *testing.T) {
+	testCases := []struct {
+		modulePath    string
+		version       string
+		expectedError string
+	}{
+		{"example.com/mod", "v1.2.3", ""},
+		{"example.com/mod", "v0.1.0", ""},
+		{"example.com/mod/v2", "v2.0.0", ""},
+		{"gopkg.in/yaml.v3", "v3.0.1", ""},
+		{"example.com/mod", "v2.0.0", "requires the major-version suffix '/v2'"},
+		{"example.com/mod/v2", "v3.0.0", "should be v2, not v3"},
+		{"example.com/mod/v2", "v1.0.0", "should be v2, not v1"},
+		{"example.com/mod", "v2.0.0+incompatible", "valid only for modules without a go.mod file"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.modulePath+"@"+testCase.version, func(t *testing.T) {
+			err := validateModuleVersion(testCase.modulePath, testCase.version)
+			if testCase.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, testCase.expectedError)
+			}
+		})
+	}
+}
+
+func TestGetVersionFromGitTag(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repoDir := t.TempDir()
+	moduleDir := filepath.Join(repoDir, "sub")
+	require.NoError(t, os.Mkdir(moduleDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module example.com/repo/sub\n"), 0644))
+	commitTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init", "--date", commitTime.Format(time.RFC3339)},
+		{"tag", "v9.0.0"},
+		{"tag", "sub/v1.2.3"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "GIT_COMMITTER_DATE="+commitTime.Format(time.RFC3339))
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	version, versionTime, err := getVersionFromGitTag(moduleDir)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", version)
+	assert.Equal(t, commitTime, versionTime)
+
+	version, _, err = getVersionFromGitTag(repoDir)
+	require.NoError(t, err)
+	assert.Equal(t, "v9.0.0", version)
+
+	otherDir := filepath.Join(repoDir, "other")
+	require.NoError(t, os.Mkdir(otherDir, 0755))
+	_, _, err = getVersionFromGitTag(otherDir)
+	assert.ErrorContains(t, err, "has no semantic version tag with the prefix 'other/'")
+}
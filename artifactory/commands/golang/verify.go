@@ -0,0 +1,337 @@
+package golang
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	commandutils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	goutils "github.com/jfrog/jfrog-cli-core/v2/utils/golang"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+const goSumFileName = "go.sum"
+
+type VerificationStatus string
+
+const (
+	// The hash of the module zip matches the expected hash.
+	Verified VerificationStatus = "verified"
+	// The hash of the module zip doesn't match the expected hash. Consumers of the module will fail to download it.
+	HashMismatch VerificationStatus = "mismatch"
+	// The module zip was not found in the repository.
+	ModuleNotFound VerificationStatus = "not found"
+	// No hash is expected for the module, so its hash was computed but not verified.
+	NoExpectedHash VerificationStatus = "unverified"
+)
+
+// The verification result of a module zip.
+type ModuleVerification struct {
+	Module       string             `json:"module"`
+	Version      string             `json:"version"`
+	ExpectedHash string             `json:"expectedHash,omitempty"`
+	ActualHash   string             `json:"actualHash,omitempty"`
+	Status       VerificationStatus `json:"status"`
+}
+
+// Verifies that module zips have the h1 hashes which the go command expects, as listed in go.sum files and in the checksum database.
+// The zip of the current project is created as it is for publishing, and the zips of modules in an Artifactory repository are downloaded.
+type GoVerifyCommand struct {
+	serverDetails    *config.ServerDetails
+	repo             string
+	version          string
+	hashesFilePath   string
+	excludedPatterns []string
+	verifications    []*ModuleVerification
+	result           *commandutils.Result
+}
+
+func NewGoVerifyCommand() *GoVerifyCommand {
+	return &GoVerifyCommand{result: new(commandutils.Result)}
+}
+
+func (gvc *GoVerifyCommand) CommandName() string {
+	return "rt_go_verify"
+}
+
+func (gvc *GoVerifyCommand) ServerDetails() (*config.ServerDetails, error) {
+	return gvc.serverDetails, nil
+}
+
+func (gvc *GoVerifyCommand) SetServerDetails(serverDetails *config.ServerDetails) *GoVerifyCommand {
+	gvc.serverDetails = serverDetails
+	return gvc
+}
+
+// Verifies the zips of the project modules, as stored in the Go repository. If a hashes file is provided, all the modules listed in it are verified.
+func (gvc *GoVerifyCommand) SetRepo(repo string) *GoVerifyCommand {
+	gvc.repo = repo
+	return gvc
+}
+
+// Verifies the zip of the current project, as it would be published with this version.
+func (gvc *GoVerifyCommand) SetVersion(version string) *GoVerifyCommand {
+	gvc.version = version
+	return gvc
+}
+
+// A go.sum file, or a file with the same format, which lists the expected hashes. Defaults to the go.sum file of the project.
+func (gvc *GoVerifyCommand) SetHashesFilePath(hashesFilePath string) *GoVerifyCommand {
+	gvc.hashesFilePath = hashesFilePath
+	return gvc
+}
+
+func (gvc *GoVerifyCommand) SetExcludedPatterns(excludedPatterns []string) *GoVerifyCommand {
+	gvc.excludedPatterns = excludedPatterns
+	return gvc
+}
+
+func (gvc *GoVerifyCommand) Result() *commandutils.Result {
+	return gvc.result
+}
+
+func (gvc *GoVerifyCommand) Verifications() []*ModuleVerification {
+	return gvc.verifications
+}
+
+func (gvc *GoVerifyCommand) Run() error {
+	if gvc.version == "" && gvc.repo == "" {
+		return errorutils.CheckErrorf("either a version of the current project or a repository must be provided for verification")
+	}
+	projectPath, err := goutils.GetProjectRoot()
+	if err != nil {
+		return err
+	}
+	expectedHashes := map[string]string{}
+	hashesFilePath := gvc.hashesFilePath
+	if hashesFilePath == "" {
+		hashesFilePath = filepath.Join(projectPath, goSumFileName)
+	}
+	// The go.sum file of the project is optional, since a module without dependencies doesn't have one.
+	exists, err := fileutils.IsFileExists(hashesFilePath, false)
+	if err != nil {
+		return err
+	}
+	if exists || gvc.hashesFilePath != "" {
+		if expectedHashes, err = ReadModuleHashes(hashesFilePath); err != nil {
+			return err
+		}
+	}
+	if gvc.version != "" {
+		if err = gvc.verifyProject(projectPath, expectedHashes); err != nil {
+			return err
+		}
+	}
+	if gvc.repo != "" {
+		// The dependencies listed in the go.sum file of the project are usually not deployed to the repository, so only the project modules are verified.
+		if gvc.hashesFilePath == "" {
+			if expectedHashes, err = gvc.getProjectModulesHashes(projectPath, expectedHashes); err != nil {
+				return err
+			}
+		}
+		if err = gvc.verifyRepoModules(expectedHashes); err != nil {
+			return err
+		}
+	}
+	return gvc.summarize()
+}
+
+// Returns the hashes of the project module and its nested modules. The zip of the verified version of the project is expected to have the hash computed locally.
+func (gvc *GoVerifyCommand) getProjectModulesHashes(projectPath string, expectedHashes map[string]string) (map[string]string, error) {
+	moduleName, err := goutils.GetModuleName(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	projectHashes := filterModuleHashes(expectedHashes, moduleName)
+	for _, verification := range gvc.verifications {
+		moduleVersion := verification.Module + "@" + verification.Version
+		if _, exists := projectHashes[moduleVersion]; !exists && verification.Module == moduleName {
+			projectHashes[moduleVersion] = verification.ActualHash
+		}
+	}
+	return projectHashes, nil
+}
+
+// Returns the hashes of the module and of the modules nested in it.
+func filterModuleHashes(hashes map[string]string, moduleName string) map[string]string {
+	filtered := make(map[string]string)
+	for moduleVersion, hash := range hashes {
+		name, _, _ := strings.Cut(moduleVersion, "@")
+		if name == moduleName || strings.HasPrefix(name, moduleName+"/") {
+			filtered[moduleVersion] = hash
+		}
+	}
+	return filtered
+}
+
+func (gvc *GoVerifyCommand) verifyProject(projectPath string, expectedHashes map[string]string) (err error) {
+	moduleName, err := goutils.GetModuleName(projectPath)
+	if err != nil {
+		return err
+	}
+	if err = module.Check(moduleName, gvc.version); err != nil {
+		return errorutils.CheckError(err)
+	}
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+	}()
+	zipPath, _, err := archive(moduleName, gvc.version, projectPath, "", tempDirPath, gvc.excludedPatterns)
+	if err != nil {
+		return err
+	}
+	verification, err := VerifyModuleZip(zipPath, moduleName, gvc.version, expectedHashes[moduleName+"@"+gvc.version])
+	if err != nil {
+		return err
+	}
+	gvc.verifications = append(gvc.verifications, verification)
+	return nil
+}
+
+func (gvc *GoVerifyCommand) verifyRepoModules(expectedHashes map[string]string) error {
+	if len(expectedHashes) == 0 {
+		return errorutils.CheckErrorf("no module hashes were found for verifying the modules in the repository. Provide a version of the project or a hashes file")
+	}
+	servicesManager, err := utils.CreateServiceManager(gvc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	moduleVersions := make([]string, 0, len(expectedHashes))
+	for moduleVersion := range expectedHashes {
+		moduleVersions = append(moduleVersions, moduleVersion)
+	}
+	sort.Strings(moduleVersions)
+	for _, moduleVersion := range moduleVersions {
+		moduleName, version, _ := strings.Cut(moduleVersion, "@")
+		verification, err := gvc.verifyRepoModule(servicesManager, moduleName, version, expectedHashes[moduleVersion])
+		if err != nil {
+			return err
+		}
+		gvc.verifications = append(gvc.verifications, verification)
+	}
+	return nil
+}
+
+// Downloads the module zip through the GOPROXY API of the repository, as the go command does, and verifies its hash.
+func (gvc *GoVerifyCommand) verifyRepoModule(servicesManager artifactory.ArtifactoryServicesManager, moduleName, version, expectedHash string) (verification *ModuleVerification, err error) {
+	escapedPath, err := module.EscapePath(moduleName)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	zipUrl := fmt.Sprintf("%sapi/go/%s/%s/@v/%s.zip", serviceDetails.GetUrl(), gvc.repo, escapedPath, escapedVersion)
+	log.Debug("Downloading", zipUrl)
+	tempDirPath, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tempDirPath))
+	}()
+	// The zip is written directly to the file, since modules may be large.
+	downloadFileDetails := &httpclient.DownloadFileDetails{FileName: "module.zip", DownloadPath: zipUrl, LocalPath: tempDirPath, LocalFileName: "module.zip"}
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, err := servicesManager.Client().DownloadFile(downloadFileDetails, "", &httpClientDetails, false, false)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return &ModuleVerification{Module: moduleName, Version: version, ExpectedHash: expectedHash, Status: ModuleNotFound}, nil
+	}
+	if err = errorutils.CheckResponseStatus(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return VerifyModuleZip(filepath.Join(tempDirPath, downloadFileDetails.LocalFileName), moduleName, version, expectedHash)
+}
+
+// Logs the verifications, and returns an error if any of them failed.
+func (gvc *GoVerifyCommand) summarize() error {
+	var failed []string
+	for _, verification := range gvc.verifications {
+		moduleVersion := verification.Module + "@" + verification.Version
+		switch verification.Status {
+		case Verified:
+			log.Info(moduleVersion, "is verified:", verification.ActualHash)
+		case NoExpectedHash:
+			log.Warn(moduleVersion, "has no expected hash. Its hash is", verification.ActualHash)
+		case ModuleNotFound:
+			log.Error(moduleVersion, "was not found in", gvc.repo)
+			failed = append(failed, moduleVersion)
+		case HashMismatch:
+			log.Error(fmt.Sprintf("%s has the hash %s, but %s is expected", moduleVersion, verification.ActualHash, verification.ExpectedHash))
+			failed = append(failed, moduleVersion)
+		}
+	}
+	gvc.result.SetSuccessCount(len(gvc.verifications) - len(failed))
+	gvc.result.SetFailCount(len(failed))
+	if len(failed) > 0 {
+		return errorutils.CheckErrorf("the verification of the following modules failed:\n%s", strings.Join(failed, "\n"))
+	}
+	return nil
+}
+
+// Computes the h1 hash of a module zip, as the go command does, and compares it with the expected hash, if provided.
+func VerifyModuleZip(zipPath, moduleName, version, expectedHash string) (*ModuleVerification, error) {
+	actualHash, err := dirhash.HashZip(zipPath, dirhash.DefaultHash)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	verification := &ModuleVerification{Module: moduleName, Version: version, ExpectedHash: expectedHash, ActualHash: actualHash}
+	switch {
+	case expectedHash == "":
+		verification.Status = NoExpectedHash
+	case expectedHash == actualHash:
+		verification.Status = Verified
+	default:
+		verification.Status = HashMismatch
+	}
+	return verification, nil
+}
+
+// Reads the hashes of module zips from a go.sum file, or from a file with the same format.
+// Returns the hashes mapped by "module@version". The hashes of go.mod files are skipped.
+func ReadModuleHashes(hashesFilePath string) (hashes map[string]string, err error) {
+	file, err := os.Open(hashesFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hashes = make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, errorutils.CheckErrorf("unexpected format in line %d of %s. Expected: <module> <version> <hash>", lineNumber, hashesFilePath)
+		}
+		if strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		hashes[fields[0]+"@"+fields[1]] = fields[2]
+	}
+	return hashes, errorutils.CheckError(scanner.Err())
+}
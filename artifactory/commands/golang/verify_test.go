@@ -0,0 +1,103 @@
+package golang
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The h1 hash of the test zip of rsc.io/quote@v1.5.2, which has only some of the module files.
+const quoteHash = "h1:jlRN4NYUBK2F392nzA22zZM68qyYvYiYwKVp0Gp7Uy4="
+
+func TestVerifyModuleZip(t *testing.T) {
+	zipPath := filepath.Join("testdata", "zip", "rsc.io", "quote", "@v", "v1.5.2.zip")
+	testCases := []struct {
+		name           string
+		expectedHash   string
+		expectedStatus VerificationStatus
+	}{
+		{"verified", quoteHash, Verified},
+		{"mismatch", "h1:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", HashMismatch},
+		{"noExpectedHash", "", NoExpectedHash},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			verification, err := VerifyModuleZip(zipPath, "rsc.io/quote", "v1.5.2", testCase.expectedHash)
+			require.NoError(t, err)
+			assert.Equal(t, &ModuleVerification{Module: "rsc.io/quote", Version: "v1.5.2", ExpectedHash: testCase.expectedHash, ActualHash: quoteHash, Status: testCase.expectedStatus}, verification)
+		})
+	}
+}
+
+func TestReadModuleHashes(t *testing.T) {
+	hashesFilePath := filepath.Join(t.TempDir(), "go.sum")
+	require.NoError(t, os.WriteFile(hashesFilePath, []byte(`rsc.io/quote v1.5.2 `+quoteHash+`
+rsc.io/quote v1.5.2/go.mod h1:LzX7hefJvL54yjefDEDHNONDjII0t9xZLPXsUe+TKr0=
+
+# Hashes of modules published by the team.
+example.com/mod/v2 v2.0.0 h1:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=
+`), 0600))
+	hashes, err := ReadModuleHashes(hashesFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"rsc.io/quote@v1.5.2":       quoteHash,
+		"example.com/mod/v2@v2.0.0": "h1:47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+	}, hashes)
+
+	require.NoError(t, os.WriteFile(hashesFilePath, []byte("rsc.io/quote v1.5.2\n"), 0600))
+	_, err = ReadModuleHashes(hashesFilePath)
+	assert.ErrorContains(t, err, "unexpected format in line 1")
+}
+
+func TestSummarize(t *testing.T) {
+	verifyCommand := NewGoVerifyCommand().SetRepo("go-local")
+	verifyCommand.verifications = []*ModuleVerification{
+		{Module: "rsc.io/quote", Version: "v1.5.2", ActualHash: quoteHash, ExpectedHash: quoteHash, Status: Verified},
+		{Module: "example.com/mod", Version: "v1.0.0", Status: ModuleNotFound},
+	}
+	assert.ErrorContains(t, verifyCommand.summarize(), "example.com/mod@v1.0.0")
+	assert.Equal(t, 1, verifyCommand.Result().SuccessCount())
+	assert.Equal(t, 1, verifyCommand.Result().FailCount())
+}
+
+func TestVerifyRepoModule(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/go/go-local/rsc.io/quote/@v/v1.5.2.zip" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", "zip", "rsc.io", "quote", "@v", "v1.5.2.zip"))
+	}))
+	defer testServer.Close()
+	servicesManager, err := utils.CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: testServer.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+	verifyCommand := NewGoVerifyCommand().SetRepo("go-local")
+
+	verification, err := verifyCommand.verifyRepoModule(servicesManager, "rsc.io/quote", "v1.5.2", quoteHash)
+	require.NoError(t, err)
+	assert.Equal(t, Verified, verification.Status)
+
+	verification, err = verifyCommand.verifyRepoModule(servicesManager, "rsc.io/quote", "v1.5.3", quoteHash)
+	require.NoError(t, err)
+	assert.Equal(t, ModuleNotFound, verification.Status)
+}
+
+func TestFilterModuleHashes(t *testing.T) {
+	hashes := map[string]string{
+		"example.com/mod@v1.0.0":     "h1:mod",
+		"example.com/mod/sub@v1.0.0": "h1:sub",
+		"example.com/module@v1.0.0":  "h1:other",
+		"rsc.io/quote@v1.5.2":        quoteHash,
+	}
+	assert.Equal(t, map[string]string{
+		"example.com/mod@v1.0.0":     "h1:mod",
+		"example.com/mod/sub@v1.0.0": "h1:sub",
+	}, filterModuleHashes(hashes, "example.com/mod"))
+}
@@ -0,0 +1,275 @@
+package helm
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/helm"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Artifactory package types of repositories, to which charts are pushed as OCI artifacts.
+var ociPackageTypes = []string{"helmoci", "oci", "docker"}
+
+// Packages a chart directory with helm, and deploys the chart package to a Helm or an OCI repository in Artifactory.
+type HelmPushCommand struct {
+	chartPath          string
+	repo               string
+	properties         string
+	helmArgs           []string
+	serverDetails      *config.ServerDetails
+	buildConfiguration *buildUtils.BuildConfiguration
+	executablePath     string
+	result             *commandUtils.Result
+}
+
+func NewHelmPushCommand() *HelmPushCommand {
+	return &HelmPushCommand{chartPath: ".", result: new(commandUtils.Result)}
+}
+
+func (hpc *HelmPushCommand) SetChartPath(chartPath string) *HelmPushCommand {
+	hpc.chartPath = chartPath
+	return hpc
+}
+
+func (hpc *HelmPushCommand) SetRepo(repo string) *HelmPushCommand {
+	hpc.repo = repo
+	return hpc
+}
+
+// Properties to set on the deployed chart, in addition to the build properties, formatted as "key1=value1;key2=value2".
+func (hpc *HelmPushCommand) SetProperties(properties string) *HelmPushCommand {
+	hpc.properties = properties
+	return hpc
+}
+
+// Args to pass to 'helm package'.
+func (hpc *HelmPushCommand) SetHelmArgs(helmArgs []string) *HelmPushCommand {
+	hpc.helmArgs = helmArgs
+	return hpc
+}
+
+func (hpc *HelmPushCommand) SetServerDetails(serverDetails *config.ServerDetails) *HelmPushCommand {
+	hpc.serverDetails = serverDetails
+	return hpc
+}
+
+func (hpc *HelmPushCommand) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *HelmPushCommand {
+	hpc.buildConfiguration = buildConfiguration
+	return hpc
+}
+
+func (hpc *HelmPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return hpc.serverDetails, nil
+}
+
+func (hpc *HelmPushCommand) Result() *commandUtils.Result {
+	return hpc.result
+}
+
+func (hpc *HelmPushCommand) CommandName() string {
+	return "rt_helm_push"
+}
+
+func (hpc *HelmPushCommand) Run() (err error) {
+	log.Info("Running helm push...")
+	if hpc.executablePath, err = exec.LookPath("helm"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	chart, err := helm.ReadChart(hpc.chartPath)
+	if err != nil {
+		return
+	}
+	servicesManager, err := utils.CreateServiceManager(hpc.serverDetails, -1, 0, false)
+	if err != nil {
+		return
+	}
+	repoDetails := &services.RepositoryDetails{}
+	if err = servicesManager.GetRepository(hpc.repo, &repoDetails); err != nil {
+		return errorutils.CheckErrorf("failed to get details for repository '%s'. Error:\n%s", hpc.repo, err.Error())
+	}
+	collectBuildInfo, err := hpc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return
+	}
+	props, err := hpc.getProps(collectBuildInfo)
+	if err != nil {
+		return
+	}
+	packageDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(packageDir))
+	}()
+	log.Debug("Packaging the chart", chart.Name)
+	if err = hpc.runHelm(nil, append([]string{"package", hpc.chartPath, "--destination", packageDir}, hpc.helmArgs...)...); err != nil {
+		return
+	}
+	packagePath := filepath.Join(packageDir, chart.PackageFileName())
+	var artifacts []entities.Artifact
+	if slices.Contains(ociPackageTypes, strings.ToLower(repoDetails.PackageType)) {
+		artifacts, err = hpc.pushToOciRepo(servicesManager, chart, packagePath, props)
+	} else {
+		artifacts, err = hpc.deployToHelmRepo(servicesManager, packagePath, props)
+	}
+	if err != nil {
+		return
+	}
+	log.Info("helm push finished successfully.")
+	if !collectBuildInfo {
+		return
+	}
+	return hpc.saveBuildInfo(chart, artifacts)
+}
+
+// Returns the properties to set on the deployed chart.
+func (hpc *HelmPushCommand) getProps(collectBuildInfo bool) (string, error) {
+	props := hpc.properties
+	if !collectBuildInfo {
+		return props, nil
+	}
+	buildProps, err := buildUtils.CreateBuildPropsFromConfiguration(hpc.buildConfiguration)
+	if err != nil {
+		return "", err
+	}
+	if props == "" {
+		return buildProps, nil
+	}
+	return props + ";" + buildProps, nil
+}
+
+func (hpc *HelmPushCommand) deployToHelmRepo(servicesManager artifactory.ArtifactoryServicesManager, packagePath, props string) (artifacts []entities.Artifact, err error) {
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &specutils.CommonParams{Pattern: packagePath, Target: hpc.repo + "/"}
+	if props != "" {
+		if uploadParams.TargetProps, err = specutils.ParseProperties(props); err != nil {
+			return nil, err
+		}
+	}
+	log.Debug("Deploying the chart package to", hpc.repo)
+	summary, err := servicesManager.UploadFilesWithSummary(uploadParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, summary.Close())
+	}()
+	hpc.result.SetSuccessCount(summary.TotalSucceeded)
+	hpc.result.SetFailCount(summary.TotalFailed)
+	if summary.TotalFailed > 0 {
+		return nil, errorutils.CheckErrorf("failed to upload the chart package to Artifactory. See Artifactory logs for more details")
+	}
+	return specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+}
+
+// Pushes the chart package to an OCI repository with 'helm push', after logging in to the registry with the credentials used by the container commands.
+// Artifactory stores the chart as an OCI manifest and layers, which are then set with the properties and added as the build artifacts.
+func (hpc *HelmPushCommand) pushToOciRepo(servicesManager artifactory.ArtifactoryServicesManager, chart *helm.Chart, packagePath, props string) (artifacts []entities.Artifact, err error) {
+	artifactoryUrl, err := url.Parse(hpc.serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	registry := artifactoryUrl.Host
+	username, password := container.GetLoginCredentials(hpc.serverDetails)
+	log.Debug("Logging in to the OCI registry", registry)
+	if err = hpc.runHelm(strings.NewReader(password), "registry", "login", registry, "--username", username, "--password-stdin"); err != nil {
+		return nil, errorutils.CheckErrorf("helm registry login failed for %s: %s", registry, err.Error())
+	}
+	if err = hpc.runHelm(nil, "push", packagePath, "oci://"+registry+"/"+hpc.repo); err != nil {
+		return nil, err
+	}
+	searchParams := services.NewSearchParams()
+	searchParams.Pattern = hpc.repo + "/" + chart.Name + "/" + chart.Version + "/*"
+	searchParams.Recursive = true
+	reader, err := servicesManager.SearchFiles(searchParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, reader.Close())
+	}()
+	for item := new(specutils.ResultItem); reader.NextRecord(item) == nil; item = new(specutils.ResultItem) {
+		artifacts = append(artifacts, item.ToArtifact())
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	if len(artifacts) == 0 {
+		return nil, errorutils.CheckErrorf("the chart %s was not found in %s after it was pushed", chart.BuildInfoModuleId(), hpc.repo)
+	}
+	hpc.result.SetSuccessCount(len(artifacts))
+	if props == "" {
+		return artifacts, nil
+	}
+	if _, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props}); err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+func (hpc *HelmPushCommand) saveBuildInfo(chart *helm.Chart, artifacts []entities.Artifact) error {
+	if _, err := buildUtils.PrepareBuildPrerequisites(hpc.buildConfiguration); err != nil {
+		return err
+	}
+	chartLock, err := helm.ReadChartLock(hpc.chartPath)
+	if err != nil {
+		return err
+	}
+	dependencies, err := helm.GetDependencies(hpc.chartPath, chartLock)
+	if err != nil {
+		return err
+	}
+	moduleId := hpc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = chart.BuildInfoModuleId()
+	}
+	buildName, err := hpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := hpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return buildUtils.SavePartialBuildInfo(buildName, buildNumber, hpc.buildConfiguration.GetProject(), func(partial *entities.Partial) {
+		partial.ModuleId = moduleId
+		partial.ModuleType = helm.ModuleType
+		partial.Dependencies = dependencies
+		partial.Artifacts = artifacts
+	})
+}
+
+func (hpc *HelmPushCommand) runHelm(stdin *strings.Reader, args ...string) error {
+	command := exec.Command(hpc.executablePath, args...)
+	if stdin != nil {
+		command.Stdin = stdin
+	}
+	command.Stdout = os.Stderr
+	command.Stderr = os.Stderr
+	log.Debug("Running:", hpc.executablePath, strings.Join(args, " "))
+	err := command.Run()
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		err = errors.New(err.Error())
+	}
+	return errorutils.CheckError(err)
+}
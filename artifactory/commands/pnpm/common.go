@@ -0,0 +1,152 @@
+package pnpm
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/ioutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	npmrcFileName       = ".npmrc"
+	npmrcBackupFileName = "jfrog.npmrc.backup"
+)
+
+type CommonArgs struct {
+	repo               string
+	buildConfiguration *buildUtils.BuildConfiguration
+	pnpmArgs           []string
+	serverDetails      *config.ServerDetails
+	configFilePath     string
+	executablePath     string
+	workingDirectory   string
+}
+
+func (ca *CommonArgs) SetServerDetails(serverDetails *config.ServerDetails) *CommonArgs {
+	ca.serverDetails = serverDetails
+	return ca
+}
+
+func (ca *CommonArgs) SetPnpmArgs(pnpmArgs []string) *CommonArgs {
+	ca.pnpmArgs = pnpmArgs
+	return ca
+}
+
+func (ca *CommonArgs) SetBuildConfiguration(buildConfiguration *buildUtils.BuildConfiguration) *CommonArgs {
+	ca.buildConfiguration = buildConfiguration
+	return ca
+}
+
+func (ca *CommonArgs) SetRepo(repo string) *CommonArgs {
+	ca.repo = repo
+	return ca
+}
+
+func (ca *CommonArgs) SetConfigFilePath(configFilePath string) *CommonArgs {
+	ca.configFilePath = configFilePath
+	return ca
+}
+
+func (ca *CommonArgs) ServerDetails() (*config.ServerDetails, error) {
+	return ca.serverDetails, nil
+}
+
+func (ca *CommonArgs) setExecutableAndWorkingDirectory() (err error) {
+	if ca.executablePath, err = exec.LookPath("pnpm"); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Found pnpm executable at:", ca.executablePath)
+	if ca.workingDirectory, err = os.Getwd(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Debug("Working directory set to:", ca.workingDirectory)
+	return nil
+}
+
+func (ca *CommonArgs) runPnpm(args ...string) error {
+	command := exec.Command(ca.executablePath, args...)
+	command.Dir = ca.workingDirectory
+	command.Stdout = os.Stderr
+	command.Stderr = os.Stderr
+	log.Debug("Running:", ca.executablePath, strings.Join(args, " "))
+	err := command.Run()
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		err = errors.New(err.Error())
+	}
+	return errorutils.CheckError(err)
+}
+
+// Creates a temporary .npmrc file in the working directory, which configures Artifactory as the npm registry.
+// The existing .npmrc file, if any, is kept, except for its registries and auth configurations.
+// Returns a function which restores the original .npmrc file.
+func (ca *CommonArgs) createTempNpmrc() (restoreNpmrcFunc func() error, err error) {
+	authArtDetails, err := ca.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	if authArtDetails.GetSshAuthHeaders() != nil {
+		return nil, errorutils.CheckErrorf("SSH authentication is not supported in this command")
+	}
+	npmAuth, registry, err := commandUtils.GetArtifactoryNpmRepoDetails(ca.repo, authArtDetails, false)
+	if err != nil {
+		return nil, err
+	}
+	npmrcPath := filepath.Join(ca.workingDirectory, npmrcFileName)
+	existingConfig, err := os.ReadFile(npmrcPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errorutils.CheckError(err)
+	}
+	restoreNpmrcFunc, err = ioutils.BackupFile(npmrcPath, npmrcBackupFileName)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Creating temporary .npmrc file.")
+	if err = os.WriteFile(npmrcPath, []byte(createNpmrcContent(string(existingConfig), registry, npmAuth)), 0600); err != nil {
+		return nil, errors.Join(errorutils.CheckError(err), restoreNpmrcFunc())
+	}
+	return restoreNpmrcFunc, nil
+}
+
+// Returns the .npmrc content, which points the default and the scoped registries to Artifactory.
+// pnpm only sends credentials to the registry they are configured for, so the auth returned by Artifactory is scoped to the registry URL.
+func createNpmrcContent(existingConfig, registry, npmAuth string) string {
+	var npmrc strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(existingConfig))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		key, _, _ := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		switch {
+		case line == "", strings.HasPrefix(key, "//"), key == "registry", key == commandUtils.NpmConfigAuthKey, key == commandUtils.NpmConfigAuthTokenKey, key == "always-auth":
+			continue
+		case strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry"):
+			npmrc.WriteString(key + "=" + registry + "\n")
+		default:
+			npmrc.WriteString(line + "\n")
+		}
+	}
+	npmrc.WriteString("registry=" + registry + "\n")
+	registryWithoutProtocol := registry[strings.Index(registry, "://")+1:]
+	if !strings.HasSuffix(registryWithoutProtocol, "/") {
+		registryWithoutProtocol += "/"
+	}
+	scanner = bufio.NewScanner(strings.NewReader(npmAuth))
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), "=")
+		key = strings.TrimSpace(key)
+		if found && (key == commandUtils.NpmConfigAuthKey || key == commandUtils.NpmConfigAuthTokenKey) {
+			npmrc.WriteString(registryWithoutProtocol + ":" + key + "=" + strings.TrimSpace(value) + "\n")
+		}
+	}
+	return npmrc.String()
+}
@@ -0,0 +1,22 @@
+package pnpm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateNpmrcContent(t *testing.T) {
+	existingConfig := `registry=https://registry.npmjs.org/
+@jfrog:registry=https://registry.npmjs.org/
+//registry.npmjs.org/:_authToken=public-token
+auto-install-peers=true
+`
+	registry := "https://acme.jfrog.io/artifactory/api/npm/npm-virtual"
+	npmAuth := "_authToken = my-token\nalways-auth = true"
+	assert.Equal(t, `@jfrog:registry=https://acme.jfrog.io/artifactory/api/npm/npm-virtual
+auto-install-peers=true
+registry=https://acme.jfrog.io/artifactory/api/npm/npm-virtual
+//acme.jfrog.io/artifactory/api/npm/npm-virtual/:_authToken=my-token
+`, createNpmrcContent(existingConfig, registry, npmAuth))
+}
@@ -0,0 +1,167 @@
+package pnpm
+
+import (
+	"errors"
+	"slices"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/pnpm"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// pnpm commands which resolve dependencies, and update pnpm-lock.yaml accordingly.
+var dependenciesResolutionCommands = []string{"install", "i", "add", "update", "up", "upgrade"}
+
+// Runs pnpm with Artifactory as the npm registry, and collects the build-info dependencies from pnpm-lock.yaml.
+type PnpmCommand struct {
+	CommonArgs
+	cmdName string
+	threads int
+}
+
+func NewPnpmCommand(cmdName string) *PnpmCommand {
+	return &PnpmCommand{cmdName: cmdName, threads: 3}
+}
+
+func (pc *PnpmCommand) SetArgs(args []string) *PnpmCommand {
+	pc.pnpmArgs = args
+	return pc
+}
+
+func (pc *PnpmCommand) SetThreads(threads int) *PnpmCommand {
+	pc.threads = threads
+	return pc
+}
+
+func (pc *PnpmCommand) CommandName() string {
+	return "rt_pnpm_" + pc.cmdName
+}
+
+// Reads the resolver details from the config file, and extracts the JFrog CLI options from the pnpm args.
+func (pc *PnpmCommand) Init() error {
+	log.Debug("Preparing to read the config file", pc.configFilePath)
+	vConfig, err := project.ReadConfigFile(pc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	repoConfig, err := project.GetRepoConfigByPrefix(pc.configFilePath, project.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	pc.repo = repoConfig.TargetRepo()
+	if pc.serverDetails, err = repoConfig.ServerDetails(); err != nil {
+		return err
+	}
+	pc.threads, _, _, _, pc.pnpmArgs, pc.buildConfiguration, err = commandUtils.ExtractYarnOptionsFromArgs(pc.pnpmArgs)
+	return err
+}
+
+func (pc *PnpmCommand) Run() (err error) {
+	log.Info("Running pnpm " + pc.cmdName + "...")
+	if err = pc.setExecutableAndWorkingDirectory(); err != nil {
+		return
+	}
+	restoreNpmrcFunc, err := pc.createTempNpmrc()
+	if err != nil {
+		return
+	}
+	defer func() {
+		err = errors.Join(err, restoreNpmrcFunc())
+	}()
+	if err = pc.runPnpm(append([]string{pc.cmdName}, pc.pnpmArgs...)...); err != nil {
+		return
+	}
+	log.Info("pnpm " + pc.cmdName + " finished successfully.")
+	collectBuildInfo, err := pc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo {
+		return
+	}
+	if !slices.Contains(dependenciesResolutionCommands, pc.cmdName) {
+		log.Debug("Build-info dependencies are not collected by 'pnpm " + pc.cmdName + "'.")
+		return
+	}
+	return pc.collectDependencies()
+}
+
+// Adds a build-info module for each project of the workspace, with the dependencies listed in pnpm-lock.yaml.
+func (pc *PnpmCommand) collectDependencies() error {
+	log.Info("Collecting build-info dependencies from " + pnpm.LockfileName + "...")
+	if _, err := buildUtils.PrepareBuildPrerequisites(pc.buildConfiguration); err != nil {
+		return err
+	}
+	lockfile, err := pnpm.ReadLockfile(pc.workingDirectory)
+	if err != nil {
+		return err
+	}
+	buildName, err := pc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := pc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	// Collect checksums from the last build to decrease the requests to Artifactory.
+	previousBuildDependencies, err := commandUtils.GetDependenciesFromLatestBuild(servicesManager, buildName)
+	if err != nil {
+		return err
+	}
+	var missingDependencies []string
+	missingDepsChan := make(chan string)
+	missingDepsDone := make(chan struct{})
+	go func() {
+		for depId := range missingDepsChan {
+			missingDependencies = append(missingDependencies, depId)
+		}
+		close(missingDepsDone)
+	}()
+	collectChecksumsFunc := commandUtils.CreateCollectChecksumsFunc(previousBuildDependencies, servicesManager, missingDepsChan)
+	err = pc.saveModules(lockfile, buildName, buildNumber, collectChecksumsFunc)
+	close(missingDepsChan)
+	<-missingDepsDone
+	if err != nil {
+		return err
+	}
+	commandUtils.PrintMissingDependencies(missingDependencies)
+	return nil
+}
+
+func (pc *PnpmCommand) saveModules(lockfile *pnpm.Lockfile, buildName, buildNumber string, collectChecksumsFunc func(dependency *entities.Dependency) (bool, error)) error {
+	for _, importerPath := range lockfile.ImporterPaths() {
+		moduleId, err := lockfile.ModuleId(importerPath)
+		if err != nil {
+			return err
+		}
+		if importerPath == pnpm.RootImporter && pc.buildConfiguration.GetModule() != "" {
+			moduleId = pc.buildConfiguration.GetModule()
+		}
+		dependenciesMap, err := lockfile.GetDependencies(importerPath, moduleId)
+		if err != nil {
+			return err
+		}
+		dependencies, err := biutils.TraverseDependencies(dependenciesMap, collectChecksumsFunc, pc.threads)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Debug("Collected", len(dependencies), "dependencies of module", moduleId)
+		err = buildUtils.SavePartialBuildInfo(buildName, buildNumber, pc.buildConfiguration.GetProject(), func(partial *entities.Partial) {
+			partial.ModuleId = moduleId
+			partial.ModuleType = entities.Npm
+			partial.Dependencies = dependencies
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package pnpm
+
+import (
+	"errors"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	commandUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	specutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Packs the project with pnpm, and deploys the package to Artifactory.
+type PnpmPublishCommand struct {
+	CommonArgs
+	result *commandUtils.Result
+}
+
+func NewPnpmPublishCommand() *PnpmPublishCommand {
+	return &PnpmPublishCommand{result: new(commandUtils.Result)}
+}
+
+func (ppc *PnpmPublishCommand) SetArgs(args []string) *PnpmPublishCommand {
+	ppc.pnpmArgs = args
+	return ppc
+}
+
+func (ppc *PnpmPublishCommand) Result() *commandUtils.Result {
+	return ppc.result
+}
+
+func (ppc *PnpmPublishCommand) CommandName() string {
+	return "rt_pnpm_publish"
+}
+
+// Reads the deployer details from the config file, and extracts the JFrog CLI options from the pnpm args.
+func (ppc *PnpmPublishCommand) Init() error {
+	log.Debug("Preparing to read the config file", ppc.configFilePath)
+	vConfig, err := project.ReadConfigFile(ppc.configFilePath, project.YAML)
+	if err != nil {
+		return err
+	}
+	repoConfig, err := project.GetRepoConfigByPrefix(ppc.configFilePath, project.ProjectConfigDeployerPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	ppc.repo = repoConfig.TargetRepo()
+	if ppc.serverDetails, err = repoConfig.ServerDetails(); err != nil {
+		return err
+	}
+	_, _, _, ppc.pnpmArgs, ppc.buildConfiguration, err = commandUtils.ExtractNpmOptionsFromArgs(ppc.pnpmArgs)
+	return err
+}
+
+func (ppc *PnpmPublishCommand) Run() (err error) {
+	log.Info("Running pnpm publish...")
+	for _, arg := range ppc.pnpmArgs {
+		if arg == "-r" || arg == "--recursive" {
+			return errorutils.CheckErrorf("publishing all the workspace projects is not supported. Run the command in the directory of each project instead")
+		}
+	}
+	if err = ppc.setExecutableAndWorkingDirectory(); err != nil {
+		return
+	}
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(ppc.workingDirectory, nil)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if packageInfo.Name == "" || packageInfo.Version == "" {
+		return errorutils.CheckErrorf("the package.json in %s must include the package name and version", ppc.workingDirectory)
+	}
+	tarballDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, fileutils.RemoveTempDir(tarballDir))
+	}()
+	log.Debug("Creating the pnpm package.")
+	if err = ppc.runPnpm(append([]string{"pack", "--pack-destination", tarballDir}, ppc.pnpmArgs...)...); err != nil {
+		return
+	}
+	tarballPath, err := findTarball(tarballDir)
+	if err != nil {
+		return
+	}
+	if err = ppc.deploy(tarballPath, ppc.repo+"/"+packageInfo.GetDeployPath(), packageInfo); err != nil {
+		return
+	}
+	log.Info("pnpm publish finished successfully.")
+	return
+}
+
+func findTarball(tarballDir string) (string, error) {
+	files, err := fileutils.ListFiles(tarballDir, false)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		if strings.HasSuffix(file, ".tgz") {
+			return file, nil
+		}
+	}
+	return "", errorutils.CheckErrorf("the package created by 'pnpm pack' was not found")
+}
+
+func (ppc *PnpmPublishCommand) deploy(tarballPath, target string, packageInfo *biutils.PackageInfo) (err error) {
+	servicesManager, err := utils.CreateServiceManager(ppc.serverDetails, -1, 0, false)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo, err := ppc.buildConfiguration.IsCollectBuildInfo()
+	if err != nil {
+		return err
+	}
+	uploadParams := services.NewUploadParams()
+	uploadParams.CommonParams = &specutils.CommonParams{Pattern: tarballPath, Target: target}
+	if collectBuildInfo {
+		if uploadParams.BuildProps, err = buildUtils.CreateBuildPropsFromConfiguration(ppc.buildConfiguration); err != nil {
+			return err
+		}
+	}
+	log.Debug("Deploying the pnpm package to", target)
+	summary, err := servicesManager.UploadFilesWithSummary(uploadParams)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, summary.Close())
+	}()
+	ppc.result.SetSuccessCount(summary.TotalSucceeded)
+	ppc.result.SetFailCount(summary.TotalFailed)
+	// Only one package is deployed, so any failure fails the command.
+	if summary.TotalFailed > 0 {
+		return errorutils.CheckErrorf("failed to upload the pnpm package to Artifactory. See Artifactory logs for more details")
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	artifacts, err := specutils.ConvertArtifactsDetailsToBuildInfoArtifacts(summary.ArtifactsDetailsReader)
+	if err != nil {
+		return err
+	}
+	moduleId := ppc.buildConfiguration.GetModule()
+	if moduleId == "" {
+		moduleId = packageInfo.BuildInfoModuleId()
+	}
+	buildName, err := ppc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := ppc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return buildUtils.SavePartialBuildInfo(buildName, buildNumber, ppc.buildConfiguration.GetProject(), func(partial *entities.Partial) {
+		partial.ModuleId = moduleId
+		partial.ModuleType = entities.Npm
+		partial.Artifacts = artifacts
+	})
+}
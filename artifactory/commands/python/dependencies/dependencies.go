@@ -21,6 +21,15 @@ import (
 // Otherwise, check if exists in cache.
 // Return dependency-names of all dependencies which its information could not be obtained.
 func UpdateDepsChecksumInfo(dependenciesMap map[string]buildinfo.Dependency, srcPath string, servicesManager artifactory.ArtifactoryServicesManager, repository string) error {
+	if err := ResolveDepsChecksumInfo(dependenciesMap, srcPath, servicesManager, repository); err != nil {
+		return err
+	}
+	return UpdateDependenciesCache(dependenciesMap, srcPath)
+}
+
+// Sets the checksums of the dependencies from the dependencies cache of the project or from Artifactory, without updating the cache.
+// Dependencies which their checksums weren't found are removed from the map.
+func ResolveDepsChecksumInfo(dependenciesMap map[string]buildinfo.Dependency, srcPath string, servicesManager artifactory.ArtifactoryServicesManager, repository string) error {
 	dependenciesCache, err := GetProjectDependenciesCache(srcPath)
 	if err != nil {
 		return err
@@ -49,11 +58,6 @@ func UpdateDepsChecksumInfo(dependenciesMap map[string]buildinfo.Dependency, src
 	}
 
 	promptMissingDependencies(missingDeps)
-
-	err = UpdateDependenciesCache(dependenciesMap, srcPath)
-	if err != nil {
-		return err
-	}
 	return nil
 }
 
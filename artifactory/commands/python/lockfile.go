@@ -0,0 +1,138 @@
+package python
+
+import (
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/pythonutils"
+	gofrogcmd "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/commands/python/dependencies"
+	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
+	python "github.com/jfrog/jfrog-cli-core/v2/utils/python"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const publishCmdName = "publish"
+
+// The base of the commands of Python tools which pin the project's packages with their hashes in a lock file.
+// The build-info dependencies are collected from the lock file, instead of from the installation logs and Artifactory.
+type lockfileToolCommand struct {
+	PythonCommand
+	// The tool's commands after which the build-info dependencies are collected.
+	collectCommands []string
+	// Returns the environment variables which configure the tool to resolve from Artifactory, and to publish to it.
+	createEnvFunc func(indexUrl, publishUrl, username, password string) map[string]string
+	env           map[string]string
+}
+
+func (ltc *lockfileToolCommand) Run() (err error) {
+	log.Info("Running", string(ltc.pythonTool), ltc.commandName)
+	var buildConfiguration *buildUtils.BuildConfiguration
+	ltc.args, buildConfiguration, err = buildUtils.ExtractBuildDetailsFromArgs(ltc.args)
+	if err != nil {
+		return
+	}
+	if err = ltc.SetPypiRepoUrlWithCredentials(); err != nil {
+		return
+	}
+	if err = gofrogcmd.RunCmd(ltc); err != nil {
+		return
+	}
+	collectBuildInfo, err := buildConfiguration.IsCollectBuildInfo()
+	if err != nil || !collectBuildInfo || !slices.Contains(ltc.collectCommands, ltc.commandName) {
+		return
+	}
+	return ltc.collectLockfileDependencies(buildConfiguration)
+}
+
+// Passes the Artifactory repository URL and credentials to the tool through its environment, so that they are not written to the project files.
+func (ltc *lockfileToolCommand) SetPypiRepoUrlWithCredentials() error {
+	rtUrl, username, password, err := python.GetPypiRepoUrlWithCredentials(ltc.serverDetails, ltc.repository, false)
+	if err != nil {
+		return err
+	}
+	publishUrl := strings.TrimSuffix(rtUrl.String(), "/simple")
+	indexUrl, err := python.GetPypiRepoUrl(ltc.serverDetails, ltc.repository, false)
+	if err != nil {
+		return err
+	}
+	ltc.env = ltc.createEnvFunc(indexUrl, publishUrl, username, password)
+	return nil
+}
+
+// Saves a build-info module with the packages pinned in the lock file, including their sha256 hashes.
+// The packages are also stored in the project's dependencies cache, so that later pip installations don't look them up in Artifactory.
+func (ltc *lockfileToolCommand) collectLockfileDependencies(buildConfiguration *buildUtils.BuildConfiguration) error {
+	if _, err := buildUtils.PrepareBuildPrerequisites(buildConfiguration); err != nil {
+		return err
+	}
+	srcPath, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Collecting build-info dependencies from " + python.GetLockFileName(ltc.pythonTool) + "...")
+	packages, topLevelPackages, err := python.ReadLockedPackages(ltc.pythonTool, srcPath)
+	if err != nil {
+		return err
+	}
+	if err = updateDependenciesCache(packages, srcPath); err != nil {
+		return err
+	}
+	buildName, err := buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	moduleName := buildConfiguration.GetModule()
+	if moduleName == "" {
+		if moduleName, err = python.GetPyprojectPackageName(srcPath); err != nil {
+			return err
+		}
+	}
+	if moduleName == "" {
+		moduleName = buildName
+	}
+	dependenciesList := python.LockedPackagesToDependencies(packages, topLevelPackages, moduleName)
+	log.Debug("Collected", len(dependenciesList), "dependencies of module", moduleName)
+	return buildUtils.SavePartialBuildInfo(buildName, buildNumber, buildConfiguration.GetProject(), func(partial *entities.Partial) {
+		partial.ModuleId = moduleName
+		partial.ModuleType = entities.Python
+		partial.Dependencies = dependenciesList
+	})
+}
+
+func updateDependenciesCache(packages []python.LockedPackage, srcPath string) error {
+	dependenciesCache, err := dependencies.GetProjectDependenciesCache(srcPath)
+	if err != nil {
+		return err
+	}
+	dependenciesMap := python.LockedPackagesToDependenciesMap(packages)
+	if dependenciesCache != nil {
+		for name, dependency := range dependenciesCache.DepsMap {
+			if _, exists := dependenciesMap[name]; !exists {
+				dependenciesMap[name] = dependency
+			}
+		}
+	}
+	return dependencies.UpdateDependenciesCache(dependenciesMap, srcPath)
+}
+
+func (ltc *lockfileToolCommand) GetCmd() *exec.Cmd {
+	cmd := ltc.PythonCommand.GetCmd()
+	cmd.Env = os.Environ()
+	for key, value := range ltc.env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	return cmd
+}
+
+func newLockfileToolCommand(tool pythonutils.PythonTool, collectCommands []string, createEnvFunc func(indexUrl, publishUrl, username, password string) map[string]string) *lockfileToolCommand {
+	return &lockfileToolCommand{PythonCommand: *NewPythonCommand(tool), collectCommands: collectCommands, createEnvFunc: createEnvFunc}
+}
@@ -0,0 +1,47 @@
+package python
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	python "github.com/jfrog/jfrog-cli-core/v2/utils/python"
+)
+
+type PdmCommand struct {
+	lockfileToolCommand
+}
+
+func NewPdmCommand() *PdmCommand {
+	return &PdmCommand{lockfileToolCommand: *newLockfileToolCommand(python.Pdm, []string{"install", "sync", "lock", "add", "remove", "update", publishCmdName}, createPdmEnv)}
+}
+
+func createPdmEnv(indexUrl, publishUrl, username, password string) map[string]string {
+	return map[string]string{
+		"PDM_PYPI_URL":         indexUrl,
+		"PDM_PUBLISH_REPO":     publishUrl,
+		"PDM_PUBLISH_USERNAME": username,
+		"PDM_PUBLISH_PASSWORD": password,
+	}
+}
+
+func (pc *PdmCommand) SetRepo(repo string) *PdmCommand {
+	pc.PythonCommand.SetRepo(repo)
+	return pc
+}
+
+func (pc *PdmCommand) SetArgs(arguments []string) *PdmCommand {
+	pc.PythonCommand.SetArgs(arguments)
+	return pc
+}
+
+func (pc *PdmCommand) SetCommandName(commandName string) *PdmCommand {
+	pc.PythonCommand.SetCommandName(commandName)
+	return pc
+}
+
+func (pc *PdmCommand) SetServerDetails(serverDetails *config.ServerDetails) *PdmCommand {
+	pc.PythonCommand.SetServerDetails(serverDetails)
+	return pc
+}
+
+func (pc *PdmCommand) CommandName() string {
+	return "rt_python_pdm"
+}
@@ -0,0 +1,47 @@
+package python
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	python "github.com/jfrog/jfrog-cli-core/v2/utils/python"
+)
+
+type UvCommand struct {
+	lockfileToolCommand
+}
+
+func NewUvCommand() *UvCommand {
+	return &UvCommand{lockfileToolCommand: *newLockfileToolCommand(python.Uv, []string{"sync", "lock", "add", "remove", publishCmdName}, createUvEnv)}
+}
+
+func createUvEnv(indexUrl, publishUrl, username, password string) map[string]string {
+	return map[string]string{
+		"UV_INDEX_URL":        indexUrl,
+		"UV_PUBLISH_URL":      publishUrl,
+		"UV_PUBLISH_USERNAME": username,
+		"UV_PUBLISH_PASSWORD": password,
+	}
+}
+
+func (uc *UvCommand) SetRepo(repo string) *UvCommand {
+	uc.PythonCommand.SetRepo(repo)
+	return uc
+}
+
+func (uc *UvCommand) SetArgs(arguments []string) *UvCommand {
+	uc.PythonCommand.SetArgs(arguments)
+	return uc
+}
+
+func (uc *UvCommand) SetCommandName(commandName string) *UvCommand {
+	uc.PythonCommand.SetCommandName(commandName)
+	return uc
+}
+
+func (uc *UvCommand) SetServerDetails(serverDetails *config.ServerDetails) *UvCommand {
+	uc.PythonCommand.SetServerDetails(serverDetails)
+	return uc
+}
+
+func (uc *UvCommand) CommandName() string {
+	return "rt_python_uv"
+}
@@ -0,0 +1,112 @@
+package terraform
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	TerraformLockFileName = ".terraform.lock.hcl"
+	providerType          = "provider"
+	// The prefix of the hashes of the provider zip files, one for each platform.
+	zipHashPrefix = "zh:"
+)
+
+// Returns the providers locked in the .terraform.lock.hcl file of the module as build-info dependencies, or nil if the module has no lock file.
+func readLockDependencies(moduleDir string) ([]buildInfo.Dependency, error) {
+	content, err := os.ReadFile(filepath.Join(moduleDir, TerraformLockFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return parseLockFile(content)
+}
+
+// Parses the provider blocks of a .terraform.lock.hcl file. Example:
+//
+//	provider "registry.terraform.io/hashicorp/aws" {
+//	  version     = "5.31.0"
+//	  constraints = "~> 5.0"
+//	  hashes = [
+//	    "h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+//	    "zh:0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d",
+//	  ]
+//	}
+//
+// The dependency ID is the provider address and version. The zh hashes are the sha256 checksums of the provider zips of the
+// different platforms, so the sha256 checksum of the dependency is set only when the lock file was created for a single platform.
+func parseLockFile(content []byte) (dependencies []buildInfo.Dependency, err error) {
+	var provider string
+	var version string
+	var zipHashes []string
+	inHashes := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//"):
+		case strings.HasPrefix(line, "provider "):
+			if provider, err = unquote(strings.TrimSuffix(strings.TrimPrefix(line, "provider "), "{")); err != nil {
+				return nil, errorutils.CheckErrorf("failed to parse %s in line %d: %s", TerraformLockFileName, lineNumber, err.Error())
+			}
+			version, zipHashes = "", nil
+		case provider == "":
+			return nil, errorutils.CheckErrorf("failed to parse %s: unexpected content in line %d", TerraformLockFileName, lineNumber)
+		case inHashes:
+			if line == "]" {
+				inHashes = false
+				continue
+			}
+			hash, err := unquote(strings.TrimSuffix(line, ","))
+			if err != nil {
+				return nil, errorutils.CheckErrorf("failed to parse %s in line %d: %s", TerraformLockFileName, lineNumber, err.Error())
+			}
+			if zipHash, found := strings.CutPrefix(hash, zipHashPrefix); found {
+				zipHashes = append(zipHashes, zipHash)
+			}
+		case line == "}":
+			if version == "" {
+				return nil, errorutils.CheckErrorf("the version of the provider '%s' is missing in %s", provider, TerraformLockFileName)
+			}
+			dependency := buildInfo.Dependency{Id: provider + ":" + version, Type: providerType}
+			if len(zipHashes) == 1 {
+				dependency.Checksum.Sha256 = zipHashes[0]
+			}
+			dependencies = append(dependencies, dependency)
+			provider = ""
+		default:
+			key, value, found := strings.Cut(line, "=")
+			if !found {
+				return nil, errorutils.CheckErrorf("failed to parse %s: unexpected content in line %d", TerraformLockFileName, lineNumber)
+			}
+			switch strings.TrimSpace(key) {
+			case "version":
+				if version, err = unquote(value); err != nil {
+					return nil, errorutils.CheckErrorf("failed to parse %s in line %d: %s", TerraformLockFileName, lineNumber, err.Error())
+				}
+			case "hashes":
+				inHashes = strings.TrimSpace(value) == "["
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if provider != "" {
+		return nil, errorutils.CheckErrorf("the block of the provider '%s' is not closed in %s", provider, TerraformLockFileName)
+	}
+	return dependencies, nil
+}
+
+func unquote(value string) (string, error) {
+	return strconv.Unquote(strings.TrimSpace(value))
+}
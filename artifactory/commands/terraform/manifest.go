@@ -0,0 +1,223 @@
+package terraform
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+// A manifest file, which describes the Terraform modules to publish.
+// Example:
+//
+//	modules:
+//	  - path: modules/vpc
+//	    namespace: networking
+//	    provider: aws
+//	    version: 1.2.0
+//	  - path: modules/bucket
+//	    name: s3-bucket
+//	    namespace: storage
+//	    provider: aws
+//	    version: 0.3.1
+//	    exclusions: ["*examples*"]
+type Manifest struct {
+	Modules []*terraformModule `yaml:"modules"`
+}
+
+// A Terraform module to publish, to namespace/name/provider/version.zip in the Terraform repository.
+type terraformModule struct {
+	// The module directory. In a manifest, relative paths are relative to the manifest directory.
+	Path      string `yaml:"path"`
+	Namespace string `yaml:"namespace"`
+	// The module name. Defaults to the module directory name.
+	Name       string   `yaml:"name"`
+	Provider   string   `yaml:"provider"`
+	Version    string   `yaml:"version"`
+	Exclusions []string `yaml:"exclusions"`
+}
+
+// Returns the path of the module zip in the repository.
+func (tm *terraformModule) target(repo string) string {
+	return path.Join(repo, tm.Namespace, tm.Name, tm.Provider, tm.Version+".zip")
+}
+
+func ReadManifest(manifestPath string) (*Manifest, error) {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	manifest := new(Manifest)
+	if err = yaml.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the Terraform manifest %s: %s", manifestPath, err.Error())
+	}
+	if err = manifest.validate(filepath.Dir(manifestPath)); err != nil {
+		return nil, errorutils.CheckErrorf("invalid Terraform manifest %s: %s", manifestPath, err.Error())
+	}
+	return manifest, nil
+}
+
+// Validates the manifest modules, and resolves their paths and names.
+func (m *Manifest) validate(manifestDir string) error {
+	if len(m.Modules) == 0 {
+		return fmt.Errorf("no modules are listed")
+	}
+	targets := make(map[string]string, len(m.Modules))
+	for i, module := range m.Modules {
+		if module == nil || module.Path == "" {
+			return fmt.Errorf("the path of module #%d is missing", i+1)
+		}
+		if module.Namespace == "" || module.Provider == "" || module.Version == "" {
+			return fmt.Errorf("the namespace, provider and version of module '%s' are mandatory", module.Path)
+		}
+		if !filepath.IsAbs(module.Path) {
+			module.Path = filepath.Join(manifestDir, module.Path)
+		}
+		if module.Name == "" {
+			module.Name = filepath.Base(module.Path)
+		}
+		if err := validateModuleDir(module.Path); err != nil {
+			return err
+		}
+		target := module.target("")
+		if otherPath, exists := targets[target]; exists {
+			return fmt.Errorf("the modules '%s' and '%s' are both published to %s", otherPath, module.Path, target)
+		}
+		targets[target] = module.Path
+	}
+	return nil
+}
+
+// A module directory must contain at least one '.tf' file, and all its '.tf' files must be valid.
+func validateModuleDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	isModule := false
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || filepath.Ext(entry.Name()) != ".tf" {
+			continue
+		}
+		isModule = true
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err = validateTerraformFile(content); err != nil {
+			return fmt.Errorf("%s: %s", filepath.Join(dir, entry.Name()), err.Error())
+		}
+	}
+	if !isModule {
+		return fmt.Errorf("the module directory '%s' doesn't contain '.tf' files", dir)
+	}
+	return nil
+}
+
+// Checks that a '.tf' file is UTF-8 text, and that its blocks, lists, strings, comments and heredocs are closed.
+// This doesn't replace 'terraform validate', but catches truncated and malformed files before they are published.
+func validateTerraformFile(content []byte) error {
+	if !utf8.Valid(content) {
+		return fmt.Errorf("the file is not UTF-8 encoded")
+	}
+	text := string(content)
+	var brackets []byte
+	lineNumber := 1
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case c == '\n':
+			lineNumber++
+		case c == '"':
+			end := stringEnd(text, i+1)
+			if end == -1 {
+				return fmt.Errorf("unclosed string in line %d", lineNumber)
+			}
+			i = end
+		case c == '#' || strings.HasPrefix(text[i:], "//"):
+			if end := strings.IndexByte(text[i:], '\n'); end != -1 {
+				// Stop before the line break, so it's counted.
+				i += end - 1
+			} else {
+				i = len(text)
+			}
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end == -1 {
+				return fmt.Errorf("unclosed comment in line %d", lineNumber)
+			}
+			lineNumber += strings.Count(text[i:i+2+end], "\n")
+			i += 2 + end + 1
+		case strings.HasPrefix(text[i:], "<<"):
+			end, err := heredocEnd(text, i)
+			if err != nil {
+				return fmt.Errorf("%s in line %d", err.Error(), lineNumber)
+			}
+			lineNumber += strings.Count(text[i:end], "\n")
+			i = end - 1
+		case c == '{' || c == '[' || c == '(':
+			brackets = append(brackets, c)
+		case c == '}' || c == ']' || c == ')':
+			if len(brackets) == 0 || brackets[len(brackets)-1] != matchingBracket(c) {
+				return fmt.Errorf("unexpected '%c' in line %d", c, lineNumber)
+			}
+			brackets = brackets[:len(brackets)-1]
+		}
+	}
+	if len(brackets) > 0 {
+		return fmt.Errorf("'%c' is not closed", brackets[len(brackets)-1])
+	}
+	return nil
+}
+
+// Returns the index of the quote which closes the string starting at start, or -1 if the string isn't closed in the same line.
+func stringEnd(text string, start int) int {
+	for i := start; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		case '\n':
+			return -1
+		}
+	}
+	return -1
+}
+
+// Returns the index of the line break which ends the heredoc (<<EOF or <<-EOF) starting at start.
+func heredocEnd(text string, start int) (int, error) {
+	lineEnd := strings.IndexByte(text[start:], '\n')
+	if lineEnd == -1 {
+		return 0, fmt.Errorf("the heredoc has no content")
+	}
+	terminator := strings.TrimSpace(strings.TrimPrefix(text[start+2:start+lineEnd], "-"))
+	if terminator == "" {
+		return 0, fmt.Errorf("the heredoc has no terminator")
+	}
+	for i := start + lineEnd + 1; i < len(text); {
+		end := strings.IndexByte(text[i:], '\n')
+		if end == -1 {
+			end = len(text) - i
+		}
+		if strings.TrimSpace(text[i:i+end]) == terminator {
+			return i + end, nil
+		}
+		i += end + 1
+	}
+	return 0, fmt.Errorf("the heredoc '%s' is not closed", terminator)
+}
+
+func matchingBracket(closing byte) byte {
+	switch closing {
+	case '}':
+		return '{'
+	case ']':
+		return '['
+	}
+	return '('
+}
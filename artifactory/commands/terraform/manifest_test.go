@@ -0,0 +1,103 @@
+package terraform
+
+import (
+	"path/filepath"
+	"testing"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestReadManifest(t *testing.T) {
+	manifestDir := getTerraformTestDir("manifest")
+	manifest, err := ReadManifest(filepath.Join(manifestDir, "modules.yaml"))
+	require.NoError(t, err)
+	require.Len(t, manifest.Modules, 2)
+	assert.Equal(t, &terraformModule{Path: getTerraformTestDir("terraform_project"), Namespace: "acme", Name: "terraform_project", Provider: "aws", Version: "1.0.0"}, manifest.Modules[0])
+	assert.Equal(t, "terraform-local/acme/test-module/aws/0.1.0.zip", manifest.Modules[1].target("terraform-local"))
+	assert.Equal(t, []string{"*submodules*"}, manifest.Modules[1].Exclusions)
+}
+
+func TestManifestValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		manifest      string
+		expectedError string
+	}{
+		{"noModules", "modules: []", "no modules are listed"},
+		{"missingVersion", "modules:\n  - path: terraform_project\n    namespace: acme\n    provider: aws", "the namespace, provider and version of module"},
+		{"notModule", "modules:\n  - path: empty\n    namespace: acme\n    provider: aws\n    version: 1.0.0", "doesn't contain '.tf' files"},
+		{"duplicateTarget", "modules:\n  - path: terraform_project\n    namespace: acme\n    provider: aws\n    version: 1.0.0\n" +
+			"  - path: terraform_project/test_dir\n    name: terraform_project\n    namespace: acme\n    provider: aws\n    version: 1.0.0", "are both published to"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			manifest := new(Manifest)
+			require.NoError(t, yaml.Unmarshal([]byte(testCase.manifest), manifest))
+			assert.ErrorContains(t, manifest.validate(getTerraformTestDir("")), testCase.expectedError)
+		})
+	}
+}
+
+func TestValidateTerraformFile(t *testing.T) {
+	valid := `# A comment with an unbalanced {
+resource "aws_s3_bucket" "bucket" {
+  bucket = "${var.prefix}-bucket"
+  tags   = { Name = "a } b" }
+  /* a multi-line
+     comment ] */
+  policy = <<-EOT
+    {"Version": "2012-10-17"
+  EOT
+  list = [1, 2, (3)]
+}
+`
+	assert.NoError(t, validateTerraformFile([]byte(valid)))
+	assert.NoError(t, validateTerraformFile(nil))
+	assert.ErrorContains(t, validateTerraformFile([]byte("resource \"a\" \"b\" {\n  count = 1\n")), "'{' is not closed")
+	assert.ErrorContains(t, validateTerraformFile([]byte("locals {\n  a = [1, 2}\n")), "unexpected '}' in line 2")
+	assert.ErrorContains(t, validateTerraformFile([]byte("locals {\n  a = \"b\n}\n")), "unclosed string in line 2")
+	assert.ErrorContains(t, validateTerraformFile([]byte("locals {\n  a = <<EOT\n  b\n}\n")), "the heredoc 'EOT' is not closed")
+	assert.ErrorContains(t, validateTerraformFile([]byte{0xff, 0xfe}), "not UTF-8")
+}
+
+func TestParseLockFile(t *testing.T) {
+	content := `# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.31.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+    "zh:0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+  hashes = [
+    "h1:R5Ucn26riKIEijcsiOMBR3uOAjuOMfI1x7XvH4P6B1w=",
+    "zh:03360ed3ecd31e8c5dac9c95fe0858be50f3e9a0d0c654b5e504109c2159287d",
+    "zh:1c67ac51254ba2a2bb53a25e8ae7e4d076103483f55f39b426ec55e47d1fe211",
+  ]
+}
+`
+	dependencies, err := parseLockFile([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, []buildInfo.Dependency{
+		{Id: "registry.terraform.io/hashicorp/aws:5.31.0", Type: "provider", Checksum: buildInfo.Checksum{Sha256: "0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d"}},
+		{Id: "registry.terraform.io/hashicorp/random:3.6.0", Type: "provider"},
+	}, dependencies)
+
+	_, err = parseLockFile([]byte("provider \"registry.terraform.io/hashicorp/aws\" {\n  version = \"5.31.0\"\n"))
+	assert.ErrorContains(t, err, "is not closed")
+}
+
+func TestReadLockDependenciesWithoutLockFile(t *testing.T) {
+	dependencies, err := readLockDependencies(t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, dependencies)
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -32,6 +33,8 @@ type TerraformPublishCommandArgs struct {
 	provider           string
 	tag                string
 	exclusions         []string
+	manifestPath       string
+	dryRun             bool
 	buildConfiguration *build.BuildConfiguration
 	collectBuildInfo   bool
 	buildProps         string
@@ -44,10 +47,21 @@ type TerraformPublishCommand struct {
 	configFilePath string
 	serverDetails  *config.ServerDetails
 	result         *commandsUtils.Result
+	manifest       *Manifest
+	// The providers locked by the published modules, which are added to the build-info as dependencies.
+	dependencies map[string]buildInfo.Dependency
+	// The modules which would have been published, when running in dry-run mode.
+	dryRunModules []*DryRunModule
+}
+
+// A module zip which would have been published, and the files it would have included.
+type DryRunModule struct {
+	Target string
+	Files  []string
 }
 
 func NewTerraformPublishCommand() *TerraformPublishCommand {
-	return &TerraformPublishCommand{TerraformPublishCommandArgs: NewTerraformPublishCommandArgs(), result: new(commandsUtils.Result)}
+	return &TerraformPublishCommand{TerraformPublishCommandArgs: NewTerraformPublishCommandArgs(), result: new(commandsUtils.Result), dependencies: make(map[string]buildInfo.Dependency)}
 }
 
 func NewTerraformPublishCommandArgs() *TerraformPublishCommandArgs {
@@ -91,6 +105,11 @@ func (tpc *TerraformPublishCommand) Result() *commandsUtils.Result {
 	return tpc.result
 }
 
+// Returns the module zips which would have been published, when running in dry-run mode.
+func (tpc *TerraformPublishCommand) DryRunModules() []*DryRunModule {
+	return tpc.dryRunModules
+}
+
 func (tpc *TerraformPublishCommand) Run() error {
 	log.Info("Running Terraform publish")
 	err := tpc.publish()
@@ -106,12 +125,23 @@ func (tpc *TerraformPublishCommand) Init() error {
 	if err != nil {
 		return err
 	}
-	if tpc.namespace == "" || tpc.provider == "" || tpc.tag == "" {
-		return errorutils.CheckErrorf("the --namespace, --provider and --tag options are mandatory")
+	if tpc.manifestPath != "" {
+		if tpc.namespace != "" || tpc.provider != "" || tpc.tag != "" {
+			return errorutils.CheckErrorf("the --namespace, --provider and --tag options can't be used with --manifest, since the modules are described in the manifest")
+		}
+		if tpc.manifest, err = ReadManifest(tpc.manifestPath); err != nil {
+			return err
+		}
+	} else if tpc.namespace == "" || tpc.provider == "" || tpc.tag == "" {
+		return errorutils.CheckErrorf("the --namespace, --provider and --tag options are mandatory, unless the modules are described in a --manifest file")
 	}
 	if err = tpc.setRepoFromConfiguration(); err != nil {
 		return err
 	}
+	// Nothing is deployed in dry-run mode, so the repository and the build-info are not required.
+	if tpc.dryRun {
+		return nil
+	}
 	artDetails, err := tpc.serverDetails.CreateArtAuthConfig()
 	if err != nil {
 		return err
@@ -137,9 +167,21 @@ func (tpc *TerraformPublishCommand) publish() error {
 	}
 	tpc.result.SetSuccessCount(success)
 	tpc.result.SetFailCount(failed)
+	if tpc.dryRun {
+		tpc.printDryRunModules()
+	}
 	return nil
 }
 
+func (tpc *TerraformPublishCommand) printDryRunModules() {
+	for _, module := range tpc.dryRunModules {
+		log.Output(module.Target)
+		for _, file := range module.Files {
+			log.Output("  " + file)
+		}
+	}
+}
+
 func (tpa *TerraformPublishCommandArgs) extractTerraformPublishOptionsFromArgs(args []string) (err error) {
 	// Extract namespace information from the args.
 	var flagIndex, valueIndex int
@@ -167,12 +209,24 @@ func (tpa *TerraformPublishCommandArgs) extractTerraformPublishOptionsFromArgs(a
 	}
 	tpa.exclusions = append(tpa.exclusions, strings.Split(exclusionsString, ";")...)
 	coreutils.RemoveFlagFromCommand(&args, flagIndex, valueIndex)
+	// Extract manifest information from the args.
+	flagIndex, valueIndex, tpa.manifestPath, err = coreutils.FindFlag("--manifest", args)
+	if err != nil {
+		return
+	}
+	coreutils.RemoveFlagFromCommand(&args, flagIndex, valueIndex)
+	// Extract dry-run information from the args.
+	flagIndex, tpa.dryRun, err = coreutils.FindBooleanFlag("--dry-run", args)
+	if err != nil {
+		return
+	}
+	coreutils.RemoveFlagFromCommand(&args, flagIndex, flagIndex)
 	args, tpa.buildConfiguration, err = build.ExtractBuildDetailsFromArgs(args)
 	if err != nil {
 		return err
 	}
 	if len(args) != 0 {
-		err = errorutils.CheckErrorf("Unknown flag:" + strings.Split(args[0], "=")[0] + ". for a terraform publish command please provide --namespace, --provider, --tag and optionally --exclusions.")
+		err = errorutils.CheckErrorf("Unknown flag:" + strings.Split(args[0], "=")[0] + ". for a terraform publish command please provide --namespace, --provider, --tag or --manifest, and optionally --exclusions and --dry-run.")
 	}
 	return
 }
@@ -182,25 +236,36 @@ func (tpc *TerraformPublishCommand) terraformPublish() (int, int, error) {
 	producerConsumer := parallel.NewRunner(3, 20000, false)
 	errorsQueue := clientUtils.NewErrorsQueue(threads)
 
-	tpc.prepareTerraformPublishTasks(producerConsumer, errorsQueue, uploadSummary)
+	produceTaskFunc := addTaskWithError
+	if tpc.dryRun {
+		produceTaskFunc = tpc.listModuleFiles
+	}
+	tpc.prepareTerraformPublishTasks(producerConsumer, errorsQueue, uploadSummary, produceTaskFunc)
 	tpc.performTerraformPublishTasks(producerConsumer)
 	e := errorsQueue.GetError()
 	if e != nil {
 		return 0, 0, e
 	}
+	if tpc.dryRun {
+		return len(tpc.dryRunModules), 0, nil
+	}
 	return tpc.aggregateSummaryResults(uploadSummary)
 }
 
-func (tpc *TerraformPublishCommand) prepareTerraformPublishTasks(producer parallel.Runner, errorsQueue *clientUtils.ErrorsQueue, uploadSummary *[][]*servicesUtils.OperationSummary) {
+func (tpc *TerraformPublishCommand) prepareTerraformPublishTasks(producer parallel.Runner, errorsQueue *clientUtils.ErrorsQueue, uploadSummary *[][]*servicesUtils.OperationSummary, produceTaskFunc ProduceTaskFunc) {
 	go func() {
 		defer producer.Done()
+		if tpc.manifest != nil {
+			tpc.uploadManifestModules(producer, errorsQueue, uploadSummary, produceTaskFunc)
+			return
+		}
 		pwd, err := os.Getwd()
 		if err != nil {
 			log.Error(err)
 			errorsQueue.AddError(err)
 		}
 		// Walk and upload directories which contain '.tf' files.
-		err = tpc.walkDirAndUploadTerraformModules(pwd, producer, errorsQueue, uploadSummary, addTaskWithError)
+		err = tpc.walkDirAndUploadTerraformModules(pwd, producer, errorsQueue, uploadSummary, produceTaskFunc)
 		if err != nil && err != io.EOF {
 			log.Error(err)
 			errorsQueue.AddError(err)
@@ -227,6 +292,31 @@ func uploadModuleTask(serverDetails *config.ServerDetails, uploadSummary *[][]*s
 	}
 }
 
+// Lists the files which would have been included in the module zip, by running the upload in dry-run mode without archiving.
+func (tpc *TerraformPublishCommand) listModuleFiles(_ parallel.Runner, serverDetails *config.ServerDetails, _ *[][]*servicesUtils.OperationSummary, uploadParams *services.UploadParams, _ *clientUtils.ErrorsQueue) (int, error) {
+	listParams := *uploadParams
+	commonParams := *uploadParams.CommonParams
+	listParams.CommonParams = &commonParams
+	listParams.Target = path.Join(uploadParams.Target, "{1}")
+	listParams.TargetPathInArchive = ""
+	listParams.Archive = ""
+	summary, err := createServiceManagerAndUpload(serverDetails, &listParams, true)
+	if err != nil {
+		return 0, err
+	}
+	artifacts, err := readArtifactsFromSummary(summary)
+	if err != nil {
+		return 0, err
+	}
+	module := &DryRunModule{Target: uploadParams.Target}
+	for _, artifact := range artifacts {
+		module.Files = append(module.Files, strings.TrimPrefix(artifact.Path, strings.TrimPrefix(uploadParams.Target, tpc.repo+"/")+"/"))
+	}
+	slices.Sort(module.Files)
+	tpc.dryRunModules = append(tpc.dryRunModules, module)
+	return 0, nil
+}
+
 func createServiceManagerAndUpload(serverDetails *config.ServerDetails, uploadParams *services.UploadParams, dryRun bool) (operationSummary *servicesUtils.OperationSummary, err error) {
 	serviceManager, err := utils.CreateServiceManagerWithThreads(serverDetails, dryRun, 1, -1, 0)
 	if err != nil {
@@ -253,9 +343,15 @@ func (tpc *TerraformPublishCommand) walkDirAndUploadTerraformModules(pwd string,
 			return e
 		}
 		if isTerraformModule {
-			uploadParams := tpc.uploadParamsForTerraformPublish(pathInfo.Name(), strings.TrimPrefix(path, pwd+string(filepath.Separator)))
-			_, e = produceTaskFunc(producer, tpc.serverDetails, uploadSummary, uploadParams, errorsQueue)
-			if e != nil {
+			module := &terraformModule{
+				Path:       strings.TrimPrefix(path, pwd+string(filepath.Separator)),
+				Namespace:  tpc.namespace,
+				Name:       pathInfo.Name(),
+				Provider:   tpc.provider,
+				Version:    tpc.tag,
+				Exclusions: tpc.exclusions,
+			}
+			if e = tpc.uploadModule(module, producer, errorsQueue, uploadSummary, produceTaskFunc); e != nil {
 				log.Error(e)
 				errorsQueue.AddError(e)
 			}
@@ -267,6 +363,29 @@ func (tpc *TerraformPublishCommand) walkDirAndUploadTerraformModules(pwd string,
 	})
 }
 
+func (tpc *TerraformPublishCommand) uploadManifestModules(producer parallel.Runner, errorsQueue *clientUtils.ErrorsQueue, uploadSummary *[][]*servicesUtils.OperationSummary, produceTaskFunc ProduceTaskFunc) {
+	for _, module := range tpc.manifest.Modules {
+		if err := tpc.uploadModule(module, producer, errorsQueue, uploadSummary, produceTaskFunc); err != nil {
+			log.Error(err)
+			errorsQueue.AddError(err)
+		}
+	}
+}
+
+func (tpc *TerraformPublishCommand) uploadModule(module *terraformModule, producer parallel.Runner, errorsQueue *clientUtils.ErrorsQueue, uploadSummary *[][]*servicesUtils.OperationSummary, produceTaskFunc ProduceTaskFunc) error {
+	if tpc.collectBuildInfo {
+		dependencies, err := readLockDependencies(module.Path)
+		if err != nil {
+			return err
+		}
+		for _, dependency := range dependencies {
+			tpc.dependencies[dependency.Id] = dependency
+		}
+	}
+	_, err := produceTaskFunc(producer, tpc.serverDetails, uploadSummary, tpc.uploadParamsForTerraformPublish(module), errorsQueue)
+	return err
+}
+
 func (tpc *TerraformPublishCommand) performTerraformPublishTasks(consumer parallel.Runner) {
 	// Blocking until consuming is finished.
 	consumer.Run()
@@ -296,11 +415,35 @@ func (tpc *TerraformPublishCommand) aggregateSummaryResults(uploadSummary *[][]*
 		}
 	}
 	if tpc.collectBuildInfo {
-		err = build.PopulateBuildArtifactsAsPartials(artifacts, tpc.buildConfiguration, buildInfo.Terraform)
+		err = tpc.saveBuildInfo(artifacts)
 	}
 	return
 }
 
+func (tpc *TerraformPublishCommand) saveBuildInfo(artifacts []buildInfo.Artifact) error {
+	dependencies := make([]buildInfo.Dependency, 0, len(tpc.dependencies))
+	for _, dependency := range tpc.dependencies {
+		dependencies = append(dependencies, dependency)
+	}
+	sort.Slice(dependencies, func(i, j int) bool {
+		return dependencies[i].Id < dependencies[j].Id
+	})
+	buildName, err := tpc.buildConfiguration.GetBuildName()
+	if err != nil {
+		return err
+	}
+	buildNumber, err := tpc.buildConfiguration.GetBuildNumber()
+	if err != nil {
+		return err
+	}
+	return build.SavePartialBuildInfo(buildName, buildNumber, tpc.buildConfiguration.GetProject(), func(partial *buildInfo.Partial) {
+		partial.ModuleId = tpc.buildConfiguration.GetModule()
+		partial.ModuleType = buildInfo.Terraform
+		partial.Artifacts = artifacts
+		partial.Dependencies = dependencies
+	})
+}
+
 func readArtifactsFromSummary(summary *servicesUtils.OperationSummary) (artifacts []buildInfo.Artifact, err error) {
 	artifactsDetailsReader := summary.ArtifactsDetailsReader
 	if artifactsDetailsReader == nil {
@@ -310,24 +453,19 @@ func readArtifactsFromSummary(summary *servicesUtils.OperationSummary) (artifact
 	return servicesUtils.ConvertArtifactsDetailsToBuildInfoArtifacts(artifactsDetailsReader)
 }
 
-func (tpc *TerraformPublishCommand) uploadParamsForTerraformPublish(moduleName, dirPath string) *services.UploadParams {
+func (tpc *TerraformPublishCommand) uploadParamsForTerraformPublish(module *terraformModule) *services.UploadParams {
 	uploadParams := services.NewUploadParams()
-	uploadParams.Target = tpc.getPublishTarget(moduleName)
-	uploadParams.Pattern = dirPath + "/(*)"
+	uploadParams.Target = module.target(tpc.repo)
+	uploadParams.Pattern = module.Path + "/(*)"
 	uploadParams.TargetPathInArchive = "{1}"
 	uploadParams.Archive = "zip"
 	uploadParams.Recursive = true
 	uploadParams.CommonParams.TargetProps = servicesUtils.NewProperties()
-	uploadParams.CommonParams.Exclusions = append(slices.Clone(tpc.exclusions), "*.git", "*.DS_Store")
+	uploadParams.CommonParams.Exclusions = append(slices.Clone(module.Exclusions), "*.git", "*.DS_Store")
 	uploadParams.BuildProps = tpc.buildProps
 	return &uploadParams
 }
 
-// Module's path in terraform repository : namespace/moduleName/provider/tag.zip
-func (tpc *TerraformPublishCommand) getPublishTarget(moduleName string) string {
-	return path.Join(tpc.repo, tpc.namespace, moduleName, tpc.provider, tpc.tag+".zip")
-}
-
 // We identify a Terraform module by having at least one file with a ".tf" extension inside the module directory.
 func checkIfTerraformModule(path string) (isModule bool, err error) {
 	dirname := path + string(filepath.Separator)
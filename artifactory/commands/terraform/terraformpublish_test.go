@@ -20,9 +20,14 @@ func TestPreparePrerequisites(t *testing.T) {
 	assert.Equal(t, "aws", terraformPublishArgs.provider)
 	assert.Equal(t, "v0.1.2", terraformPublishArgs.tag)
 	assert.Equal(t, []string{"*test*", "*ignore*"}, terraformPublishArgs.exclusions)
+	// Publish from a manifest
+	terraformPublishArgs = NewTerraformPublishCommandArgs()
+	assert.NoError(t, terraformPublishArgs.extractTerraformPublishOptionsFromArgs([]string{"--manifest", "modules.yaml", "--dry-run"}))
+	assert.Equal(t, "modules.yaml", terraformPublishArgs.manifestPath)
+	assert.True(t, terraformPublishArgs.dryRun)
 	// Add unknown flag
 	terraformArgs = []string{"--namespace=name", "--provider=aws", "--tag=v0.1.2", "--exclusions=*test*;*ignore*", "--unknown-flag=value"}
-	assert.EqualError(t, terraformPublishArgs.extractTerraformPublishOptionsFromArgs(terraformArgs), "Unknown flag:--unknown-flag. for a terraform publish command please provide --namespace, --provider, --tag and optionally --exclusions.")
+	assert.EqualError(t, terraformPublishArgs.extractTerraformPublishOptionsFromArgs(terraformArgs), "Unknown flag:--unknown-flag. for a terraform publish command please provide --namespace, --provider, --tag or --manifest, and optionally --exclusions and --dry-run.")
 }
 
 func TestCheckIfTerraformModule(t *testing.T) {
@@ -100,6 +105,22 @@ func getTaskMock(t *testing.T, expectedPaths []string) func(parallel.Runner, *co
 	}
 }
 
+func TestDryRunModules(t *testing.T) {
+	terraformPublish := NewTerraformPublishCommand()
+	terraformPublish.setServerDetails(&config.ServerDetails{})
+	terraformPublish.repo = "terraform-local"
+	terraformPublish.namespace = "acme"
+	terraformPublish.provider = "aws"
+	terraformPublish.tag = "v1.0.0"
+	terraformPublish.exclusions = []string{"*test_sub*"}
+	uploadSummary := getNewUploadSummaryMultiArray()
+	producerConsumer := parallel.NewRunner(threads, 20000, false)
+	errorsQueue := clientUtils.NewErrorsQueue(1)
+	assert.NoError(t, terraformPublish.walkDirAndUploadTerraformModules(getTerraformTestDir("terraform_project"), producerConsumer, errorsQueue, uploadSummary, terraformPublish.listModuleFiles))
+	assert.NoError(t, errorsQueue.GetError())
+	assert.Equal(t, []*DryRunModule{{Target: "terraform-local/acme/terraform_project/aws/v1.0.0.zip", Files: []string{"a.tf", "test_dir/b.tf"}}}, terraformPublish.DryRunModules())
+}
+
 func mockEmptyModule(_ parallel.Runner, _ *config.ServerDetails, _ *[][]*clientServicesUtils.OperationSummary, _ *services.UploadParams, _ *clientUtils.ErrorsQueue) (int, error) {
 	return 0, errors.New("failed: testing empty directory. this function shouldn't be called. ")
 }
@@ -16,8 +16,6 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-const sizeUnits = "KMGTPE"
-
 func ShowStatus() error {
 	var output strings.Builder
 	stateManager, err := state.NewTransferStateManager(true)
@@ -75,7 +73,7 @@ func addOverallStatus(stateManager *state.TransferStateManager, output *strings.
 	addTitle(output, "Overall Transfer Status")
 	addString(output, coreutils.RemoveEmojisIfNonSupportedTerminal("🟢"), "Status", "Running", 3)
 	addString(output, "🏃", "Running for", runningTime, 3)
-	addString(output, "🗄 ", "Storage", sizeToString(stateManager.OverallTransfer.TransferredSizeBytes)+" / "+sizeToString(stateManager.OverallTransfer.TotalSizeBytes)+calcPercentageInt64(stateManager.OverallTransfer.TransferredSizeBytes, stateManager.OverallTransfer.TotalSizeBytes), 3)
+	addString(output, "🗄 ", "Storage", coreutils.SizeToString(stateManager.OverallTransfer.TransferredSizeBytes)+" / "+coreutils.SizeToString(stateManager.OverallTransfer.TotalSizeBytes)+calcPercentageInt64(stateManager.OverallTransfer.TransferredSizeBytes, stateManager.OverallTransfer.TotalSizeBytes), 3)
 	addString(output, "📦", "Repositories", fmt.Sprintf("%d / %d", stateManager.TotalRepositories.TransferredUnits, stateManager.TotalRepositories.TotalUnits)+calcPercentageInt64(stateManager.TotalRepositories.TransferredUnits, stateManager.TotalRepositories.TotalUnits), 2)
 	addString(output, "🧵", "Working threads", strconv.Itoa(stateManager.WorkingThreads), 2)
 	addString(output, "⚡", "Transfer speed", stateManager.GetSpeedString(), 2)
@@ -105,7 +103,7 @@ func setRepositoryStatus(stateManager *state.TransferStateManager, output *strin
 		} else {
 			addString(output, "🔢", "Phase", "Retrying transfer failures and transfer delayed files (3/3)", 3)
 		}
-		addString(output, "🗄 ", "Storage", sizeToString(currentRepo.Phase1Info.TransferredSizeBytes)+" / "+sizeToString(currentRepo.Phase1Info.TotalSizeBytes)+calcPercentageInt64(currentRepo.Phase1Info.TransferredSizeBytes, currentRepo.Phase1Info.TotalSizeBytes), 3)
+		addString(output, "🗄 ", "Storage", coreutils.SizeToString(currentRepo.Phase1Info.TransferredSizeBytes)+" / "+coreutils.SizeToString(currentRepo.Phase1Info.TotalSizeBytes)+calcPercentageInt64(currentRepo.Phase1Info.TransferredSizeBytes, currentRepo.Phase1Info.TotalSizeBytes), 3)
 		addString(output, "📄", "Files", fmt.Sprintf("%d / %d", currentRepo.Phase1Info.TransferredUnits, currentRepo.Phase1Info.TotalUnits)+calcPercentageInt64(currentRepo.Phase1Info.TransferredUnits, currentRepo.Phase1Info.TotalUnits), 3)
 	case api.Phase2:
 		addString(output, "🔢", "Phase", "Transferring newly created and modified files (2/3)", 3)
@@ -161,12 +159,3 @@ func addString(output *strings.Builder, emoji, key, value string, tabsCount int)
 	output.WriteString(coreutils.PrintBold(key))
 	output.WriteString(indentation + value + "\n")
 }
-
-func sizeToString(sizeInBytes int64) string {
-	var divider int64 = 1024
-	sizeUnitIndex := 0
-	for ; sizeUnitIndex < len(sizeUnits)-1 && (sizeInBytes >= divider<<10); sizeUnitIndex++ {
-		divider <<= 10
-	}
-	return fmt.Sprintf("%.1f %ciB", float64(sizeInBytes)/float64(divider), sizeUnits[sizeUnitIndex])
-}
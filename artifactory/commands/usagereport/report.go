@@ -0,0 +1,235 @@
+package usagereport
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+)
+
+type Dimension string
+
+const (
+	PathPrefix   Dimension = "path"
+	Extension    Dimension = "extension"
+	Creator      Dimension = "creator"
+	Age          Dimension = "age"
+	LastDownload Dimension = "last-download"
+
+	// The entries which aren't in the top entries of a dimension are merged into this entry.
+	otherEntriesKey = "(other)"
+	unknownKey      = "(unknown)"
+	noExtensionKey  = "(none)"
+	neverKey        = "Never downloaded"
+)
+
+// The dimensions of the report, by the order they are printed.
+var Dimensions = []Dimension{PathPrefix, Extension, Creator, Age, LastDownload}
+
+var dimensionTitles = map[Dimension]string{
+	PathPrefix:   "Path",
+	Extension:    "Extension",
+	Creator:      "Created By",
+	Age:          "Age",
+	LastDownload: "Last Downloaded",
+}
+
+func (d Dimension) Title() string {
+	return dimensionTitles[d]
+}
+
+// A time bucket, for files which are at most maxAge old.
+type timeBucket struct {
+	name   string
+	maxAge time.Duration
+}
+
+const day = 24 * time.Hour
+
+var timeBuckets = []timeBucket{
+	{"Up to 30 days", 30 * day},
+	{"30 to 90 days", 90 * day},
+	{"90 to 180 days", 180 * day},
+	{"180 days to 1 year", 365 * day},
+	{"1 to 2 years", 2 * 365 * day},
+	{"Over 2 years", 0},
+}
+
+// The storage used by a group of files.
+type UsageEntry struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+	Size  int64  `json:"size"`
+}
+
+type UsageGroup struct {
+	Dimension Dimension    `json:"dimension"`
+	Entries   []UsageEntry `json:"entries"`
+}
+
+// The storage summary of a repository, as calculated by Artifactory.
+type RepositoryStorage struct {
+	Key       string `json:"key"`
+	FileCount int64  `json:"fileCount"`
+	UsedSpace int64  `json:"usedSpace"`
+}
+
+type UsageReport struct {
+	Pattern      string              `json:"pattern"`
+	TotalCount   int64               `json:"totalCount"`
+	TotalSize    int64               `json:"totalSize"`
+	Repositories []RepositoryStorage `json:"repositories,omitempty"`
+	Groups       []UsageGroup        `json:"groups"`
+}
+
+// Aggregates the size and count of files by each of the report dimensions.
+type usageAggregator struct {
+	// The number of path components of the path prefixes, including the repository.
+	depth int
+	// The age of the files is calculated from their 'updated' time instead of their 'created' time.
+	byUpdated bool
+	now       time.Time
+	report    *UsageReport
+	entries   map[Dimension]map[string]*UsageEntry
+	repos     map[string]bool
+}
+
+func newUsageAggregator(pattern string, depth int, byUpdated bool, now time.Time) *usageAggregator {
+	ua := &usageAggregator{depth: depth, byUpdated: byUpdated, now: now, report: &UsageReport{Pattern: pattern}, entries: make(map[Dimension]map[string]*UsageEntry), repos: make(map[string]bool)}
+	for _, dimension := range Dimensions {
+		ua.entries[dimension] = make(map[string]*UsageEntry)
+	}
+	return ua
+}
+
+func (ua *usageAggregator) add(item *servicesutils.ResultItem) {
+	if item.Type == "folder" {
+		return
+	}
+	ua.repos[item.Repo] = true
+	ua.report.TotalCount++
+	ua.report.TotalSize += item.Size
+	ua.addToEntry(PathPrefix, ua.getPathPrefix(item), item.Size)
+	ua.addToEntry(Extension, getExtension(item.Name), item.Size)
+	creator := item.CreatedBy
+	if creator == "" {
+		creator = unknownKey
+	}
+	ua.addToEntry(Creator, creator, item.Size)
+	ageTime := item.Created
+	if ua.byUpdated {
+		ageTime = item.Updated
+	}
+	ua.addToEntry(Age, ua.getTimeBucket(ageTime), item.Size)
+	lastDownload := neverKey
+	if len(item.Stats) > 0 && item.Stats[0].Downloaded != "" {
+		lastDownload = ua.getTimeBucket(item.Stats[0].Downloaded)
+	}
+	ua.addToEntry(LastDownload, lastDownload, item.Size)
+}
+
+func (ua *usageAggregator) addToEntry(dimension Dimension, key string, size int64) {
+	entry, ok := ua.entries[dimension][key]
+	if !ok {
+		entry = &UsageEntry{Key: key}
+		ua.entries[dimension][key] = entry
+	}
+	entry.Count++
+	entry.Size += size
+}
+
+// Returns the directory of the file, cut to the depth of the report.
+func (ua *usageAggregator) getPathPrefix(item *servicesutils.ResultItem) string {
+	components := []string{item.Repo}
+	if item.Path != "" && item.Path != "." {
+		components = append(components, strings.Split(item.Path, "/")...)
+	}
+	if len(components) > ua.depth {
+		components = components[:ua.depth]
+	}
+	return strings.Join(components, "/") + "/"
+}
+
+func getExtension(name string) string {
+	extension := strings.ToLower(path.Ext(name))
+	if extension == "" || extension == name {
+		return noExtensionKey
+	}
+	// Compressed tarballs are more meaningful with their full extension.
+	if strings.HasSuffix(strings.ToLower(strings.TrimSuffix(name, path.Ext(name))), ".tar") {
+		return ".tar" + extension
+	}
+	return extension
+}
+
+func (ua *usageAggregator) getTimeBucket(timestamp string) string {
+	parsedTime, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return unknownKey
+	}
+	age := ua.now.Sub(parsedTime)
+	for _, bucket := range timeBuckets {
+		if bucket.maxAge == 0 || age <= bucket.maxAge {
+			return bucket.name
+		}
+	}
+	return unknownKey
+}
+
+// Returns the report. The path, extension and creator entries are sorted by size, and only the top entries are kept.
+// The time buckets are sorted from the newest to the oldest.
+func (ua *usageAggregator) getReport(top int) *UsageReport {
+	ua.report.Groups = nil
+	for _, dimension := range Dimensions {
+		var entries []UsageEntry
+		for _, entry := range ua.entries[dimension] {
+			entries = append(entries, *entry)
+		}
+		if dimension == Age || dimension == LastDownload {
+			sortByTimeBuckets(entries)
+		} else {
+			entries = sortAndCutEntries(entries, top)
+		}
+		ua.report.Groups = append(ua.report.Groups, UsageGroup{Dimension: dimension, Entries: entries})
+	}
+	return ua.report
+}
+
+func (ua *usageAggregator) getRepositories() []string {
+	repos := make([]string, 0, len(ua.repos))
+	for repo := range ua.repos {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
+
+func sortAndCutEntries(entries []UsageEntry, top int) []UsageEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Size != entries[j].Size {
+			return entries[i].Size > entries[j].Size
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	if top <= 0 || len(entries) <= top {
+		return entries
+	}
+	other := UsageEntry{Key: otherEntriesKey}
+	for _, entry := range entries[top:] {
+		other.Count += entry.Count
+		other.Size += entry.Size
+	}
+	return append(entries[:top], other)
+}
+
+func sortByTimeBuckets(entries []UsageEntry) {
+	order := map[string]int{neverKey: len(timeBuckets), unknownKey: len(timeBuckets) + 1}
+	for i, bucket := range timeBuckets {
+		order[bucket.name] = i
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return order[entries[i].Key] < order[entries[j].Key]
+	})
+}
@@ -0,0 +1,82 @@
+package usagereport
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+var testItems = []servicesutils.ResultItem{
+	{Repo: "libs-local", Path: "org/acme/app/1.0", Name: "app-1.0.jar", Type: "file", Size: 1000, CreatedBy: "ci", Created: "2024-05-20T10:00:00.000Z", Updated: "2024-05-20T10:00:00.000Z",
+		Stats: []servicesutils.Stat{{Downloaded: "2024-05-30T10:00:00.000Z"}}},
+	{Repo: "libs-local", Path: "org/acme/app/0.9", Name: "app-0.9.tar.gz", Type: "file", Size: 3000, CreatedBy: "ci", Created: "2022-01-01T10:00:00.000+02:00", Updated: "2024-04-01T10:00:00.000Z"},
+	{Repo: "libs-local", Path: "com/other", Name: "README", Type: "file", Size: 10, Created: "2023-10-01T10:00:00.000Z", Updated: "2023-10-01T10:00:00.000Z",
+		Stats: []servicesutils.Stat{{Downloaded: "2023-12-01T10:00:00.000Z"}}},
+	{Repo: "libs-local", Path: ".", Name: "index.json", Type: "file", Size: 5, CreatedBy: "admin", Created: "invalid"},
+	{Repo: "libs-local", Path: "org", Name: "acme", Type: "folder"},
+}
+
+func createTestReport(depth, top int, byUpdated bool) *UsageReport {
+	aggregator := newUsageAggregator("libs-local", depth, byUpdated, testNow)
+	for i := range testItems {
+		aggregator.add(&testItems[i])
+	}
+	return aggregator.getReport(top)
+}
+
+func getGroup(report *UsageReport, dimension Dimension) []UsageEntry {
+	for _, group := range report.Groups {
+		if group.Dimension == dimension {
+			return group.Entries
+		}
+	}
+	return nil
+}
+
+func TestUsageAggregator(t *testing.T) {
+	report := createTestReport(2, 0, false)
+	assert.Equal(t, int64(4), report.TotalCount)
+	assert.Equal(t, int64(4015), report.TotalSize)
+	assert.Equal(t, []UsageEntry{{"libs-local/org/", 2, 4000}, {"libs-local/com/", 1, 10}, {"libs-local/", 1, 5}}, getGroup(report, PathPrefix))
+	assert.Equal(t, []UsageEntry{{".tar.gz", 1, 3000}, {".jar", 1, 1000}, {"(none)", 1, 10}, {".json", 1, 5}}, getGroup(report, Extension))
+	assert.Equal(t, []UsageEntry{{"ci", 2, 4000}, {"(unknown)", 1, 10}, {"admin", 1, 5}}, getGroup(report, Creator))
+	assert.Equal(t, []UsageEntry{{"Up to 30 days", 1, 1000}, {"180 days to 1 year", 1, 10}, {"Over 2 years", 1, 3000}, {"(unknown)", 1, 5}}, getGroup(report, Age))
+	assert.Equal(t, []UsageEntry{{"Up to 30 days", 1, 1000}, {"180 days to 1 year", 1, 10}, {"Never downloaded", 2, 3005}}, getGroup(report, LastDownload))
+}
+
+func TestUsageAggregatorDepthTopAndUpdated(t *testing.T) {
+	report := createTestReport(4, 2, true)
+	assert.Equal(t, []UsageEntry{{"libs-local/org/acme/app/", 2, 4000}, {"libs-local/com/other/", 1, 10}, {"(other)", 1, 5}}, getGroup(report, PathPrefix))
+	assert.Equal(t, []UsageEntry{{"Up to 30 days", 1, 1000}, {"30 to 90 days", 1, 3000}, {"180 days to 1 year", 1, 10}, {"(unknown)", 1, 5}}, getGroup(report, Age))
+}
+
+func TestUsageReportSummary(t *testing.T) {
+	report := createTestReport(2, 0, false)
+	report.Repositories = []RepositoryStorage{{Key: "libs-local", FileCount: 4, UsedSpace: 4015}}
+	dataFile := filepath.Join(t.TempDir(), "data-1")
+	require.NoError(t, writeJson(dataFile, report))
+
+	markdown, err := new(UsageReportSummary).GenerateMarkdownFromFiles([]string{dataFile})
+	require.NoError(t, err)
+	assert.Contains(t, markdown, "### Storage usage of `libs-local`")
+	assert.Contains(t, markdown, "**4** files, **3.9 KiB**")
+	assert.Contains(t, markdown, "| libs-local | 4 | 3.9 KiB |")
+	assert.Contains(t, markdown, "| libs-local/org/ | 2 | 3.9 KiB | 99.6% |")
+	assert.Contains(t, markdown, "| Never downloaded | 2 | 2.9 KiB | 74.8% |")
+}
+
+func writeJson(filePath string, value any) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, content, 0644)
+}
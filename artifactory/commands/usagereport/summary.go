@@ -0,0 +1,64 @@
+package usagereport
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/commandsummary"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+)
+
+// Generates the command summary markdown of the usage reports.
+type UsageReportSummary struct {
+	reports []UsageReport
+}
+
+func (urs *UsageReportSummary) GenerateMarkdownFromFiles(dataFilePaths []string) (markdown string, err error) {
+	urs.reports = nil
+	for _, dataFilePath := range dataFilePaths {
+		var report UsageReport
+		if err = commandsummary.UnmarshalFromFilePath(dataFilePath, &report); err != nil {
+			return
+		}
+		urs.reports = append(urs.reports, report)
+	}
+	var builder strings.Builder
+	for _, report := range urs.reports {
+		writeReportMarkdown(&builder, &report)
+	}
+	return builder.String(), nil
+}
+
+func writeReportMarkdown(builder *strings.Builder, report *UsageReport) {
+	builder.WriteString(fmt.Sprintf("\n### Storage usage of `%s`\n\n", report.Pattern))
+	builder.WriteString(fmt.Sprintf("**%d** files, **%s**\n\n", report.TotalCount, coreutils.SizeToString(report.TotalSize)))
+	if len(report.Repositories) > 0 {
+		builder.WriteString("| Repository | Files | Used Space |\n|---|---:|---:|\n")
+		for _, repository := range report.Repositories {
+			builder.WriteString(fmt.Sprintf("| %s | %d | %s |\n", escapeMarkdownCell(repository.Key), repository.FileCount, coreutils.SizeToString(repository.UsedSpace)))
+		}
+		builder.WriteString("\n")
+	}
+	for _, group := range report.Groups {
+		if len(group.Entries) == 0 {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("<details>\n<summary>By %s</summary>\n\n", group.Dimension.Title()))
+		builder.WriteString(fmt.Sprintf("| %s | Files | Size | %% of Size |\n|---|---:|---:|---:|\n", group.Dimension.Title()))
+		for _, entry := range group.Entries {
+			builder.WriteString(fmt.Sprintf("| %s | %d | %s | %s |\n", escapeMarkdownCell(entry.Key), entry.Count, coreutils.SizeToString(entry.Size), percentage(entry.Size, report.TotalSize)))
+		}
+		builder.WriteString("\n</details>\n\n")
+	}
+}
+
+func escapeMarkdownCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
+func percentage(part, total int64) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
@@ -0,0 +1,243 @@
+package usagereport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/commandsummary"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	defaultDepth = 2
+	defaultTop   = 20
+)
+
+// The output formats of the report.
+var OutputFormats = []format.OutputFormat{format.Table, format.Csv, format.Json}
+
+// The AQL fields required for the report.
+var reportFields = []string{"name", "repo", "path", "type", "size", "created", "updated", "created_by", "stat.downloaded"}
+
+// Reports the storage used by the files which match a pattern, aggregated by path, extension, creator, age and download inactivity.
+type UsageReportCommand struct {
+	serverDetails      *config.ServerDetails
+	pattern            string
+	depth              int
+	top                int
+	ageByUpdated       bool
+	includeStorageInfo bool
+	outputFormat       format.OutputFormat
+	report             *UsageReport
+}
+
+func NewUsageReportCommand() *UsageReportCommand {
+	return &UsageReportCommand{depth: defaultDepth, top: defaultTop, outputFormat: format.Table}
+}
+
+func (urc *UsageReportCommand) CommandName() string {
+	return "rt_usage_report"
+}
+
+func (urc *UsageReportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return urc.serverDetails, nil
+}
+
+func (urc *UsageReportCommand) SetServerDetails(serverDetails *config.ServerDetails) *UsageReportCommand {
+	urc.serverDetails = serverDetails
+	return urc
+}
+
+// The files to report, for example 'libs-*' for all the files of the matching repositories, or 'libs-release-local/org/acme/'.
+func (urc *UsageReportCommand) SetPattern(pattern string) *UsageReportCommand {
+	urc.pattern = pattern
+	return urc
+}
+
+// The number of path components, including the repository, by which the files are grouped.
+func (urc *UsageReportCommand) SetDepth(depth int) *UsageReportCommand {
+	urc.depth = depth
+	return urc
+}
+
+// The number of largest paths, extensions and creators to report. The rest are reported together. 0 reports all of them.
+func (urc *UsageReportCommand) SetTop(top int) *UsageReportCommand {
+	urc.top = top
+	return urc
+}
+
+// Calculates the age of the files from the time they were last updated, rather than created.
+func (urc *UsageReportCommand) SetAgeByUpdated(ageByUpdated bool) *UsageReportCommand {
+	urc.ageByUpdated = ageByUpdated
+	return urc
+}
+
+// Adds the storage summary of the repositories, as calculated by Artifactory. This requires admin permissions.
+func (urc *UsageReportCommand) SetIncludeStorageInfo(includeStorageInfo bool) *UsageReportCommand {
+	urc.includeStorageInfo = includeStorageInfo
+	return urc
+}
+
+func (urc *UsageReportCommand) SetOutputFormat(outputFormat format.OutputFormat) *UsageReportCommand {
+	urc.outputFormat = outputFormat
+	return urc
+}
+
+func (urc *UsageReportCommand) Report() *UsageReport {
+	return urc.report
+}
+
+func (urc *UsageReportCommand) Run() (err error) {
+	if urc.pattern == "" {
+		return errorutils.CheckErrorf("a pattern of the files to report must be provided")
+	}
+	if urc.depth < 1 {
+		return errorutils.CheckErrorf("the path depth must be at least 1")
+	}
+	storageInfoManager, err := utils.NewStorageInfoManager(context.Background(), urc.serverDetails)
+	if err != nil {
+		return err
+	}
+	aggregator := newUsageAggregator(urc.pattern, urc.depth, urc.ageByUpdated, time.Now())
+	log.Info("Searching the files which match", urc.pattern+"...")
+	searchSpec := spec.NewBuilder().Pattern(urc.pattern).Recursive(true).Include(reportFields).BuildSpec()
+	readers, closeReaders, err := utils.SearchFiles(storageInfoManager.GetServiceManager(), searchSpec)
+	defer func() {
+		err = errors.Join(err, closeReaders())
+	}()
+	if err != nil {
+		return err
+	}
+	for _, reader := range readers {
+		for item := new(servicesutils.ResultItem); reader.NextRecord(item) == nil; item = new(servicesutils.ResultItem) {
+			aggregator.add(item)
+		}
+		if err = reader.GetError(); err != nil {
+			return err
+		}
+	}
+	urc.report = aggregator.getReport(urc.top)
+	if urc.includeStorageInfo {
+		if urc.report.Repositories, err = getRepositoriesStorage(storageInfoManager, aggregator.getRepositories()); err != nil {
+			return err
+		}
+	}
+	if err = printReport(urc.report, urc.outputFormat); err != nil {
+		return err
+	}
+	return recordCommandSummary(urc.report)
+}
+
+func getRepositoriesStorage(storageInfoManager *utils.StorageInfoManager, repos []string) ([]RepositoryStorage, error) {
+	if len(repos) == 0 {
+		return nil, nil
+	}
+	if err := storageInfoManager.CalculateStorageInfo(); err != nil {
+		return nil, err
+	}
+	var repositories []RepositoryStorage
+	for _, repo := range repos {
+		repoSummary, err := storageInfoManager.GetRepoSummary(repo)
+		if err != nil {
+			return nil, err
+		}
+		fileCount, err := utils.GetFilesCountFromRepositorySummary(repoSummary)
+		if err != nil {
+			return nil, err
+		}
+		usedSpace, err := utils.GetUsedSpaceInBytes(repoSummary)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, RepositoryStorage{Key: repo, FileCount: fileCount, UsedSpace: usedSpace})
+	}
+	return repositories, nil
+}
+
+type usageRow struct {
+	Key     string `col-name:"Key"`
+	Files   string `col-name:"Files"`
+	Size    string `col-name:"Size"`
+	Percent string `col-name:"% of Size"`
+}
+
+type repositoryRow struct {
+	Key       string `col-name:"Repository"`
+	Files     string `col-name:"Files"`
+	UsedSpace string `col-name:"Used Space"`
+}
+
+func printReport(report *UsageReport, outputFormat format.OutputFormat) error {
+	switch outputFormat {
+	case format.Json:
+		content, err := json.Marshal(report)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	case format.Csv:
+		log.Output("dimension,key,count,size")
+		for _, group := range report.Groups {
+			for _, entry := range group.Entries {
+				record, err := coreutils.ToCsvRecord(string(group.Dimension), entry.Key, strconv.FormatInt(entry.Count, 10), strconv.FormatInt(entry.Size, 10))
+				if err != nil {
+					return err
+				}
+				log.Output(record)
+			}
+		}
+		return nil
+	case format.Table, "":
+		log.Output(fmt.Sprintf("%s: %d files, %s", report.Pattern, report.TotalCount, coreutils.SizeToString(report.TotalSize)))
+		if len(report.Repositories) > 0 {
+			var rows []repositoryRow
+			for _, repository := range report.Repositories {
+				rows = append(rows, repositoryRow{Key: repository.Key, Files: strconv.FormatInt(repository.FileCount, 10), UsedSpace: coreutils.SizeToString(repository.UsedSpace)})
+			}
+			if err := coreutils.PrintTable(rows, "Repositories", "", false); err != nil {
+				return err
+			}
+		}
+		for _, group := range report.Groups {
+			var rows []usageRow
+			for _, entry := range group.Entries {
+				rows = append(rows, usageRow{
+					Key:     entry.Key,
+					Files:   strconv.FormatInt(entry.Count, 10),
+					Size:    coreutils.SizeToString(entry.Size),
+					Percent: percentage(entry.Size, report.TotalSize),
+				})
+			}
+			if err := coreutils.PrintTable(rows, "By "+group.Dimension.Title(), "No files were found", false); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'", outputFormat)
+	}
+}
+
+func recordCommandSummary(report *UsageReport) error {
+	if !commandsummary.ShouldRecordSummary() {
+		return nil
+	}
+	usageReportSummary, err := commandsummary.New(new(UsageReportSummary), "usage-report")
+	if err != nil {
+		return err
+	}
+	return usageReportSummary.Record(report)
+}
@@ -0,0 +1,142 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	LockfileName = "Cargo.lock"
+	crateType    = "crate"
+
+	ModuleType entities.ModuleType = "cargo"
+)
+
+// The Cargo.lock file, which lists the workspace crates and all the crates they depend on.
+type Lockfile struct {
+	Version  int            `toml:"version"`
+	Packages []*LockPackage `toml:"package"`
+}
+
+type LockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+	// The registry or the git repository from which the crate is resolved. Empty for crates of the workspace.
+	Source string `toml:"source"`
+	// The sha256 checksum of the crate. Available for registry crates only.
+	Checksum string `toml:"checksum"`
+	// References to the crates this crate depends on, formatted as "name", "name version" or "name version (source)".
+	Dependencies []string `toml:"dependencies"`
+}
+
+func (lp *LockPackage) Id() string {
+	return lp.Name + ":" + lp.Version
+}
+
+func (lp *LockPackage) isWorkspaceMember() bool {
+	return lp.Source == ""
+}
+
+func ReadLockfile(dir string) (*Lockfile, error) {
+	content, err := os.ReadFile(filepath.Join(dir, LockfileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("%s was not found in %s. Run 'cargo generate-lockfile' to create it", LockfileName, dir)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseLockfile(content)
+}
+
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if _, err := toml.Decode(string(content), lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	return lockfile, nil
+}
+
+// Returns a build-info module for each crate of the workspace, with the registry and git crates it depends on.
+// Dependencies on other crates of the workspace are not included, since each of them is a separate module.
+func (l *Lockfile) GetModules() ([]entities.Module, error) {
+	var modules []entities.Module
+	for _, lockPackage := range l.Packages {
+		if !lockPackage.isWorkspaceMember() {
+			continue
+		}
+		dependencies := make(map[string]*entities.Dependency)
+		if err := l.appendDependencies(lockPackage, []string{lockPackage.Id()}, dependencies); err != nil {
+			return nil, err
+		}
+		module := entities.Module{Id: lockPackage.Id(), Type: ModuleType, Dependencies: make([]entities.Dependency, 0, len(dependencies))}
+		ids := maps.Keys(dependencies)
+		slices.Sort(ids)
+		for _, id := range ids {
+			module.Dependencies = append(module.Dependencies, *dependencies[id])
+		}
+		modules = append(modules, module)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return modules[i].Id < modules[j].Id
+	})
+	return modules, nil
+}
+
+func (l *Lockfile) appendDependencies(parent *LockPackage, pathToRoot []string, dependencies map[string]*entities.Dependency) error {
+	for _, reference := range parent.Dependencies {
+		lockPackage, err := l.findPackage(reference)
+		if err != nil {
+			return err
+		}
+		id := lockPackage.Id()
+		// Skip crates of the workspace, and circular dependencies.
+		if lockPackage.isWorkspaceMember() || slices.Contains(pathToRoot, id) {
+			continue
+		}
+		dependency, exists := dependencies[id]
+		if !exists {
+			dependency = &entities.Dependency{Id: id, Type: crateType, Checksum: entities.Checksum{Sha256: lockPackage.Checksum}}
+			dependencies[id] = dependency
+		}
+		dependency.RequestedBy = append(dependency.RequestedBy, pathToRoot)
+		// Crate graphs are usually dense, so the dependencies of each crate are expanded only once,
+		// to avoid walking every possible path. Each dependency is still included, with the paths found first.
+		if exists {
+			continue
+		}
+		if err = l.appendDependencies(lockPackage, append([]string{id}, pathToRoot...), dependencies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finds a crate by its reference in the dependencies list of another crate.
+// The version and the source are included in the reference only if the lockfile has several crates with the same name.
+func (l *Lockfile) findPackage(reference string) (*LockPackage, error) {
+	fields := strings.Fields(reference)
+	if len(fields) == 0 {
+		return nil, errorutils.CheckErrorf("%s contains an empty dependency reference", LockfileName)
+	}
+	name, version, source := fields[0], "", ""
+	if len(fields) > 1 {
+		version = fields[1]
+	}
+	if len(fields) > 2 {
+		source = strings.TrimSuffix(strings.TrimPrefix(fields[2], "("), ")")
+	}
+	for _, lockPackage := range l.Packages {
+		if lockPackage.Name == name && (version == "" || lockPackage.Version == version) && (source == "" || lockPackage.Source == source) {
+			return lockPackage, nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("the crate '%s' was not found in %s", reference, LockfileName)
+}
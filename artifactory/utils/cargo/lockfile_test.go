@@ -0,0 +1,79 @@
+package cargo
+
+import (
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLockfile = `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "lib",
+ "serde",
+ "rand 0.8.5",
+]
+
+[[package]]
+name = "lib"
+version = "0.1.0"
+dependencies = [
+ "rand 0.7.3",
+]
+
+[[package]]
+name = "rand"
+version = "0.7.3"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "a1"
+
+[[package]]
+name = "rand"
+version = "0.8.5"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "a2"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.200"
+source = "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-remote/index/"
+checksum = "b1"
+`
+
+func TestGetModules(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(testLockfile))
+	require.NoError(t, err)
+	modules, err := lockfile.GetModules()
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	app := modules[0]
+	assert.Equal(t, "app:0.1.0", app.Id)
+	assert.Equal(t, ModuleType, app.Type)
+	// The workspace crate 'lib' is a separate module.
+	assert.Equal(t, []entities.Dependency{
+		{Id: "rand:0.8.5", Type: crateType, Checksum: entities.Checksum{Sha256: "a2"}, RequestedBy: [][]string{{"app:0.1.0"}}},
+		{Id: "serde:1.0.200", Type: crateType, Checksum: entities.Checksum{Sha256: "b1"}, RequestedBy: [][]string{{"app:0.1.0"}, {"rand:0.8.5", "app:0.1.0"}}},
+	}, app.Dependencies)
+
+	lib := modules[1]
+	assert.Equal(t, "lib:0.1.0", lib.Id)
+	assert.Equal(t, []entities.Dependency{
+		{Id: "rand:0.7.3", Type: crateType, Checksum: entities.Checksum{Sha256: "a1"}, RequestedBy: [][]string{{"lib:0.1.0"}}},
+	}, lib.Dependencies)
+}
+
+func TestGetModulesMissingCrate(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte("version = 3\n[[package]]\nname = \"app\"\nversion = \"1.0.0\"\ndependencies = [\"missing\"]\n"))
+	require.NoError(t, err)
+	_, err = lockfile.GetModules()
+	assert.ErrorContains(t, err, "missing")
+}
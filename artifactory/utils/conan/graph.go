@@ -0,0 +1,182 @@
+package conan
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	ModuleType entities.ModuleType = "conan"
+
+	rootNodeId = "0"
+	// The recipe of the virtual root node, created by Conan for commands such as 'conan create' and 'conan install --requires'.
+	virtualRootRecipe = "Cli"
+	packageFileName   = "conan_package.tgz"
+	emptyField        = "_"
+)
+
+// The dependency graph printed by Conan 2 commands with '--format=json'.
+type Graph struct {
+	Graph struct {
+		Nodes map[string]*GraphNode `json:"nodes"`
+	} `json:"graph"`
+}
+
+type GraphNode struct {
+	Ref          string `json:"ref"`
+	Recipe       string `json:"recipe"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	User         string `json:"user"`
+	Channel      string `json:"channel"`
+	RecipeRev    string `json:"rrev"`
+	PackageId    string `json:"package_id"`
+	PackageRev   string `json:"prev"`
+	Context      string `json:"context"`
+	Dependencies map[string]struct {
+		Direct bool `json:"direct"`
+	} `json:"dependencies"`
+}
+
+// Returns the reference of the node without its revision, formatted as "name/version[@user/channel]".
+func (gn *GraphNode) Reference() string {
+	if gn.Name == "" {
+		reference, _, _ := strings.Cut(gn.Ref, "#")
+		return reference
+	}
+	reference := gn.Name + "/" + gn.Version
+	if gn.User != "" || gn.Channel != "" {
+		reference += "@" + valueOrEmptyField(gn.User) + "/" + valueOrEmptyField(gn.Channel)
+	}
+	return reference
+}
+
+// Returns the path of the package binary of the node in a Conan repository in Artifactory, or an empty string if the binary is unknown.
+func (gn *GraphNode) PackagePath() string {
+	if gn.Name == "" || gn.RecipeRev == "" || gn.PackageId == "" || gn.PackageRev == "" {
+		return ""
+	}
+	return strings.Join([]string{RecipePath(gn.Name, gn.Version, gn.User, gn.Channel, gn.RecipeRev), "package", gn.PackageId, gn.PackageRev, packageFileName}, "/")
+}
+
+// Returns the path of a recipe revision in a Conan repository in Artifactory.
+// The recipe files are stored in the 'export' directory, and the package binaries in the 'package' directory under this path.
+func RecipePath(name, version, user, channel, recipeRev string) string {
+	return strings.Join([]string{valueOrEmptyField(user), name, version, valueOrEmptyField(channel), recipeRev}, "/")
+}
+
+func valueOrEmptyField(value string) string {
+	if value == "" {
+		return emptyField
+	}
+	return value
+}
+
+func ParseGraph(content []byte) (*Graph, error) {
+	graph := new(Graph)
+	if err := json.Unmarshal(content, graph); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the Conan graph: %s", err.Error())
+	}
+	if graph.Graph.Nodes[rootNodeId] == nil {
+		return nil, errorutils.CheckErrorf("the Conan graph has no root node")
+	}
+	return graph, nil
+}
+
+// Returns the nodes which are built by the command as modules.
+// This is the root node of a conanfile, or the direct dependencies of a virtual root node, such as the package created by 'conan create'.
+func (g *Graph) ModuleNodeIds() []string {
+	root := g.Graph.Nodes[rootNodeId]
+	if root.Recipe != virtualRootRecipe {
+		return []string{rootNodeId}
+	}
+	var ids []string
+	for id, dependency := range root.Dependencies {
+		if dependency.Direct {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Returns the transitive dependencies of a node, with the Conan context of each dependency (host or build) as its scope.
+func (g *Graph) GetDependencies(nodeId string) ([]entities.Dependency, error) {
+	node, ok := g.Graph.Nodes[nodeId]
+	if !ok {
+		return nil, errorutils.CheckErrorf("the node %s was not found in the Conan graph", nodeId)
+	}
+	dependencies := make(map[string]*entities.Dependency)
+	if err := g.appendDependencies(node, []string{node.Reference()}, dependencies); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(dependencies))
+	for id := range dependencies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	result := make([]entities.Dependency, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *dependencies[id])
+	}
+	return result, nil
+}
+
+func (g *Graph) appendDependencies(parent *GraphNode, pathToRoot []string, dependencies map[string]*entities.Dependency) error {
+	for _, childId := range sortedDependencyIds(parent) {
+		child, ok := g.Graph.Nodes[childId]
+		if !ok {
+			return errorutils.CheckErrorf("the node %s was not found in the Conan graph", childId)
+		}
+		id := child.Reference()
+		if slices.Contains(pathToRoot, id) {
+			continue
+		}
+		dependency, exists := dependencies[id]
+		if !exists {
+			dependency = &entities.Dependency{Id: id}
+			dependencies[id] = dependency
+		}
+		scope := child.Context
+		if scope == "" {
+			scope = "host"
+		}
+		if !slices.Contains(dependency.Scopes, scope) {
+			dependency.Scopes = append(dependency.Scopes, scope)
+		}
+		dependency.RequestedBy = append(dependency.RequestedBy, pathToRoot)
+		// The dependencies of each node are expanded only once, to avoid walking every possible path of dense graphs.
+		if exists {
+			continue
+		}
+		if err := g.appendDependencies(child, append([]string{id}, pathToRoot...), dependencies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedDependencyIds(node *GraphNode) []string {
+	ids := make([]string, 0, len(node.Dependencies))
+	for id := range node.Dependencies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Returns the paths of the package binaries of the graph nodes in Artifactory, mapped by their references.
+func (g *Graph) PackagePaths() map[string]string {
+	paths := make(map[string]string)
+	for _, node := range g.Graph.Nodes {
+		if path := node.PackagePath(); path != "" {
+			paths[node.Reference()] = path
+		}
+	}
+	return paths
+}
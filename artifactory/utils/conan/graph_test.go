@@ -0,0 +1,62 @@
+package conan
+
+import (
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCreateGraph = `{"graph": {"nodes": {
+	"0": {"ref": "", "recipe": "Cli", "context": "host", "dependencies": {"1": {"direct": true}, "2": {"direct": false}, "3": {"direct": false}}},
+	"1": {"ref": "app/1.0.0#r1", "name": "app", "version": "1.0.0", "rrev": "r1", "package_id": "p1", "prev": "pr1", "context": "host",
+		"dependencies": {"2": {"direct": true}, "3": {"direct": true}, "4": {"direct": true}}},
+	"2": {"ref": "openssl/3.2.1#r2", "name": "openssl", "version": "3.2.1", "rrev": "r2", "package_id": "p2", "prev": "pr2", "context": "host",
+		"dependencies": {"3": {"direct": true}}},
+	"3": {"ref": "zlib/1.3.1#r3", "name": "zlib", "version": "1.3.1", "rrev": "r3", "context": "host", "dependencies": {}},
+	"4": {"ref": "cmake/3.28.1@tools/stable#r4", "name": "cmake", "version": "3.28.1", "user": "tools", "channel": "stable",
+		"rrev": "r4", "package_id": "p4", "prev": "pr4", "context": "build", "dependencies": {}}
+}}}`
+
+func TestGraphDependencies(t *testing.T) {
+	graph, err := ParseGraph([]byte(testCreateGraph))
+	require.NoError(t, err)
+	moduleIds := graph.ModuleNodeIds()
+	require.Equal(t, []string{"1"}, moduleIds)
+	dependencies, err := graph.GetDependencies(moduleIds[0])
+	require.NoError(t, err)
+	assert.Equal(t, []entities.Dependency{
+		{Id: "cmake/3.28.1@tools/stable", Scopes: []string{"build"}, RequestedBy: [][]string{{"app/1.0.0"}}},
+		{Id: "openssl/3.2.1", Scopes: []string{"host"}, RequestedBy: [][]string{{"app/1.0.0"}}},
+		{Id: "zlib/1.3.1", Scopes: []string{"host"}, RequestedBy: [][]string{{"openssl/3.2.1", "app/1.0.0"}, {"app/1.0.0"}}},
+	}, dependencies)
+	assert.Equal(t, map[string]string{
+		"app/1.0.0":                 "_/app/1.0.0/_/r1/package/p1/pr1/conan_package.tgz",
+		"openssl/3.2.1":             "_/openssl/3.2.1/_/r2/package/p2/pr2/conan_package.tgz",
+		"cmake/3.28.1@tools/stable": "tools/cmake/3.28.1/stable/r4/package/p4/pr4/conan_package.tgz",
+	}, graph.PackagePaths())
+}
+
+func TestGraphConanfileRoot(t *testing.T) {
+	graph, err := ParseGraph([]byte(`{"graph": {"nodes": {"0": {"ref": "conanfile", "recipe": "Consumer", "dependencies": {}}}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0"}, graph.ModuleNodeIds())
+
+	_, err = ParseGraph([]byte(`{"graph": {"nodes": {}}}`))
+	assert.ErrorContains(t, err, "no root node")
+}
+
+func TestUploadedRecipes(t *testing.T) {
+	packageList, err := ParsePackageList([]byte(`{"artifactory": {
+		"zlib/1.3.1": {"revisions": {"r3": {"timestamp": 1700000000.0, "packages": {"p3": {"info": {}, "revisions": {"pr3": {"timestamp": 1700000001.0}}}}}}},
+		"cmake/3.28.1@tools/stable": {"revisions": {"r4": {"timestamp": 1700000000.0}}}
+	}}`))
+	require.NoError(t, err)
+	recipes, err := packageList.UploadedRecipes("artifactory")
+	require.NoError(t, err)
+	assert.Equal(t, []UploadedRecipe{
+		{Reference: "cmake/3.28.1@tools/stable", Paths: []string{"tools/cmake/3.28.1/stable/r4/export"}},
+		{Reference: "zlib/1.3.1", Paths: []string{"_/zlib/1.3.1/_/r3/export", "_/zlib/1.3.1/_/r3/package/p3/pr3"}},
+	}, recipes)
+}
@@ -0,0 +1,76 @@
+package conan
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The package list printed by 'conan upload --format=json', which maps each remote to the uploaded recipes and packages.
+type PackageList map[string]map[string]struct {
+	Revisions map[string]struct {
+		Packages map[string]struct {
+			Revisions map[string]struct{} `json:"revisions"`
+		} `json:"packages"`
+	} `json:"revisions"`
+}
+
+// An uploaded recipe revision, with the paths of its files in Artifactory.
+type UploadedRecipe struct {
+	// The recipe reference, formatted as "name/version[@user/channel]".
+	Reference string
+	// The paths of the directories which contain the recipe and package files, relative to the repository root.
+	Paths []string
+}
+
+func ParsePackageList(content []byte) (PackageList, error) {
+	packageList := make(PackageList)
+	if err := json.Unmarshal(content, &packageList); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the Conan package list: %s", err.Error())
+	}
+	return packageList, nil
+}
+
+// Returns the recipes uploaded to the remote, sorted by their references.
+func (pl PackageList) UploadedRecipes(remote string) ([]UploadedRecipe, error) {
+	var recipes []UploadedRecipe
+	for reference, recipe := range pl[remote] {
+		name, version, user, channel, err := parseReference(reference)
+		if err != nil {
+			return nil, err
+		}
+		for recipeRev, revision := range recipe.Revisions {
+			recipePath := RecipePath(name, version, user, channel, recipeRev)
+			uploaded := UploadedRecipe{Reference: reference, Paths: []string{recipePath + "/export"}}
+			for packageId, packageDetails := range revision.Packages {
+				for packageRev := range packageDetails.Revisions {
+					uploaded.Paths = append(uploaded.Paths, strings.Join([]string{recipePath, "package", packageId, packageRev}, "/"))
+				}
+			}
+			sort.Strings(uploaded.Paths)
+			recipes = append(recipes, uploaded)
+		}
+	}
+	sort.Slice(recipes, func(i, j int) bool {
+		if recipes[i].Reference != recipes[j].Reference {
+			return recipes[i].Reference < recipes[j].Reference
+		}
+		return recipes[i].Paths[0] < recipes[j].Paths[0]
+	})
+	return recipes, nil
+}
+
+// Parses a reference formatted as "name/version[@user/channel]".
+func parseReference(reference string) (name, version, user, channel string, err error) {
+	nameAndVersion, userAndChannel, hasUserAndChannel := strings.Cut(reference, "@")
+	name, version, found := strings.Cut(nameAndVersion, "/")
+	if !found || name == "" || version == "" {
+		return "", "", "", "", errorutils.CheckErrorf("invalid Conan reference: %s", reference)
+	}
+	if hasUserAndChannel {
+		user, channel, _ = strings.Cut(userAndChannel, "/")
+	}
+	return
+}
@@ -187,6 +187,21 @@ func (loginCmd *LoginCmd) RunCmd() error {
 	return command.Run()
 }
 
+// Returns the credentials for logging in to an Artifactory registry.
+// If an access token exists, it is used instead of the password.
+func GetLoginCredentials(serverDetails *config.ServerDetails) (username, password string) {
+	username = serverDetails.User
+	password = serverDetails.Password
+	if serverDetails.AccessToken != "" {
+		log.Debug("Using access-token details in the registry login.")
+		if username == "" {
+			username = auth.ExtractUsernameFromAccessToken(serverDetails.AccessToken)
+		}
+		password = serverDetails.AccessToken
+	}
+	return
+}
+
 // First we'll try to log in assuming a proxy-less tag (e.g. "registry-address/docker-repo/image:ver").
 // If fails, we will try assuming a reverse proxy tag (e.g. "registry-address-docker-repo/image:ver").
 func ContainerManagerLogin(image *Image, config *ContainerManagerLoginConfig, containerManager ContainerManagerType) error {
@@ -194,16 +209,7 @@ func ContainerManagerLogin(image *Image, config *ContainerManagerLoginConfig, co
 	if err != nil {
 		return err
 	}
-	username := config.ServerDetails.User
-	password := config.ServerDetails.Password
-	// If access-token exists, perform login with it.
-	if config.ServerDetails.AccessToken != "" {
-		log.Debug("Using access-token details in " + containerManager.String() + "-login command.")
-		if username == "" {
-			username = auth.ExtractUsernameFromAccessToken(config.ServerDetails.AccessToken)
-		}
-		password = config.ServerDetails.AccessToken
-	}
+	username, password := GetLoginCredentials(config.ServerDetails)
 	// Perform login.
 	cmd := &LoginCmd{DockerRegistry: imageRegistry, Username: username, Password: password, containerManager: containerManager}
 	err = cmd.RunCmd()
@@ -0,0 +1,94 @@
+package dotnet
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	PackageExtension       = ".nupkg"
+	SymbolPackageExtension = ".snupkg"
+	nuspecExtension        = ".nuspec"
+	symbolsPackageType     = "SymbolsPackage"
+)
+
+// The manifest of a NuGet package, which is the .nuspec file at the root of the package.
+type Nuspec struct {
+	Metadata NuspecMetadata `xml:"metadata"`
+}
+
+type NuspecMetadata struct {
+	Id          string `xml:"id"`
+	Version     string `xml:"version"`
+	Title       string `xml:"title"`
+	Authors     string `xml:"authors"`
+	Description string `xml:"description"`
+	Tags        string `xml:"tags"`
+	ProjectUrl  string `xml:"projectUrl"`
+	// The package types, for example 'SymbolsPackage' for symbol packages.
+	PackageTypes []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"packageTypes>packageType"`
+}
+
+// Symbol packages are marked by the SymbolsPackage package type.
+func (nm *NuspecMetadata) IsSymbolsPackage() bool {
+	for _, packageType := range nm.PackageTypes {
+		if packageType.Name == symbolsPackageType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the file name of the package, as NuGet names it: <id>.<version>.nupkg, or .snupkg for symbol packages.
+func (nm *NuspecMetadata) FileName(extension string) string {
+	return nm.Id + "." + nm.Version + extension
+}
+
+// Returns the properties which describe the package in Artifactory.
+func (nm *NuspecMetadata) Properties() map[string]string {
+	properties := map[string]string{"nuget.id": nm.Id, "nuget.version": nm.Version}
+	for key, value := range map[string]string{"nuget.title": nm.Title, "nuget.authors": nm.Authors, "nuget.tags": nm.Tags, "nuget.projectUrl": nm.ProjectUrl} {
+		if value = strings.TrimSpace(value); value != "" {
+			properties[key] = value
+		}
+	}
+	return properties
+}
+
+// Reads the .nuspec file of a package or a symbol package.
+func ReadNuspec(packagePath string) (nuspec *Nuspec, err error) {
+	reader, err := zip.OpenReader(packagePath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to open the NuGet package %s: %s", packagePath, err.Error())
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	for _, file := range reader.File {
+		if path.Dir(file.Name) != "." || path.Ext(file.Name) != nuspecExtension {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		nuspec = new(Nuspec)
+		err = xml.NewDecoder(content).Decode(nuspec)
+		err = errors.Join(err, content.Close())
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the .nuspec file of %s: %s", packagePath, err.Error())
+		}
+		if nuspec.Metadata.Id == "" || nuspec.Metadata.Version == "" {
+			return nil, errorutils.CheckErrorf("the .nuspec file of %s doesn't include the package id and version", packagePath)
+		}
+		return nuspec, nil
+	}
+	return nil, errorutils.CheckErrorf("the NuGet package %s doesn't include a .nuspec file", packagePath)
+}
@@ -0,0 +1,113 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ChartFileName     = "Chart.yaml"
+	ChartLockFileName = "Chart.lock"
+	chartsDirName     = "charts"
+	chartPackageType  = "tgz"
+
+	ModuleType entities.ModuleType = "helm"
+)
+
+// The metadata of a chart, from its Chart.yaml file.
+type Chart struct {
+	ApiVersion string `yaml:"apiVersion"`
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+}
+
+// The name of the package created by 'helm package'.
+func (c *Chart) PackageFileName() string {
+	return c.Name + "-" + c.Version + "." + chartPackageType
+}
+
+func (c *Chart) BuildInfoModuleId() string {
+	return c.Name + ":" + c.Version
+}
+
+// The Chart.lock file, which lists the exact versions of the chart dependencies.
+type ChartLock struct {
+	Dependencies []ChartDependency `yaml:"dependencies"`
+	Digest       string            `yaml:"digest"`
+}
+
+type ChartDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+func ReadChart(chartDir string) (*Chart, error) {
+	chart := new(Chart)
+	exists, err := readYaml(filepath.Join(chartDir, ChartFileName), chart)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errorutils.CheckErrorf("%s was not found in %s", ChartFileName, chartDir)
+	}
+	if chart.Name == "" || chart.Version == "" {
+		return nil, errorutils.CheckErrorf("the %s in %s must include the chart name and version", ChartFileName, chartDir)
+	}
+	return chart, nil
+}
+
+// Returns nil if the chart has no Chart.lock file.
+func ReadChartLock(chartDir string) (*ChartLock, error) {
+	chartLock := new(ChartLock)
+	exists, err := readYaml(filepath.Join(chartDir, ChartLockFileName), chartLock)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return chartLock, nil
+}
+
+func readYaml(path string, out any) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errorutils.CheckError(err)
+	}
+	if err = yaml.Unmarshal(content, out); err != nil {
+		return false, errorutils.CheckErrorf("failed to parse %s: %s", path, err.Error())
+	}
+	return true, nil
+}
+
+// Returns the build-info dependencies of the chart, from its Chart.lock file.
+// The checksums are calculated from the dependency packages in the charts directory, if 'helm dependency build' downloaded them.
+func GetDependencies(chartDir string, chartLock *ChartLock) ([]entities.Dependency, error) {
+	if chartLock == nil {
+		return nil, nil
+	}
+	dependencies := make([]entities.Dependency, 0, len(chartLock.Dependencies))
+	for _, chartDependency := range chartLock.Dependencies {
+		dependency := entities.Dependency{Id: chartDependency.Name + ":" + chartDependency.Version, Type: chartPackageType}
+		packagePath := filepath.Join(chartDir, chartsDirName, chartDependency.Name+"-"+chartDependency.Version+"."+chartPackageType)
+		exists, err := fileutils.IsFileExists(packagePath, false)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			details, err := fileutils.GetFileDetails(packagePath, true)
+			if err != nil {
+				return nil, err
+			}
+			dependency.Checksum = details.Checksum
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
@@ -0,0 +1,44 @@
+package helm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testChartDir = filepath.Join("testdata", "chart")
+
+func TestReadChart(t *testing.T) {
+	chart, err := ReadChart(testChartDir)
+	require.NoError(t, err)
+	assert.Equal(t, "web:1.2.0", chart.BuildInfoModuleId())
+	assert.Equal(t, "web-1.2.0.tgz", chart.PackageFileName())
+
+	_, err = ReadChart(t.TempDir())
+	assert.ErrorContains(t, err, ChartFileName+" was not found")
+}
+
+func TestGetDependencies(t *testing.T) {
+	chartLock, err := ReadChartLock(testChartDir)
+	require.NoError(t, err)
+	dependencies, err := GetDependencies(testChartDir, chartLock)
+	require.NoError(t, err)
+	// The redis package exists in the charts directory, so its checksums are calculated.
+	assert.Equal(t, []entities.Dependency{
+		{Id: "redis:18.6.1", Type: "tgz", Checksum: entities.Checksum{
+			Sha1:   "94a282078cc54f204b8b5a500e5d49facf307750",
+			Md5:    "394c534c53d403a7be7f0d99fcb50db7",
+			Sha256: "316e8106b8122660eac2e586c4ff4afae68bf0d18cc9ebe9906c67e5134efd1f",
+		}},
+		{Id: "common:2.14.1", Type: "tgz"},
+	}, dependencies)
+}
+
+func TestReadChartLockNotExist(t *testing.T) {
+	chartLock, err := ReadChartLock(t.TempDir())
+	assert.NoError(t, err)
+	assert.Nil(t, chartLock)
+}
@@ -0,0 +1,66 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	GoSumFileName = "go.sum"
+	goModFileName = "go.mod"
+	goModSuffix   = "/" + goModFileName
+	goZipType     = "zip"
+)
+
+// go.sum doesn't describe the dependency graph, so all the dependencies are requested by the module itself.
+// The h1 hashes of go.sum are not checksums of the module zips, so the checksums are always looked up in Artifactory.
+func readGoSum(dir string) ([]*Module, error) {
+	goMod, err := os.ReadFile(filepath.Join(dir, goModFileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	moduleId := modfile.ModulePath(goMod)
+	if moduleId == "" {
+		return nil, errorutils.CheckErrorf("the module path was not found in %s", filepath.Join(dir, goModFileName))
+	}
+	goSum, err := os.ReadFile(filepath.Join(dir, GoSumFileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	dependencies, err := parseGoSum(goSum, moduleId)
+	if err != nil {
+		return nil, err
+	}
+	return []*Module{{Id: moduleId, Type: entities.Go, Dependencies: dependencies}}, nil
+}
+
+func parseGoSum(content []byte, moduleId string) (map[string]*entities.Dependency, error) {
+	dependencies := make(map[string]*entities.Dependency)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, errorutils.CheckErrorf("failed to parse %s: unexpected format in line %d", GoSumFileName, lineNumber)
+		}
+		// Modules which are listed only by the hashes of their go.mod files are not downloaded by the build.
+		if strings.HasSuffix(fields[1], goModSuffix) {
+			continue
+		}
+		id := fields[0] + ":" + fields[1]
+		dependencies[id] = &entities.Dependency{Id: id, Type: goZipType, RequestedBy: [][]string{{moduleId}}}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return dependencies, nil
+}
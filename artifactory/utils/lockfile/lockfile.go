@@ -0,0 +1,187 @@
+package lockfile
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/pythonutils"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/cargo"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils/pnpm"
+	python "github.com/jfrog/jfrog-cli-core/v2/utils/python"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// A build-info module read from a lock file.
+type Module struct {
+	Id   string
+	Type entities.ModuleType
+	// The dependencies of the module mapped by their IDs. The checksums are set only if the lock file includes them.
+	Dependencies map[string]*entities.Dependency
+	// The names of the files which represent the dependencies of Python modules, mapped by the dependency IDs.
+	// These files are required for looking up the dependencies checksums in Artifactory.
+	FileNames map[string]string
+}
+
+// A supported lock file, and the function which reads the modules from it.
+type Lockfile struct {
+	Name       string
+	ModuleType entities.ModuleType
+	read       func(dir string) ([]*Module, error)
+}
+
+// The supported lock files, by the order in which they are detected.
+var lockfiles = []Lockfile{
+	{Name: NpmLockfileName, ModuleType: entities.Npm, read: readNpmLockfile},
+	{Name: YarnLockfileName, ModuleType: entities.Npm, read: readYarnLockfile},
+	{Name: pnpm.LockfileName, ModuleType: entities.Npm, read: readPnpmLockfile},
+	{Name: python.PoetryLockFileName, ModuleType: entities.Python, read: createPythonLockfileReader(pythonutils.Poetry)},
+	{Name: python.UvLockFileName, ModuleType: entities.Python, read: createPythonLockfileReader(python.Uv)},
+	{Name: python.PdmLockFileName, ModuleType: entities.Python, read: createPythonLockfileReader(python.Pdm)},
+	{Name: GoSumFileName, ModuleType: entities.Go, read: readGoSum},
+	{Name: NugetLockfileName, ModuleType: entities.Nuget, read: readNugetLockfile},
+	{Name: cargo.LockfileName, ModuleType: cargo.ModuleType, read: readCargoLockfile},
+}
+
+func (l *Lockfile) Read(dir string) ([]*Module, error) {
+	return l.read(dir)
+}
+
+// Returns the names of the supported lock files.
+func GetSupportedLockfiles() []string {
+	names := make([]string, 0, len(lockfiles))
+	for _, lockfile := range lockfiles {
+		names = append(names, lockfile.Name)
+	}
+	return names
+}
+
+// Returns the supported lock file with the given name.
+func GetLockfile(name string) (*Lockfile, error) {
+	for i := range lockfiles {
+		if lockfiles[i].Name == name {
+			return &lockfiles[i], nil
+		}
+	}
+	return nil, errorutils.CheckErrorf("the lock file '%s' is not supported. Supported lock files: %v", name, GetSupportedLockfiles())
+}
+
+// Returns the supported lock files which exist in dir.
+func Detect(dir string) ([]*Lockfile, error) {
+	var detected []*Lockfile
+	for i := range lockfiles {
+		exists, err := fileutils.IsFileExists(filepath.Join(dir, lockfiles[i].Name), false)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			detected = append(detected, &lockfiles[i])
+		}
+	}
+	return detected, nil
+}
+
+// A dependency graph, used to set the requested-by paths of the dependencies of a module.
+type dependencyGraph struct {
+	// The IDs of the dependencies required by each node. The module itself is the node with an empty ID.
+	children map[string][]string
+	// Creates the dependency of a node, when it's found for the first time.
+	newDependency func(id string) *entities.Dependency
+}
+
+// Returns the dependencies reachable from the module, with their requested-by paths.
+// The dependencies of each node are expanded only once, to avoid walking every possible path of dense graphs.
+func (dg *dependencyGraph) collect(moduleId string) map[string]*entities.Dependency {
+	dependencies := make(map[string]*entities.Dependency)
+	var walk func(parentId string, pathToRoot []string)
+	walk = func(parentId string, pathToRoot []string) {
+		for _, id := range dg.children[parentId] {
+			if slices.Contains(pathToRoot, id) {
+				continue
+			}
+			dependency, exists := dependencies[id]
+			if !exists {
+				dependency = dg.newDependency(id)
+				dependencies[id] = dependency
+			}
+			dependency.RequestedBy = append(dependency.RequestedBy, pathToRoot)
+			if !exists {
+				walk(id, append([]string{id}, pathToRoot...))
+			}
+		}
+	}
+	walk("", []string{moduleId})
+	return dependencies
+}
+
+func addScope(dependency *entities.Dependency, scope string) {
+	if scope != "" && !slices.Contains(dependency.Scopes, scope) {
+		dependency.Scopes = append(dependency.Scopes, scope)
+	}
+}
+
+func readPnpmLockfile(dir string) ([]*Module, error) {
+	lockfile, err := pnpm.ReadLockfile(dir)
+	if err != nil {
+		return nil, err
+	}
+	var modules []*Module
+	for _, importerPath := range lockfile.ImporterPaths() {
+		moduleId, err := lockfile.ModuleId(importerPath)
+		if err != nil {
+			return nil, err
+		}
+		dependencies, err := lockfile.GetDependencies(importerPath, moduleId)
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, &Module{Id: moduleId, Type: entities.Npm, Dependencies: dependencies})
+	}
+	return modules, nil
+}
+
+func readCargoLockfile(dir string) ([]*Module, error) {
+	lockfile, err := cargo.ReadLockfile(dir)
+	if err != nil {
+		return nil, err
+	}
+	cargoModules, err := lockfile.GetModules()
+	if err != nil {
+		return nil, err
+	}
+	modules := make([]*Module, 0, len(cargoModules))
+	for _, cargoModule := range cargoModules {
+		module := &Module{Id: cargoModule.Id, Type: cargoModule.Type, Dependencies: make(map[string]*entities.Dependency, len(cargoModule.Dependencies))}
+		for i := range cargoModule.Dependencies {
+			module.Dependencies[cargoModule.Dependencies[i].Id] = &cargoModule.Dependencies[i]
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// Python lock files don't include the project itself, so the module ID is taken from pyproject.toml, or from the directory name.
+func createPythonLockfileReader(tool pythonutils.PythonTool) func(dir string) ([]*Module, error) {
+	return func(dir string) ([]*Module, error) {
+		packages, topLevelPackages, err := python.ReadLockedPackages(tool, dir)
+		if err != nil {
+			return nil, err
+		}
+		moduleId, err := python.GetPyprojectPackageName(dir)
+		if err != nil {
+			return nil, err
+		}
+		if moduleId == "" {
+			moduleId = filepath.Base(dir)
+		}
+		module := &Module{Id: moduleId, Type: entities.Python, Dependencies: make(map[string]*entities.Dependency), FileNames: make(map[string]string)}
+		for _, dependency := range python.LockedPackagesToDependencies(packages, topLevelPackages, moduleId) {
+			module.Dependencies[dependency.Id] = &dependency
+		}
+		for _, lockedPackage := range packages {
+			module.FileNames[lockedPackage.Name+":"+lockedPackage.Version] = lockedPackage.FileName
+		}
+		return []*Module{module}, nil
+	}
+}
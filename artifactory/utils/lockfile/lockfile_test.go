@@ -0,0 +1,193 @@
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNpmLockfile(t *testing.T) {
+	content := `{
+  "name": "my-app",
+  "version": "1.0.0",
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "my-app", "version": "1.0.0", "dependencies": {"a": "^1.0.0", "b-alias": "npm:b@^2.0.0"}, "devDependencies": {"c": "^3.0.0"}},
+    "node_modules/a": {"version": "1.0.0", "integrity": "sha1-qUtTuTpeeIrzF2aUqRy5mXHXb2A=", "dependencies": {"b": "^1.0.0"}},
+    "node_modules/a/node_modules/b": {"version": "1.1.0"},
+    "node_modules/b-alias": {"name": "b", "version": "2.0.0"},
+    "node_modules/c": {"version": "3.0.0", "dev": true, "dependencies": {"a": "^1.0.0"}}
+  }
+}`
+	modules, err := parseNpmLockfile([]byte(content))
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	assert.Equal(t, "my-app:1.0.0", modules[0].Id)
+	assert.Equal(t, map[string]*entities.Dependency{
+		"a:1.0.0": {Id: "a:1.0.0", Scopes: []string{"prod"}, Checksum: entities.Checksum{Sha1: "a94b53b93a5e788af3176694a91cb99971d76f60"},
+			RequestedBy: [][]string{{"my-app:1.0.0"}, {"c:3.0.0", "my-app:1.0.0"}}},
+		"b:1.1.0": {Id: "b:1.1.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"a:1.0.0", "my-app:1.0.0"}}},
+		"b:2.0.0": {Id: "b:2.0.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+		"c:3.0.0": {Id: "c:3.0.0", Scopes: []string{"dev"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+	}, modules[0].Dependencies)
+}
+
+func TestParseNpmLockfileUnsupportedVersion(t *testing.T) {
+	_, err := parseNpmLockfile([]byte(`{"name": "my-app", "version": "1.0.0", "lockfileVersion": 1}`))
+	assert.ErrorContains(t, err, "version 1 is not supported")
+}
+
+var yarnPackageInfo = &biutils.PackageInfo{
+	Name:            "my-app",
+	Version:         "1.0.0",
+	Dependencies:    map[string]string{"a": "^1.0.0"},
+	DevDependencies: map[string]string{"@scope/c": "^3.0.0"},
+}
+
+var expectedYarnDependencies = map[string]*entities.Dependency{
+	"a:1.0.0": {Id: "a:1.0.0", Scopes: []string{"prod"}, Checksum: entities.Checksum{Sha1: "a94b53b93a5e788af3176694a91cb99971d76f60"},
+		RequestedBy: [][]string{{"my-app:1.0.0"}, {"@scope/c:3.0.0", "my-app:1.0.0"}}},
+	"b:1.1.0":        {Id: "b:1.1.0", Scopes: []string{"prod"}, RequestedBy: [][]string{{"a:1.0.0", "my-app:1.0.0"}}},
+	"@scope/c:3.0.0": {Id: "@scope/c:3.0.0", Scopes: []string{"dev"}, RequestedBy: [][]string{{"my-app:1.0.0"}}},
+}
+
+func TestParseYarnClassicLockfile(t *testing.T) {
+	content := `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@scope/c@^3.0.0":
+  version "3.0.0"
+  resolved "https://registry.yarnpkg.com/@scope/c/-/c-3.0.0.tgz"
+  dependencies:
+    a "^1.0.0"
+
+a@^1.0.0, a@^1.0.1:
+  version "1.0.0"
+  resolved "https://registry.yarnpkg.com/a/-/a-1.0.0.tgz#a94b53b93a5e788af3176694a91cb99971d76f60"
+  integrity sha512-AAAA
+  dependencies:
+    b "^1.0.0"
+
+b@^1.0.0:
+  version "1.1.0"
+  resolved "https://registry.yarnpkg.com/b/-/b-1.1.0.tgz"
+`
+	modules, err := parseYarnLockfile([]byte(content), yarnPackageInfo)
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	assert.Equal(t, "my-app:1.0.0", modules[0].Id)
+	assert.Equal(t, expectedYarnDependencies, modules[0].Dependencies)
+}
+
+func TestParseYarnBerryLockfile(t *testing.T) {
+	content := `# This file is generated by running "yarn install" inside your project.
+
+__metadata:
+  version: 8
+  cacheKey: 10
+
+"@scope/c@npm:^3.0.0":
+  version: 3.0.0
+  resolution: "@scope/c@npm:3.0.0"
+  dependencies:
+    a: "npm:^1.0.0"
+  checksum: 10/abcd
+  languageName: node
+  linkType: hard
+
+"a@npm:^1.0.0, a@npm:^1.0.1":
+  version: 1.0.0
+  resolution: "a@npm:1.0.0"
+  dependencies:
+    b: "npm:^1.0.0"
+  checksum: 10/abcd
+  languageName: node
+  linkType: hard
+
+"b@npm:^1.0.0":
+  version: 1.1.0
+  resolution: "b@npm:1.1.0"
+  languageName: node
+  linkType: hard
+
+"my-app@workspace:.":
+  version: 0.0.0-use.local
+  resolution: "my-app@workspace:."
+  dependencies:
+    a: "npm:^1.0.0"
+  languageName: unknown
+  linkType: soft
+`
+	modules, err := parseYarnLockfile([]byte(content), yarnPackageInfo)
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	// Yarn 2 and above don't keep sha1 checksums.
+	expected := make(map[string]*entities.Dependency)
+	for id, dependency := range expectedYarnDependencies {
+		withoutChecksum := *dependency
+		withoutChecksum.Checksum = entities.Checksum{}
+		expected[id] = &withoutChecksum
+	}
+	assert.Equal(t, expected, modules[0].Dependencies)
+}
+
+func TestReadGoSum(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, goModFileName), []byte("module github.com/acme/app\n\ngo 1.22\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, GoSumFileName), []byte(`github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+golang.org/x/mod v0.20.0/go.mod h1:hTbmBsO62+eylJbnUtE2MGJUyE7QWk4xUqPFrRgJ+7c=
+`), 0600))
+	modules, err := readGoSum(dir)
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	assert.Equal(t, "github.com/acme/app", modules[0].Id)
+	assert.Equal(t, entities.Go, modules[0].Type)
+	assert.Equal(t, map[string]*entities.Dependency{
+		"github.com/pkg/errors:v0.9.1": {Id: "github.com/pkg/errors:v0.9.1", Type: "zip", RequestedBy: [][]string{{"github.com/acme/app"}}},
+	}, modules[0].Dependencies)
+}
+
+func TestParseNugetLockfile(t *testing.T) {
+	content := `{
+  "version": 1,
+  "dependencies": {
+    "net6.0": {
+      "Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.1, )", "resolved": "13.0.1", "contentHash": "abcd"},
+      "Serilog": {"type": "Direct", "requested": "[2.12.0, )", "resolved": "2.12.0", "dependencies": {"System.Memory": "4.5.5"}},
+      "System.Memory": {"type": "Transitive", "resolved": "4.5.5"},
+      "lib": {"type": "Project", "dependencies": {"Newtonsoft.Json": "[13.0.1, )"}}
+    },
+    "net8.0": {
+      "Newtonsoft.Json": {"type": "Direct", "requested": "[13.0.1, )", "resolved": "13.0.1", "contentHash": "abcd"}
+    }
+  }
+}`
+	dependencies, err := parseNugetLockfile([]byte(content), "app")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*entities.Dependency{
+		"Newtonsoft.Json:13.0.1": {Id: "Newtonsoft.Json:13.0.1", Type: "nupkg", Scopes: []string{"net6.0", "net8.0"}, RequestedBy: [][]string{{"app"}}},
+		"Serilog:2.12.0":         {Id: "Serilog:2.12.0", Type: "nupkg", Scopes: []string{"net6.0"}, RequestedBy: [][]string{{"app"}}},
+		"System.Memory:4.5.5":    {Id: "System.Memory:4.5.5", Type: "nupkg", Scopes: []string{"net6.0"}, RequestedBy: [][]string{{"Serilog:2.12.0", "app"}}},
+	}, dependencies)
+}
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, GoSumFileName), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, NpmLockfileName), nil, 0600))
+	detected, err := Detect(dir)
+	require.NoError(t, err)
+	require.Len(t, detected, 2)
+	assert.Equal(t, NpmLockfileName, detected[0].Name)
+	assert.Equal(t, GoSumFileName, detected[1].Name)
+
+	_, err = GetLockfile("Gemfile.lock")
+	assert.ErrorContains(t, err, "is not supported")
+}
@@ -0,0 +1,157 @@
+package lockfile
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	NpmLockfileName = "package-lock.json"
+	nodeModulesDir  = "node_modules/"
+	// Lockfile v2 is created by npm 7 and 8, and v3 by npm 9 and above.
+	minNpmLockfileVersion = 2
+
+	prodScope = "prod"
+	devScope  = "dev"
+)
+
+type npmLockfile struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	LockfileVersion int    `json:"lockfileVersion"`
+	// Maps the installation path of each package to its details. The root project is at the empty path.
+	Packages map[string]*npmLockPackage `json:"packages"`
+}
+
+type npmLockPackage struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Integrity            string            `json:"integrity"`
+	Link                 bool              `json:"link"`
+	Dev                  bool              `json:"dev"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+func readNpmLockfile(dir string) ([]*Module, error) {
+	content, err := os.ReadFile(filepath.Join(dir, NpmLockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parseNpmLockfile(content)
+}
+
+func parseNpmLockfile(content []byte) ([]*Module, error) {
+	lockfile := new(npmLockfile)
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", NpmLockfileName, err.Error())
+	}
+	if lockfile.LockfileVersion < minNpmLockfileVersion {
+		return nil, errorutils.CheckErrorf("%s version %d is not supported. Run 'npm install' with npm 7 or above to upgrade it", NpmLockfileName, lockfile.LockfileVersion)
+	}
+	root := lockfile.Packages[""]
+	if root == nil {
+		return nil, errorutils.CheckErrorf("%s doesn't include the root project", NpmLockfileName)
+	}
+	moduleId := lockfile.Name + ":" + lockfile.Version
+	// The dependencies are identified by their installation paths while walking the graph, since packages are resolved relatively to them.
+	idsByPath := make(map[string]string)
+	graph := &dependencyGraph{children: make(map[string][]string)}
+	var addChildren func(parentId, parentPath string, parent *npmLockPackage, includeDev bool)
+	addChildren = func(parentId, parentPath string, parent *npmLockPackage, includeDev bool) {
+		if _, visited := graph.children[parentId]; visited {
+			return
+		}
+		graph.children[parentId] = []string{}
+		groups := []map[string]string{parent.Dependencies, parent.OptionalDependencies, parent.PeerDependencies}
+		if includeDev {
+			groups = append(groups, parent.DevDependencies)
+		}
+		var names []string
+		for _, group := range groups {
+			groupNames := maps.Keys(group)
+			slices.Sort(groupNames)
+			names = append(names, groupNames...)
+		}
+		for _, name := range names {
+			childPath, child := lockfile.resolve(parentPath, name)
+			if child == nil || child.Link {
+				continue
+			}
+			id := packageNameFromPath(childPath, child) + ":" + child.Version
+			idsByPath[childPath] = id
+			graph.children[parentId] = append(graph.children[parentId], id)
+			addChildren(id, childPath, child, false)
+		}
+	}
+	addChildren("", "", root, true)
+	packagesById := make(map[string]*npmLockPackage, len(idsByPath))
+	for path, id := range idsByPath {
+		packagesById[id] = lockfile.Packages[path]
+	}
+	graph.newDependency = func(id string) *entities.Dependency {
+		lockPackage := packagesById[id]
+		dependency := &entities.Dependency{Id: id, Checksum: integrityToChecksum(lockPackage.Integrity)}
+		if lockPackage.Dev {
+			addScope(dependency, devScope)
+		} else {
+			addScope(dependency, prodScope)
+		}
+		return dependency
+	}
+	return []*Module{{Id: moduleId, Type: entities.Npm, Dependencies: graph.collect(moduleId)}}, nil
+}
+
+// Resolves a package required by the package at parentPath, as Node.js does:
+// by looking for it in the node_modules directory of the parent, and then in the node_modules directories of its ancestors.
+func (nl *npmLockfile) resolve(parentPath, name string) (string, *npmLockPackage) {
+	for {
+		path := nodeModulesDir + name
+		if parentPath != "" {
+			path = parentPath + "/" + path
+		}
+		if lockPackage, ok := nl.Packages[path]; ok {
+			return path, lockPackage
+		}
+		if parentPath == "" {
+			return "", nil
+		}
+		i := strings.LastIndex(parentPath, nodeModulesDir)
+		if i <= 0 {
+			parentPath = ""
+		} else {
+			parentPath = strings.TrimSuffix(parentPath[:i], "/")
+		}
+	}
+}
+
+// Returns the package name, which differs from the installation directory name for aliased packages.
+func packageNameFromPath(path string, lockPackage *npmLockPackage) string {
+	if lockPackage.Name != "" {
+		return lockPackage.Name
+	}
+	return path[strings.LastIndex(path, nodeModulesDir)+len(nodeModulesDir):]
+}
+
+// Converts a subresource integrity to a build-info checksum. Only sha1 integrities can be converted, since build-info has no sha512 checksum.
+func integrityToChecksum(integrity string) entities.Checksum {
+	for _, hash := range strings.Fields(integrity) {
+		if encoded, found := strings.CutPrefix(hash, "sha1-"); found {
+			if decoded, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+				return entities.Checksum{Sha1: hex.EncodeToString(decoded)}
+			}
+		}
+	}
+	return entities.Checksum{}
+}
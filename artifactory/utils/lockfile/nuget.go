@@ -0,0 +1,117 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	NugetLockfileName = "packages.lock.json"
+	nupkgType         = "nupkg"
+	// The types of the packages in the lock file, which are either direct or transitive dependencies, or projects of the solution.
+	nugetDirectType  = "Direct"
+	nugetProjectType = "Project"
+)
+
+type nugetLockfile struct {
+	// The locked packages of each target framework, mapped by their names.
+	Dependencies map[string]map[string]*nugetLockedPackage `json:"dependencies"`
+}
+
+type nugetLockedPackage struct {
+	Type     string `json:"type"`
+	Resolved string `json:"resolved"`
+	// The ranges of the packages this package depends on, mapped by their names.
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// The lock file doesn't include the project itself, so the module ID is the project directory name.
+// Each target framework is added as a scope of the dependencies resolved for it.
+func readNugetLockfile(dir string) ([]*Module, error) {
+	content, err := os.ReadFile(filepath.Join(dir, NugetLockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	dependencies, err := parseNugetLockfile(content, filepath.Base(dir))
+	if err != nil {
+		return nil, err
+	}
+	return []*Module{{Id: filepath.Base(dir), Type: entities.Nuget, Dependencies: dependencies}}, nil
+}
+
+func parseNugetLockfile(content []byte, moduleId string) (map[string]*entities.Dependency, error) {
+	lockfile := new(nugetLockfile)
+	if err := json.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", NugetLockfileName, err.Error())
+	}
+	dependencies := make(map[string]*entities.Dependency)
+	frameworks := maps.Keys(lockfile.Dependencies)
+	slices.Sort(frameworks)
+	for _, framework := range frameworks {
+		packages := lockfile.Dependencies[framework]
+		// Package names are case-insensitive in NuGet.
+		packagesByName := make(map[string]*nugetLockedPackage, len(packages))
+		for name, lockedPackage := range packages {
+			packagesByName[strings.ToLower(name)] = lockedPackage
+		}
+		idOf := func(name string) string {
+			lockedPackage, ok := packagesByName[strings.ToLower(name)]
+			if !ok || lockedPackage.Type == nugetProjectType {
+				return ""
+			}
+			return name + ":" + lockedPackage.Resolved
+		}
+		graph := &dependencyGraph{children: make(map[string][]string)}
+		names := maps.Keys(packages)
+		slices.Sort(names)
+		for _, name := range names {
+			id := idOf(name)
+			if id == "" {
+				continue
+			}
+			if packages[name].Type == nugetDirectType {
+				graph.children[""] = append(graph.children[""], id)
+			}
+			childNames := maps.Keys(packages[name].Dependencies)
+			slices.Sort(childNames)
+			for _, childName := range childNames {
+				if childId := idOf(childName); childId != "" {
+					graph.children[id] = append(graph.children[id], childId)
+				}
+			}
+		}
+		graph.newDependency = func(id string) *entities.Dependency {
+			return &entities.Dependency{Id: id}
+		}
+		for id, frameworkDependency := range graph.collect(moduleId) {
+			dependency, exists := dependencies[id]
+			if !exists {
+				dependency = &entities.Dependency{Id: id, Type: nupkgType}
+				dependencies[id] = dependency
+			}
+			addScope(dependency, framework)
+			for _, pathToRoot := range frameworkDependency.RequestedBy {
+				if !containsPath(dependency.RequestedBy, pathToRoot) {
+					dependency.RequestedBy = append(dependency.RequestedBy, pathToRoot)
+				}
+			}
+		}
+	}
+	return dependencies, nil
+}
+
+func containsPath(paths [][]string, path []string) bool {
+	for _, existingPath := range paths {
+		if strings.Join(existingPath, "\n") == strings.Join(path, "\n") {
+			return true
+		}
+	}
+	return false
+}
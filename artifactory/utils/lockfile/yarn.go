@@ -0,0 +1,248 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	YarnLockfileName = "yarn.lock"
+	// The key which exists only in lock files of Yarn 2 and above.
+	yarnBerryMetadataKey = "__metadata"
+	yarnBerryNpmProtocol = "npm:"
+	yarnBerrySoftLink    = "soft"
+)
+
+var sha1HexRegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// A package resolved in yarn.lock, which is shared by all the descriptors (name@range) of its entry.
+type yarnLockEntry struct {
+	Version    string `yaml:"version"`
+	Resolution string `yaml:"resolution"`
+	// Yarn 1 only: the tarball URL, with the sha1 checksum of the tarball as its fragment.
+	Resolved             string            `yaml:"resolved"`
+	Integrity            string            `yaml:"integrity"`
+	LinkType             string            `yaml:"linkType"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+	name                 string
+}
+
+type yarnLockfile struct {
+	entries map[string]*yarnLockEntry
+	berry   bool
+}
+
+func readYarnLockfile(dir string) ([]*Module, error) {
+	content, err := os.ReadFile(filepath.Join(dir, YarnLockfileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(dir, nil)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parseYarnLockfile(content, packageInfo)
+}
+
+func parseYarnLockfile(content []byte, packageInfo *biutils.PackageInfo) ([]*Module, error) {
+	var lockfile *yarnLockfile
+	var err error
+	if bytes.Contains(content, []byte("\n"+yarnBerryMetadataKey+":")) || bytes.HasPrefix(content, []byte(yarnBerryMetadataKey+":")) {
+		lockfile, err = parseYarnBerryLockfile(content)
+	} else {
+		lockfile, err = parseYarnClassicLockfile(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	moduleId := packageInfo.BuildInfoModuleId()
+	graph := &dependencyGraph{children: make(map[string][]string)}
+	entriesById := make(map[string]*yarnLockEntry)
+	var addChildren func(parentId string, names []string, dependencies map[string]string)
+	addChildren = func(parentId string, names []string, dependencies map[string]string) {
+		if _, visited := graph.children[parentId]; visited {
+			return
+		}
+		graph.children[parentId] = []string{}
+		for _, name := range names {
+			entry := lockfile.find(name, dependencies[name])
+			if entry == nil || entry.LinkType == yarnBerrySoftLink {
+				continue
+			}
+			id := entry.name + ":" + entry.Version
+			entriesById[id] = entry
+			graph.children[parentId] = append(graph.children[parentId], id)
+			childDependencies := entry.allDependencies()
+			childNames := maps.Keys(childDependencies)
+			slices.Sort(childNames)
+			addChildren(id, childNames, childDependencies)
+		}
+	}
+	// The production dependencies of the project are walked first, so their requested-by paths don't go through dev dependencies.
+	prodDependencies := mergeMaps(packageInfo.Dependencies, packageInfo.OptionalDependencies)
+	prodNames := maps.Keys(prodDependencies)
+	slices.Sort(prodNames)
+	devNames := maps.Keys(packageInfo.DevDependencies)
+	slices.Sort(devNames)
+	addChildren("", slices.Concat(prodNames, devNames), mergeMaps(packageInfo.DevDependencies, prodDependencies))
+	// Dependencies reachable from the production dependencies of the project are in the prod scope, and the rest are in the dev scope.
+	prodIds := make(map[string]bool)
+	var markProd func(id string)
+	markProd = func(id string) {
+		if prodIds[id] {
+			return
+		}
+		prodIds[id] = true
+		for _, childId := range graph.children[id] {
+			markProd(childId)
+		}
+	}
+	for _, name := range prodNames {
+		if entry := lockfile.find(name, prodDependencies[name]); entry != nil {
+			markProd(entry.name + ":" + entry.Version)
+		}
+	}
+	graph.newDependency = func(id string) *entities.Dependency {
+		dependency := &entities.Dependency{Id: id, Checksum: entriesById[id].checksum()}
+		if prodIds[id] {
+			addScope(dependency, prodScope)
+		} else {
+			addScope(dependency, devScope)
+		}
+		return dependency
+	}
+	return []*Module{{Id: moduleId, Type: entities.Npm, Dependencies: graph.collect(moduleId)}}, nil
+}
+
+// Finds the entry of a dependency by its name and the range it's required with.
+// Yarn 2 and above prefix semver ranges with the npm protocol in the lock file, but not in package.json.
+func (yl *yarnLockfile) find(name, versionRange string) *yarnLockEntry {
+	if entry, ok := yl.entries[name+"@"+versionRange]; ok {
+		return entry
+	}
+	if yl.berry {
+		return yl.entries[name+"@"+yarnBerryNpmProtocol+versionRange]
+	}
+	return nil
+}
+
+func (yle *yarnLockEntry) allDependencies() map[string]string {
+	return mergeMaps(yle.Dependencies, yle.OptionalDependencies)
+}
+
+// Returns the sha1 checksum of the package, from the fragment of its tarball URL or from its integrity.
+// The checksums of Yarn 2 and above are sha512, and therefore can't be used.
+func (yle *yarnLockEntry) checksum() entities.Checksum {
+	if _, fragment, found := strings.Cut(yle.Resolved, "#"); found && sha1HexRegex.MatchString(fragment) {
+		return entities.Checksum{Sha1: fragment}
+	}
+	return integrityToChecksum(yle.Integrity)
+}
+
+func parseYarnBerryLockfile(content []byte) (*yarnLockfile, error) {
+	var rawEntries map[string]*yarnLockEntry
+	if err := yaml.Unmarshal(content, &rawEntries); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", YarnLockfileName, err.Error())
+	}
+	lockfile := &yarnLockfile{entries: make(map[string]*yarnLockEntry), berry: true}
+	for key, entry := range rawEntries {
+		if key == yarnBerryMetadataKey || entry == nil {
+			continue
+		}
+		entry.name = descriptorName(entry.Resolution)
+		for _, descriptor := range strings.Split(key, ",") {
+			lockfile.entries[strings.TrimSpace(descriptor)] = entry
+		}
+	}
+	return lockfile, nil
+}
+
+// Parses a lock file of Yarn 1, which has a YAML-like format of its own.
+func parseYarnClassicLockfile(content []byte) (*yarnLockfile, error) {
+	lockfile := &yarnLockfile{entries: make(map[string]*yarnLockEntry)}
+	var entry *yarnLockEntry
+	var dependencies map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indentation := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indentation == 0:
+			entry = &yarnLockEntry{}
+			dependencies = nil
+			for _, descriptor := range strings.Split(strings.TrimSuffix(trimmed, ":"), ",") {
+				descriptor = unquote(strings.TrimSpace(descriptor))
+				entry.name = descriptorName(descriptor)
+				lockfile.entries[descriptor] = entry
+			}
+		case entry == nil:
+			return nil, errorutils.CheckErrorf("failed to parse %s: unexpected indentation in line %d", YarnLockfileName, lineNumber)
+		case indentation == 2:
+			dependencies = nil
+			key, value, _ := strings.Cut(trimmed, " ")
+			switch key {
+			case "version":
+				entry.Version = unquote(value)
+			case "resolved":
+				entry.Resolved = unquote(value)
+			case "integrity":
+				entry.Integrity = unquote(value)
+			case "dependencies:":
+				entry.Dependencies = make(map[string]string)
+				dependencies = entry.Dependencies
+			case "optionalDependencies:":
+				entry.OptionalDependencies = make(map[string]string)
+				dependencies = entry.OptionalDependencies
+			}
+		case dependencies != nil:
+			name, versionRange, _ := strings.Cut(trimmed, " ")
+			dependencies[unquote(name)] = unquote(versionRange)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return lockfile, nil
+}
+
+// Returns the package name of a descriptor (name@range) or a resolution (name@protocol:version).
+func descriptorName(descriptor string) string {
+	if i := strings.Index(descriptor[min(1, len(descriptor)):], "@"); i != -1 {
+		return descriptor[:i+1]
+	}
+	return descriptor
+}
+
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
+
+func mergeMaps(maps ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, m := range maps {
+		for key, value := range m {
+			merged[key] = value
+		}
+	}
+	return merged
+}
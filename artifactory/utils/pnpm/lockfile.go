@@ -0,0 +1,248 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	biutils "github.com/jfrog/build-info-go/build/utils"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LockfileName = "pnpm-lock.yaml"
+	// The root project of the workspace, as it appears in the lockfile importers.
+	RootImporter = "."
+
+	// Lockfile v6 is used by pnpm 8, and v9 by pnpm 9 and above.
+	minSupportedLockfileVersion = 6
+
+	ProdScope     = "prod"
+	DevScope      = "dev"
+	OptionalScope = "optional"
+)
+
+// The parts of pnpm-lock.yaml which are required for building the dependencies graph.
+type Lockfile struct {
+	LockfileVersion string `yaml:"lockfileVersion"`
+	// Projects of the workspace. Lockfiles of projects without a workspace list the root project dependencies at the top level instead.
+	Importers map[string]*Importer `yaml:"importers"`
+	Importer  `yaml:",inline"`
+	// In lockfile v9, the packages hold the resolution details, and the snapshots hold the dependencies.
+	Packages  map[string]*lockfilePackage `yaml:"packages"`
+	Snapshots map[string]*lockfilePackage `yaml:"snapshots"`
+	dir       string
+}
+
+type Importer struct {
+	Dependencies         map[string]importerDependency `yaml:"dependencies,omitempty"`
+	DevDependencies      map[string]importerDependency `yaml:"devDependencies,omitempty"`
+	OptionalDependencies map[string]importerDependency `yaml:"optionalDependencies,omitempty"`
+}
+
+// A direct dependency of an importer. Lockfile v6 and above write it as {specifier, version}.
+type importerDependency struct {
+	Specifier string `yaml:"specifier"`
+	Version   string `yaml:"version"`
+}
+
+func (id *importerDependency) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		id.Version = value.Value
+		return nil
+	}
+	type plain importerDependency
+	return value.Decode((*plain)(id))
+}
+
+type lockfilePackage struct {
+	Dependencies         map[string]string `yaml:"dependencies,omitempty"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies,omitempty"`
+}
+
+// Reads the pnpm-lock.yaml file in the provided directory.
+func ReadLockfile(dir string) (*Lockfile, error) {
+	content, err := os.ReadFile(filepath.Join(dir, LockfileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errorutils.CheckErrorf("%s was not found in %s. Run 'pnpm install' to create it", LockfileName, dir)
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	lockfile, err := ParseLockfile(content)
+	if err != nil {
+		return nil, err
+	}
+	lockfile.dir = dir
+	return lockfile, nil
+}
+
+func ParseLockfile(content []byte) (*Lockfile, error) {
+	lockfile := new(Lockfile)
+	if err := yaml.Unmarshal(content, lockfile); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse %s: %s", LockfileName, err.Error())
+	}
+	majorVersion, _, _ := strings.Cut(lockfile.LockfileVersion, ".")
+	if version, err := strconv.Atoi(majorVersion); err != nil || version < minSupportedLockfileVersion {
+		return nil, errorutils.CheckErrorf("%s version %s is not supported. Use pnpm 8 or above", LockfileName, lockfile.LockfileVersion)
+	}
+	if len(lockfile.Importers) == 0 {
+		lockfile.Importers = map[string]*Importer{RootImporter: &lockfile.Importer}
+	}
+	return lockfile, nil
+}
+
+// Returns the paths of the workspace projects, relative to the workspace root. The root project comes first.
+func (l *Lockfile) ImporterPaths() []string {
+	paths := make([]string, 0, len(l.Importers))
+	for path := range l.Importers {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if paths[i] == RootImporter || paths[j] == RootImporter {
+			return paths[i] == RootImporter
+		}
+		return paths[i] < paths[j]
+	})
+	return paths
+}
+
+// Returns the build-info module ID of a workspace project, read from its package.json.
+// If the package.json has no name or version, the project path is used.
+func (l *Lockfile) ModuleId(importerPath string) (string, error) {
+	packageInfo, err := biutils.ReadPackageInfoFromPackageJsonIfExists(filepath.Join(l.dir, importerPath), nil)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if moduleId := packageInfo.BuildInfoModuleId(); moduleId != "" {
+		return moduleId, nil
+	}
+	return filepath.ToSlash(importerPath), nil
+}
+
+// Returns the dependencies of a workspace project, mapped by their IDs.
+// The requestedBy paths of each dependency end with moduleId.
+// Dependencies on other workspace projects (link:) are not included, since each workspace project is a separate module.
+func (l *Lockfile) GetDependencies(importerPath, moduleId string) (map[string]*entities.Dependency, error) {
+	importer, exists := l.Importers[importerPath]
+	if !exists {
+		return nil, errorutils.CheckErrorf("the project '%s' was not found in %s", importerPath, LockfileName)
+	}
+	dependencies := make(map[string]*entities.Dependency)
+	for _, directDependencies := range []struct {
+		scope        string
+		dependencies map[string]importerDependency
+	}{
+		{ProdScope, importer.Dependencies},
+		{DevScope, importer.DevDependencies},
+		{OptionalScope, importer.OptionalDependencies},
+	} {
+		names := maps.Keys(directDependencies.dependencies)
+		slices.Sort(names)
+		for _, name := range names {
+			key, ok := resolveKey(name, directDependencies.dependencies[name].Version)
+			if !ok {
+				continue
+			}
+			if err := l.appendDependencyRecursively(key, directDependencies.scope, []string{moduleId}, dependencies); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dependencies, nil
+}
+
+func (l *Lockfile) appendDependencyRecursively(key, scope string, pathToRoot []string, dependencies map[string]*entities.Dependency) error {
+	id := keyToDependencyId(key)
+	// To avoid infinite loops in case of circular dependencies, the dependency won't be added if it's already in pathToRoot.
+	if slices.Contains(pathToRoot, id) {
+		return nil
+	}
+	dependency, exists := dependencies[id]
+	if !exists {
+		dependency = &entities.Dependency{Id: id}
+		dependencies[id] = dependency
+	}
+	if !slices.Contains(dependency.Scopes, scope) {
+		dependency.Scopes = append(dependency.Scopes, scope)
+	}
+	dependency.RequestedBy = append(dependency.RequestedBy, pathToRoot)
+
+	lockPackage, err := l.getPackage(key)
+	if err != nil {
+		return err
+	}
+	childPath := append([]string{id}, pathToRoot...)
+	for _, childDependencies := range []map[string]string{lockPackage.Dependencies, lockPackage.OptionalDependencies} {
+		names := maps.Keys(childDependencies)
+		slices.Sort(names)
+		for _, name := range names {
+			childKey, ok := resolveKey(name, childDependencies[name])
+			if !ok {
+				continue
+			}
+			if err = l.appendDependencyRecursively(childKey, scope, childPath, dependencies); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (l *Lockfile) getPackage(key string) (*lockfilePackage, error) {
+	lockPackage, exists := l.Snapshots[key]
+	if !exists {
+		// Lockfile v6 package keys start with a slash.
+		if lockPackage, exists = l.Packages["/"+key]; !exists {
+			lockPackage, exists = l.Packages[key]
+		}
+	}
+	if exists {
+		// Packages without dependencies may have an empty entry.
+		if lockPackage == nil {
+			lockPackage = &lockfilePackage{}
+		}
+		return lockPackage, nil
+	}
+	return nil, errorutils.CheckErrorf("an error occurred while creating the dependencies tree: %s was not found in %s", key, LockfileName)
+}
+
+// Returns the key of a dependency in the lockfile packages, by the dependency name and the version reference of its requester.
+// Returns false for dependencies which are not resolved from a registry, such as links to workspace projects.
+func resolveKey(name, versionRef string) (string, bool) {
+	if versionRef == "" || strings.HasPrefix(versionRef, "link:") || strings.HasPrefix(versionRef, "file:") {
+		return "", false
+	}
+	versionRef = strings.TrimPrefix(versionRef, "/")
+	if versionRef == "" {
+		return "", false
+	}
+	// An aliased dependency references the real package by its name and version, such as 'string-width@4.2.3'.
+	if strings.Contains(stripPeersSuffix(versionRef)[1:], "@") {
+		return versionRef, true
+	}
+	return name + "@" + versionRef, true
+}
+
+// Converts a lockfile package key (name@version(peers)) to a build-info dependency ID (name:version).
+func keyToDependencyId(key string) string {
+	key = stripPeersSuffix(strings.TrimPrefix(key, "/"))
+	if index := strings.LastIndex(key, "@"); index > 0 {
+		return key[:index] + ":" + key[index+1:]
+	}
+	return key
+}
+
+// Removes the resolved peer dependencies suffix, such as '(react@18.2.0)', from a version or a package key.
+func stripPeersSuffix(value string) string {
+	if index := strings.Index(value, "("); index > 0 {
+		return value[:index]
+	}
+	return value
+}
@@ -0,0 +1,113 @@
+package pnpm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lockfileV9 = `lockfileVersion: '9.0'
+importers:
+  .:
+    dependencies:
+      react-dom:
+        specifier: ^18.2.0
+        version: 18.2.0(react@18.2.0)
+    devDependencies:
+      lib:
+        specifier: workspace:*
+        version: link:packages/lib
+  packages/lib:
+    dependencies:
+      strip:
+        specifier: npm:strip-ansi@^6.0.1
+        version: strip-ansi@6.0.1
+packages:
+  react@18.2.0:
+    resolution: {integrity: sha512-a}
+  react-dom@18.2.0:
+    resolution: {integrity: sha512-b}
+  ansi-regex@5.0.1:
+    resolution: {integrity: sha512-c}
+  strip-ansi@6.0.1:
+    resolution: {integrity: sha512-d}
+snapshots:
+  react@18.2.0:
+    dependencies:
+      loose-envify: 1.4.0
+  loose-envify@1.4.0: {}
+  react-dom@18.2.0(react@18.2.0):
+    dependencies:
+      react: 18.2.0
+  ansi-regex@5.0.1: {}
+  strip-ansi@6.0.1:
+    dependencies:
+      ansi-regex: 5.0.1
+`
+
+const lockfileV6 = `lockfileVersion: '6.0'
+dependencies:
+  '@scope/a':
+    specifier: ^1.0.0
+    version: 1.0.0
+devDependencies:
+  b:
+    specifier: ^2.0.0
+    version: 2.0.0
+packages:
+  /@scope/a@1.0.0:
+    resolution: {integrity: sha512-a}
+    dependencies:
+      b: 2.0.0
+  /b@2.0.0:
+    resolution: {integrity: sha512-b}
+    dev: false
+`
+
+func TestLockfileV9Workspace(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, LockfileName), []byte(lockfileV9), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"name":"app","version":"1.0.0"}`), 0600))
+	lockfile, err := ReadLockfile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{RootImporter, "packages/lib"}, lockfile.ImporterPaths())
+
+	moduleId, err := lockfile.ModuleId(RootImporter)
+	require.NoError(t, err)
+	assert.Equal(t, "app:1.0.0", moduleId)
+	dependencies, err := lockfile.GetDependencies(RootImporter, moduleId)
+	require.NoError(t, err)
+	// The workspace link is not a dependency.
+	assert.Len(t, dependencies, 3)
+	assert.Equal(t, [][]string{{"app:1.0.0"}}, dependencies["react-dom:18.2.0"].RequestedBy)
+	assert.Equal(t, [][]string{{"react:18.2.0", "react-dom:18.2.0", "app:1.0.0"}}, dependencies["loose-envify:1.4.0"].RequestedBy)
+	assert.Equal(t, []string{ProdScope}, dependencies["loose-envify:1.4.0"].Scopes)
+
+	// A project without a package.json is identified by its path. Aliased dependencies are resolved to the real package.
+	moduleId, err = lockfile.ModuleId("packages/lib")
+	require.NoError(t, err)
+	assert.Equal(t, "packages/lib", moduleId)
+	dependencies, err = lockfile.GetDependencies("packages/lib", moduleId)
+	require.NoError(t, err)
+	assert.Len(t, dependencies, 2)
+	assert.Equal(t, [][]string{{"strip-ansi:6.0.1", "packages/lib"}}, dependencies["ansi-regex:5.0.1"].RequestedBy)
+}
+
+func TestLockfileV6(t *testing.T) {
+	lockfile, err := ParseLockfile([]byte(lockfileV6))
+	require.NoError(t, err)
+	dependencies, err := lockfile.GetDependencies(RootImporter, "root")
+	require.NoError(t, err)
+	assert.Len(t, dependencies, 2)
+	assert.ElementsMatch(t, []string{ProdScope, DevScope}, dependencies["b:2.0.0"].Scopes)
+	assert.ElementsMatch(t, [][]string{{"@scope/a:1.0.0", "root"}, {"root"}}, dependencies["b:2.0.0"].RequestedBy)
+	assert.Equal(t, [][]string{{"root"}}, dependencies["@scope/a:1.0.0"].RequestedBy)
+}
+
+func TestLockfileUnsupportedVersion(t *testing.T) {
+	_, err := ParseLockfile([]byte("lockfileVersion: 5.4\n"))
+	assert.ErrorContains(t, err, "not supported")
+}
@@ -0,0 +1,430 @@
+package utils
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	multipartUploadsApi = "api/v1/uploads/"
+
+	multipartStatusQueued         = "QUEUED"
+	multipartStatusProcessing     = "PROCESSING"
+	multipartStatusFinished       = "FINISHED"
+	multipartStatusRetryableError = "RETRYABLE_ERROR"
+
+	multipartStatusPollingInterval = 5 * time.Second
+	// The merge of the parts is polled for up to a week, as the multipart upload of the client does.
+	maxMultipartStatusPollingRetries = int(168 * time.Hour / multipartStatusPollingInterval)
+	// Artifactory keeps the uploaded parts of a multipart upload for a limited time. Older journals are discarded.
+	uploadJournalExpiry = 24 * time.Hour
+)
+
+// Uploads large files in parts using the Artifactory multipart upload API.
+// The parts uploaded so far are recorded in a journal file, so that an interrupted upload continues where it stopped
+// when the same file is uploaded again to the same path.
+type ResumableUploader struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	journalDir      string
+	splitCount      int
+	chunkSize       int64
+	progress        ioutils.ProgressMgr
+}
+
+func NewResumableUploader(servicesManager artifactory.ArtifactoryServicesManager, splitCount int, chunkSize int64) (*ResumableUploader, error) {
+	journalDir, err := coreutils.GetJfrogUploadsJournalDir()
+	if err != nil {
+		return nil, err
+	}
+	if chunkSize <= 0 {
+		chunkSize = servicesutils.DefaultUploadChunkSize
+	}
+	if splitCount <= 0 {
+		splitCount = 1
+	}
+	return &ResumableUploader{servicesManager: servicesManager, journalDir: journalDir, splitCount: splitCount, chunkSize: chunkSize}, nil
+}
+
+func (ru *ResumableUploader) SetJournalDir(journalDir string) *ResumableUploader {
+	ru.journalDir = journalDir
+	return ru
+}
+
+func (ru *ResumableUploader) SetProgress(progress ioutils.ProgressMgr) *ResumableUploader {
+	ru.progress = progress
+	return ru
+}
+
+// Returns true if the Artifactory version and storage support multipart uploads.
+func (ru *ResumableUploader) IsSupported() (bool, error) {
+	serviceDetails := ru.servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	return servicesutils.NewMultipartUpload(ru.servicesManager.Client(), &httpClientDetails, serviceDetails.GetUrl()).IsSupported(serviceDetails)
+}
+
+// The state of a resumable upload.
+type uploadJournal struct {
+	LocalPath  string `json:"localPath"`
+	TargetPath string `json:"targetPath"`
+	Sha256     string `json:"sha256"`
+	Size       int64  `json:"size"`
+	ChunkSize  int64  `json:"chunkSize"`
+	// The token of the multipart upload, which identifies it in the following requests.
+	Token          string    `json:"token"`
+	Created        time.Time `json:"created"`
+	CompletedParts []int64   `json:"completedParts"`
+
+	path  string
+	mutex sync.Mutex
+}
+
+func (uj *uploadJournal) matches(details *fileutils.FileDetails, chunkSize int64) bool {
+	return uj.Sha256 == details.Checksum.Sha256 && uj.Size == details.Size && uj.ChunkSize == chunkSize &&
+		uj.Token != "" && time.Since(uj.Created) < uploadJournalExpiry
+}
+
+func (uj *uploadJournal) completePart(partNumber int64) error {
+	uj.mutex.Lock()
+	defer uj.mutex.Unlock()
+	uj.CompletedParts = append(uj.CompletedParts, partNumber)
+	return uj.save()
+}
+
+func (uj *uploadJournal) save() error {
+	data, err := json.Marshal(uj)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Writing to a temporary file and renaming it keeps the journal valid if the process is killed while writing.
+	tempPath := uj.path + ".tmp"
+	if err = os.WriteFile(tempPath, data, 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.Rename(tempPath, uj.path))
+}
+
+func (uj *uploadJournal) remove() error {
+	if err := os.Remove(uj.path); err != nil && !os.IsNotExist(err) {
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
+
+// The journal of an upload is identified by the Artifactory URL and the target path, so that uploading different content
+// to the same path replaces the previous journal.
+func (ru *ResumableUploader) getJournalPath(targetPath string) string {
+	key := sha256.Sum256([]byte(ru.servicesManager.GetConfig().GetServiceDetails().GetUrl() + targetPath))
+	return filepath.Join(ru.journalDir, hex.EncodeToString(key[:])+".json")
+}
+
+func (ru *ResumableUploader) readJournal(localPath, targetPath string, details *fileutils.FileDetails) (*uploadJournal, error) {
+	journalPath := ru.getJournalPath(targetPath)
+	journal := new(uploadJournal)
+	data, err := os.ReadFile(journalPath)
+	if err == nil && json.Unmarshal(data, journal) == nil && journal.matches(details, ru.chunkSize) {
+		journal.path = journalPath
+		journal.LocalPath = localPath
+		return journal, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errorutils.CheckError(err)
+	}
+	return &uploadJournal{path: journalPath, LocalPath: localPath, TargetPath: targetPath, Sha256: details.Checksum.Sha256, Size: details.Size, ChunkSize: ru.chunkSize}, nil
+}
+
+// Uploads the file to the target path, in the form of 'repo/path/to/file'.
+// The details must include the file size and checksums.
+func (ru *ResumableUploader) Upload(localPath, targetPath string, details *fileutils.FileDetails) (err error) {
+	if err = fileutils.CreateDirIfNotExist(ru.journalDir); err != nil {
+		return err
+	}
+	journal, err := ru.readJournal(localPath, targetPath, details)
+	if err != nil {
+		return err
+	}
+	logMsgPrefix := fmt.Sprintf("[Resumable upload %s] ", targetPath)
+	if journal.Token == "" {
+		if journal.Token, err = ru.createUpload(targetPath); err != nil {
+			return err
+		}
+		journal.Created = time.Now()
+		if err = journal.save(); err != nil {
+			return err
+		}
+	} else {
+		log.Info(fmt.Sprintf("%sResuming the upload, %d parts were already uploaded.", logMsgPrefix, len(journal.CompletedParts)))
+	}
+	tokenClientDetails := ru.createTokenClientDetails(journal.Token)
+
+	var progressReader ioutils.Progress
+	if ru.progress != nil {
+		progressReader = ru.progress.NewProgressReader(details.Size, "Resumable upload", targetPath)
+		defer ru.progress.RemoveProgress(progressReader.GetId())
+	}
+	if err = ru.uploadParts(logMsgPrefix, journal, tokenClientDetails, progressReader); err != nil {
+		// The journal is kept, so that the next upload of the file resumes from the last completed part.
+		return err
+	}
+	log.Info(logMsgPrefix + "Merging the parts...")
+	checksumToken, err := ru.completeUpload(logMsgPrefix, details.Checksum.Sha1, tokenClientDetails)
+	if err != nil {
+		return errors.Join(err, journal.remove())
+	}
+	if err = ru.deployWithChecksumToken(targetPath, details, checksumToken); err != nil {
+		return errors.Join(err, journal.remove())
+	}
+	log.Info(logMsgPrefix + "Upload completed successfully!")
+	return journal.remove()
+}
+
+func (ru *ResumableUploader) createTokenClientDetails(token string) *httputils.HttpClientDetails {
+	httpClientDetails := ru.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	return &httputils.HttpClientDetails{
+		AccessToken:           token,
+		Transport:             httpClientDetails.Transport,
+		DialTimeout:           httpClientDetails.DialTimeout,
+		OverallRequestTimeout: httpClientDetails.OverallRequestTimeout,
+	}
+}
+
+func (ru *ResumableUploader) uploadParts(logMsgPrefix string, journal *uploadJournal, tokenClientDetails *httputils.HttpClientDetails, progressReader ioutils.Progress) error {
+	missingParts := getMissingParts(journal.Size, journal.ChunkSize, journal.CompletedParts)
+	if progressReader != nil {
+		progressReader.SetProgress(journal.Size - getPartsSize(journal.Size, journal.ChunkSize, missingParts))
+	}
+	numberOfParts := (journal.Size + journal.ChunkSize - 1) / journal.ChunkSize
+	log.Info(fmt.Sprintf("%sUploading %d of %d parts, using %d working threads...", logMsgPrefix, len(missingParts), numberOfParts, ru.splitCount))
+	errGroup := new(errgroup.Group)
+	errGroup.SetLimit(ru.splitCount)
+	for _, partNumber := range missingParts {
+		errGroup.Go(func() error {
+			if err := ru.uploadPart(logMsgPrefix, journal, partNumber, tokenClientDetails, progressReader); err != nil {
+				return err
+			}
+			log.Debug(fmt.Sprintf("%sCompleted uploading part %d/%d", logMsgPrefix, partNumber, numberOfParts))
+			return journal.completePart(partNumber)
+		})
+	}
+	return errGroup.Wait()
+}
+
+// Returns the numbers of the parts which weren't uploaded yet. Part numbers start from 1.
+func getMissingParts(fileSize, chunkSize int64, completedParts []int64) []int64 {
+	completed := make(map[int64]bool, len(completedParts))
+	for _, partNumber := range completedParts {
+		completed[partNumber] = true
+	}
+	var missingParts []int64
+	for partNumber := int64(1); (partNumber-1)*chunkSize < fileSize; partNumber++ {
+		if !completed[partNumber] {
+			missingParts = append(missingParts, partNumber)
+		}
+	}
+	return missingParts
+}
+
+func getPartSize(fileSize, chunkSize, partNumber int64) int64 {
+	return min(chunkSize, fileSize-(partNumber-1)*chunkSize)
+}
+
+func getPartsSize(fileSize, chunkSize int64, partNumbers []int64) (size int64) {
+	for _, partNumber := range partNumbers {
+		size += getPartSize(fileSize, chunkSize, partNumber)
+	}
+	return
+}
+
+func (ru *ResumableUploader) uploadPart(logMsgPrefix string, journal *uploadJournal, partNumber int64, tokenClientDetails *httputils.HttpClientDetails, progressReader ioutils.Progress) (err error) {
+	partUrl, err := ru.getPartUrl(logMsgPrefix, partNumber, tokenClientDetails)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(journal.LocalPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	if _, err = file.Seek((partNumber-1)*journal.ChunkSize, io.SeekStart); err != nil {
+		return errorutils.CheckError(err)
+	}
+	partSize := getPartSize(journal.Size, journal.ChunkSize, partNumber)
+	var reader io.Reader = bufio.NewReader(io.LimitReader(file, partSize))
+	if progressReader != nil {
+		reader = progressReader.ActionWithProgress(reader)
+	}
+	resp, body, err := ru.servicesManager.Client().GetHttpClient().UploadFileFromReader(reader, partUrl, httputils.HttpClientDetails{}, partSize)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func (ru *ResumableUploader) createUpload(targetPath string) (string, error) {
+	repoKey, repoPath, _ := strings.Cut(targetPath, "/")
+	serviceDetails := ru.servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	requestUrl := fmt.Sprintf("%s%screate?repoKey=%s&repoPath=%s&partSizeMB=%d", serviceDetails.GetUrl(), multipartUploadsApi,
+		url.QueryEscape(repoKey), url.QueryEscape(repoPath), ru.chunkSize/servicesutils.SizeMiB)
+	resp, body, err := ru.servicesManager.Client().SendPost(requestUrl, []byte{}, &httpClientDetails)
+	if err != nil {
+		return "", err
+	}
+	// The response body isn't logged, because it includes credentials.
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return "", err
+	}
+	var response struct {
+		Token string `json:"token,omitempty"`
+	}
+	return response.Token, errorutils.CheckError(json.Unmarshal(body, &response))
+}
+
+func (ru *ResumableUploader) getPartUrl(logMsgPrefix string, partNumber int64, tokenClientDetails *httputils.HttpClientDetails) (string, error) {
+	requestUrl := fmt.Sprintf("%s%surlPart?partNumber=%d", ru.getArtifactoryUrl(), multipartUploadsApi, partNumber)
+	resp, body, err := ru.servicesManager.Client().GetHttpClient().SendPost(requestUrl, []byte{}, *tokenClientDetails, logMsgPrefix)
+	if err != nil {
+		return "", err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return "", err
+	}
+	var response struct {
+		Url string `json:"url,omitempty"`
+	}
+	return response.Url, errorutils.CheckError(json.Unmarshal(body, &response))
+}
+
+// Merges the parts and waits for the merge to finish. Returns the token to deploy the merged file with.
+// The parts are merged again after a retryable error, up to the number of HTTP retries of the services manager.
+func (ru *ResumableUploader) completeUpload(logMsgPrefix, sha1 string, tokenClientDetails *httputils.HttpClientDetails) (string, error) {
+	completeUrl := fmt.Sprintf("%s%scomplete?sha1=%s", ru.getArtifactoryUrl(), multipartUploadsApi, sha1)
+	completionAttempts := ru.servicesManager.GetConfig().GetHttpRetries() + 1
+	for attempt := 1; ; attempt++ {
+		resp, body, err := ru.servicesManager.Client().GetHttpClient().SendPost(completeUrl, []byte{}, *tokenClientDetails, logMsgPrefix)
+		if err != nil {
+			return "", err
+		}
+		if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusAccepted); err != nil {
+			return "", err
+		}
+		checksumToken, retryableErr, err := ru.pollUploadStatus(logMsgPrefix, tokenClientDetails)
+		if err != nil || retryableErr == "" {
+			return checksumToken, err
+		}
+		if attempt >= completionAttempts {
+			return "", errorutils.CheckErrorf("%smerging the parts failed after %d attempts: %s", logMsgPrefix, attempt, retryableErr)
+		}
+		log.Warn(fmt.Sprintf("%sMerging the parts failed: %s. Retrying...", logMsgPrefix, retryableErr))
+	}
+}
+
+// Polls the status of the merge until it finishes. Returns the checksum token, or the error message if the merge failed with a retryable error.
+func (ru *ResumableUploader) pollUploadStatus(logMsgPrefix string, tokenClientDetails *httputils.HttpClientDetails) (checksumToken, retryableErr string, err error) {
+	statusUrl := fmt.Sprintf("%s%sstatus", ru.getArtifactoryUrl(), multipartUploadsApi)
+	pollingExecutor := &clientutils.RetryExecutor{
+		MaxRetries:               maxMultipartStatusPollingRetries,
+		RetriesIntervalMilliSecs: int(multipartStatusPollingInterval.Milliseconds()),
+		ErrorMessage:             "Waiting for the parts to be merged...",
+		LogMsgPrefix:             logMsgPrefix,
+		ExecutionHandler: func() (bool, error) {
+			resp, body, err := ru.servicesManager.Client().GetHttpClient().SendPost(statusUrl, []byte{}, *tokenClientDetails, logMsgPrefix)
+			if err != nil {
+				return false, err
+			}
+			if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+				return false, err
+			}
+			var status struct {
+				Status        string `json:"status,omitempty"`
+				Error         string `json:"error,omitempty"`
+				ChecksumToken string `json:"checksumToken,omitempty"`
+			}
+			if err = json.Unmarshal(body, &status); err != nil {
+				return false, errorutils.CheckError(err)
+			}
+			switch status.Status {
+			case multipartStatusQueued, multipartStatusProcessing:
+				return true, nil
+			case multipartStatusFinished:
+				checksumToken = status.ChecksumToken
+				return false, nil
+			case multipartStatusRetryableError:
+				retryableErr = status.Error
+				return false, nil
+			default:
+				return false, errorutils.CheckErrorf("%smerging the parts failed with status '%s': %s", logMsgPrefix, status.Status, status.Error)
+			}
+		},
+	}
+	err = pollingExecutor.Execute()
+	return
+}
+
+// Deploys the merged file to the target path, without its properties. They are set by the checksum deploy which follows.
+func (ru *ResumableUploader) deployWithChecksumToken(targetPath string, details *fileutils.FileDetails, checksumToken string) error {
+	targetUrl, err := clientutils.BuildUrl(ru.getArtifactoryUrl(), targetPath, make(map[string]string))
+	if err != nil {
+		return err
+	}
+	httpClientDetails := ru.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	httpClientDetails.AddHeader("X-Checksum-Deploy", "true")
+	servicesutils.AddChecksumHeaders(httpClientDetails.Headers, details)
+	servicesutils.AddChecksumTokenHeader(httpClientDetails.Headers, checksumToken)
+	resp, body, err := ru.servicesManager.Client().SendPut(targetUrl, nil, &httpClientDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusCreated, http.StatusOK)
+}
+
+func (ru *ResumableUploader) getArtifactoryUrl() string {
+	return ru.servicesManager.GetConfig().GetServiceDetails().GetUrl()
+}
+
+// Removes the journals of uploads which can no longer be resumed.
+func (ru *ResumableUploader) RemoveExpiredJournals() error {
+	entries, err := os.ReadDir(ru.journalDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if time.Since(info.ModTime()) > uploadJournalExpiry {
+			if err = os.Remove(filepath.Join(ru.journalDir, entry.Name())); err != nil {
+				return errorutils.CheckError(err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	clientUtils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMissingParts(t *testing.T) {
+	assert.Equal(t, []int64{1, 2, 3}, getMissingParts(25, 10, nil))
+	assert.Equal(t, []int64{2}, getMissingParts(25, 10, []int64{3, 1}))
+	assert.Empty(t, getMissingParts(20, 10, []int64{1, 2}))
+	assert.Equal(t, int64(5), getPartSize(25, 10, 3))
+	assert.Equal(t, int64(15), getPartsSize(25, 10, []int64{2, 3}))
+}
+
+// A mock of the Artifactory multipart upload API, which fails uploading a part once.
+type mockMultipartServer struct {
+	*httptest.Server
+	failPart      string
+	mutex         sync.Mutex
+	uploadedParts map[string][]byte
+	createCount   int
+	deployedPath  string
+}
+
+func newMockMultipartServer(t *testing.T, failPart string) *mockMultipartServer {
+	server := &mockMultipartServer{failPart: failPart, uploadedParts: make(map[string][]byte)}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/create"):
+			server.createCount++
+			_, err := w.Write([]byte(`{"token":"upload-token"}`))
+			assert.NoError(t, err)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/urlPart"):
+			assert.Equal(t, "Bearer upload-token", r.Header.Get("Authorization"))
+			_, err := fmt.Fprintf(w, `{"url":"%s/parts/%s"}`, server.URL, r.URL.Query().Get("partNumber"))
+			assert.NoError(t, err)
+		case strings.HasPrefix(r.URL.Path, "/parts/"):
+			partNumber := strings.TrimPrefix(r.URL.Path, "/parts/")
+			if partNumber == server.failPart {
+				server.failPart = ""
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			content, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			server.uploadedParts[partNumber] = content
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/complete"):
+			w.WriteHeader(http.StatusAccepted)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/status"):
+			_, err := w.Write([]byte(`{"status":"FINISHED","checksumToken":"checksum-token"}`))
+			assert.NoError(t, err)
+		case r.Method == http.MethodPut:
+			assert.Equal(t, "checksum-token", r.Header.Get("X-Checksum-Deploy-Token"))
+			server.deployedPath = r.URL.Path
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestResumableUpload(t *testing.T) {
+	server := newMockMultipartServer(t, "2")
+	defer server.Close()
+
+	// A file of 3 parts, the last of them smaller.
+	content := bytes.Repeat([]byte("0123456789abcdef"), int(clientUtils.SizeMiB*5/2/16))
+	localPath := filepath.Join(t.TempDir(), "file.bin")
+	require.NoError(t, os.WriteFile(localPath, content, 0600))
+	details, err := fileutils.GetFileDetails(localPath, true)
+	require.NoError(t, err)
+
+	servicesManager, err := CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 0, 0, false)
+	require.NoError(t, err)
+	uploader, err := NewResumableUploader(servicesManager, 1, clientUtils.SizeMiB)
+	require.NoError(t, err)
+	journalDir := t.TempDir()
+	uploader.SetJournalDir(journalDir)
+
+	// The second part fails, and the journal keeps the parts which were uploaded.
+	assert.Error(t, uploader.Upload(localPath, "repo/dir/file.bin", details))
+	assert.Len(t, server.uploadedParts, 2)
+	journal, err := uploader.readJournal(localPath, "repo/dir/file.bin", details)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 3}, journal.CompletedParts)
+
+	// The upload is resumed, uploading only the missing part.
+	delete(server.uploadedParts, "1")
+	delete(server.uploadedParts, "3")
+	require.NoError(t, uploader.Upload(localPath, "repo/dir/file.bin", details))
+	assert.Equal(t, 1, server.createCount)
+	assert.Equal(t, []string{"2"}, getKeys(server.uploadedParts))
+	assert.Equal(t, content[clientUtils.SizeMiB:2*clientUtils.SizeMiB], server.uploadedParts["2"])
+	assert.Equal(t, "/repo/dir/file.bin", server.deployedPath)
+
+	// The journal is removed once the upload completes.
+	entries, err := os.ReadDir(journalDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestResumableUploadCompleteRetries(t *testing.T) {
+	completeCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/complete"):
+			completeCount++
+			w.WriteHeader(http.StatusAccepted)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/uploads/status"):
+			_, err := w.Write([]byte(`{"status":"RETRYABLE_ERROR","error":"merge failed"}`))
+			assert.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	servicesManager, err := CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: server.URL + "/"}, 2, 0, false)
+	require.NoError(t, err)
+	uploader, err := NewResumableUploader(servicesManager, 1, clientUtils.SizeMiB)
+	require.NoError(t, err)
+	tokenClientDetails := servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+
+	// The parts are merged again after each retryable error, up to the number of retries.
+	_, err = uploader.completeUpload("", "sha1", &tokenClientDetails)
+	assert.ErrorContains(t, err, "merging the parts failed after 3 attempts: merge failed")
+	assert.Equal(t, 3, completeCount)
+}
+
+func TestResumableUploadJournalMismatch(t *testing.T) {
+	servicesManager, err := CreateServiceManager(&config.ServerDetails{ArtifactoryUrl: "http://localhost/"}, 0, 0, false)
+	require.NoError(t, err)
+	uploader, err := NewResumableUploader(servicesManager, 1, clientUtils.SizeMiB)
+	require.NoError(t, err)
+	uploader.SetJournalDir(t.TempDir())
+
+	details := &fileutils.FileDetails{Size: 100}
+	details.Checksum.Sha256 = "old"
+	journal, err := uploader.readJournal("file", "repo/file", details)
+	require.NoError(t, err)
+	journal.Token = "token"
+	journal.Created = time.Now()
+	journal.CompletedParts = []int64{1}
+	require.NoError(t, journal.save())
+
+	journal, err = uploader.readJournal("file", "repo/file", details)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1}, journal.CompletedParts)
+
+	// An expired journal isn't resumed.
+	journal.Created = time.Now().Add(-uploadJournalExpiry)
+	require.NoError(t, journal.save())
+	journal, err = uploader.readJournal("file", "repo/file", details)
+	require.NoError(t, err)
+	assert.Empty(t, journal.Token)
+
+	// A journal of different content isn't resumed.
+	details.Checksum.Sha256 = "new"
+	journal, err = uploader.readJournal("file", "repo/file", details)
+	require.NoError(t, err)
+	assert.Empty(t, journal.Token)
+	assert.Empty(t, journal.CompletedParts)
+}
+
+func getKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
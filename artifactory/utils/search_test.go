@@ -7,6 +7,7 @@ import (
 
 	corelog "github.com/jfrog/jfrog-cli-core/v2/utils/log"
 
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
 	"github.com/jfrog/jfrog-client-go/utils/io/content"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +37,103 @@ func TestPrintSearchResults(t *testing.T) {
 	assert.Equal(t, 0, compareResult)
 }
 
+func captureSearchOutput(t *testing.T, options SearchOutputOptions) string {
+	testdataPath, err := GetTestDataPath()
+	assert.NoError(t, err)
+	reader := content.NewContentReader(filepath.Join(testdataPath, "search_results.json"), content.DefaultKey)
+
+	previousLog := log.Logger
+	newLog := log.NewLogger(corelog.GetCliLogLevel(), nil)
+	defer log.SetLogger(previousLog)
+	buffer := &bytes.Buffer{}
+	newLog.SetOutputWriter(buffer)
+	log.SetLogger(newLog)
+
+	assert.NoError(t, PrintSearchResultsWithOptions(reader, options))
+	return buffer.String()
+}
+
+func TestPrintSearchResultsWithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  SearchOutputOptions
+		expected string
+	}{
+		{"json", SearchOutputOptions{}, expectedLogOutput},
+		{"jsonFields", SearchOutputOptions{Format: format.Json, Fields: []string{"path", "size", "props.a"}, SortBy: "path"}, `[
+  {
+    "path": "jfrog-cli-tests-repo1-1595270324/a/a3.in",
+    "size": 7,
+    "props.a": [
+      "1"
+    ]
+  },
+  {
+    "path": "jfrog-cli-tests-repo1-1595270324/a/b/b2.in",
+    "size": 9,
+    "props.a": null
+  },
+  {
+    "path": "jfrog-cli-tests-repo1-1595270324/a/b/b3.in",
+    "size": 9,
+    "props.a": [
+      "1"
+    ]
+  },
+  {
+    "path": "jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in",
+    "size": 11,
+    "props.a": null
+  },
+  {
+    "path": "jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in",
+    "size": 11,
+    "props.a": null
+  }
+]
+`},
+		{"ndjson", SearchOutputOptions{Format: format.Ndjson, Fields: []string{"size", "path"}, SortBy: "size", Descending: true}, `{"size":11,"path":"jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in"}
+{"size":11,"path":"jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in"}
+{"size":9,"path":"jfrog-cli-tests-repo1-1595270324/a/b/b3.in"}
+{"size":9,"path":"jfrog-cli-tests-repo1-1595270324/a/b/b2.in"}
+{"size":7,"path":"jfrog-cli-tests-repo1-1595270324/a/a3.in"}
+`},
+		{"csv", SearchOutputOptions{Format: format.Csv, Fields: []string{"path", "md5", "props"}}, `path,md5,props
+jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in,82b6d565393a3fd1cc4778b1d53c0664,c=3
+jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in,d8020b86244956f647cf1beff5acdb90,c=3
+jfrog-cli-tests-repo1-1595270324/a/b/b2.in,6931271be1e5f98e36bdc7a05097407b,b=1;c=3
+jfrog-cli-tests-repo1-1595270324/a/b/b3.in,305b21db102cf3a3d2d8c3f7e9584dba,a=1;b=2;c=3
+jfrog-cli-tests-repo1-1595270324/a/a3.in,73c046196302ff7218d47046cf3c0501,a=1;b=3;c=3
+`},
+		{"table", SearchOutputOptions{Format: format.Table, Fields: []string{"path", "props.b"}, SortBy: "props.b"}, `┌──────────────────────────────────────────────┬─────────┐
+│ PATH                                         │ PROPS.B │
+├──────────────────────────────────────────────┼─────────┤
+│ jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in │         │
+│ jfrog-cli-tests-repo1-1595270324/a/b/c/c3.in │         │
+│ jfrog-cli-tests-repo1-1595270324/a/b/b2.in   │ 1       │
+│ jfrog-cli-tests-repo1-1595270324/a/b/b3.in   │ 2       │
+│ jfrog-cli-tests-repo1-1595270324/a/a3.in     │ 3       │
+└──────────────────────────────────────────────┴─────────┘
+`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, captureSearchOutput(t, test.options))
+		})
+	}
+}
+
+func TestParseSearchFields(t *testing.T) {
+	fields, err := ParseSearchFields("path, size,props.build.name")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"path", "size", "props.build.name"}, fields)
+
+	_, err = ParseSearchFields("path,checksum")
+	assert.ErrorContains(t, err, "unknown search result field 'checksum'")
+	_, err = ParseSearchFields("props.")
+	assert.ErrorContains(t, err, "unknown search result field 'props.'")
+}
+
 const expectedLogOutput = `[
   {
     "path": "jfrog-cli-tests-repo1-1595270324/a/b/c/c2.in",
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	ioutils "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The maximum number of paths in a single AQL query.
+const searchByPathsBatchSize = 100
+
+// Returns an AQL criterion which matches exactly the item of the path, in the form of 'repo/path/to/item'.
+// Unlike a pattern, the wildcard characters in the path match only themselves.
+func GetItemPathCriterion(itemPath string) map[string]string {
+	repo, relativePath, _ := strings.Cut(itemPath, "/")
+	dir, name := path.Split(relativePath)
+	dir = strings.TrimSuffix(dir, "/")
+	if dir == "" {
+		dir = "."
+	}
+	return map[string]string{"repo": repo, "path": dir, "name": name}
+}
+
+// Searches items by their paths, in the form of 'repo/path/to/item', in batches.
+// The items should also match the criterion, if it isn't empty. Only files are searched, unless the criterion has a type.
+// Returns the items by their paths, without a trailing slash for folders.
+func SearchItemsByPaths(servicesManager artifactory.ArtifactoryServicesManager, itemPaths []string, criterion map[string]any, include ...string) (map[string]*utils.ResultItem, error) {
+	pathsByRepo := make(map[string][]string)
+	for _, itemPath := range itemPaths {
+		repo, _, _ := strings.Cut(itemPath, "/")
+		pathsByRepo[repo] = append(pathsByRepo[repo], itemPath)
+	}
+	repos := make([]string, 0, len(pathsByRepo))
+	for repo := range pathsByRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	items := make(map[string]*utils.ResultItem)
+	for _, repo := range repos {
+		repoPaths := pathsByRepo[repo]
+		for start := 0; start < len(repoPaths); start += searchByPathsBatchSize {
+			results, err := searchItemsByPathsBatch(servicesManager, repo, repoPaths[start:min(start+searchByPathsBatchSize, len(repoPaths))], criterion, include...)
+			if err != nil {
+				return nil, err
+			}
+			for _, result := range results {
+				items[strings.TrimSuffix(result.GetItemRelativePath(), "/")] = result
+			}
+		}
+	}
+	return items, nil
+}
+
+func searchItemsByPathsBatch(servicesManager artifactory.ArtifactoryServicesManager, repo string, itemPaths []string, criterion map[string]any, include ...string) (results []*utils.ResultItem, err error) {
+	pathsCriteria := make([]map[string]string, len(itemPaths))
+	for i, itemPath := range itemPaths {
+		pathsCriteria[i] = GetItemPathCriterion(itemPath)
+		delete(pathsCriteria[i], "repo")
+	}
+	var query any = map[string]any{"repo": repo, "$or": pathsCriteria}
+	if len(criterion) > 0 {
+		query = map[string]any{"$and": []any{query, criterion}}
+	}
+	queryJson, err := json.Marshal(query)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	fields, err := json.Marshal(append([]string{"repo", "path", "name"}, include...))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	stream, err := servicesManager.Aql(fmt.Sprintf(`items.find(%s).include(%s)`, queryJson, strings.Trim(string(fields), "[]")))
+	if err != nil {
+		return nil, err
+	}
+	defer ioutils.Close(stream, &err)
+	parsedResult := new(struct {
+		Results []*utils.ResultItem `json:"results,omitempty"`
+	})
+	if err = json.NewDecoder(stream).Decode(parsedResult); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parsedResult.Results, nil
+}
@@ -0,0 +1,306 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	propsField       = "props"
+	propsFieldPrefix = propsField + "."
+)
+
+// The output formats of the search results.
+var SearchOutputFormats = []format.OutputFormat{format.Json, format.Table, format.Csv, format.Ndjson}
+
+// The fields printed in the table and CSV formats, when no fields are selected.
+var defaultTabularSearchFields = []string{"path", "type", "size", "modified", "sha1", "sha256", "md5"}
+
+// The fields of a search result, by their names in the JSON output.
+var searchResultFields = map[string]func(result *SearchResult) any{
+	"path":          func(result *SearchResult) any { return result.Path },
+	"type":          func(result *SearchResult) any { return result.Type },
+	"size":          func(result *SearchResult) any { return result.Size },
+	"created":       func(result *SearchResult) any { return result.Created },
+	"modified":      func(result *SearchResult) any { return result.Modified },
+	"sha1":          func(result *SearchResult) any { return result.Sha1 },
+	"sha256":        func(result *SearchResult) any { return result.Sha256 },
+	"md5":           func(result *SearchResult) any { return result.Md5 },
+	"original_md5":  func(result *SearchResult) any { return result.OriginalMd5 },
+	"modified_by":   func(result *SearchResult) any { return result.ModifiedBy },
+	"updated":       func(result *SearchResult) any { return result.Updated },
+	"created_by":    func(result *SearchResult) any { return result.CreatedBy },
+	"original_sha1": func(result *SearchResult) any { return result.OriginalSha1 },
+	"depth":         func(result *SearchResult) any { return result.Depth },
+	propsField:      func(result *SearchResult) any { return result.Props },
+}
+
+type SearchOutputOptions struct {
+	Format format.OutputFormat
+	// The fields to print, for example 'path', 'sha256' or 'props.build.name'. All the fields are printed if empty.
+	Fields []string
+	// The field to sort the results by. The results are printed in the order they were found if empty.
+	SortBy     string
+	Descending bool
+}
+
+// Parses a comma separated list of fields, such as 'path,size,props.build.name'.
+func ParseSearchFields(fields string) ([]string, error) {
+	if fields == "" {
+		return nil, nil
+	}
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if err := validateSearchField(field); err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, field)
+	}
+	return parsed, nil
+}
+
+func validateSearchField(field string) error {
+	if _, ok := searchResultFields[field]; ok || (strings.HasPrefix(field, propsFieldPrefix) && len(field) > len(propsFieldPrefix)) {
+		return nil
+	}
+	fieldNames := make([]string, 0, len(searchResultFields))
+	for name := range searchResultFields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+	return errorutils.CheckErrorf("unknown search result field '%s'. The supported fields are: %s, and '%s<property key>'", field, strings.Join(fieldNames, ", "), propsFieldPrefix)
+}
+
+// Prints the search results in the requested format.
+// The results are streamed from the reader, except for the table format, which is rendered once all the results are read.
+func PrintSearchResultsWithOptions(reader *content.ContentReader, options SearchOutputOptions) (err error) {
+	for _, field := range append([]string{options.SortBy}, options.Fields...) {
+		if field != "" {
+			if err = validateSearchField(field); err != nil {
+				return err
+			}
+		}
+	}
+	if options.SortBy != "" {
+		if reader, err = sortSearchResults(reader, options.SortBy, options.Descending); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, reader.Close())
+		}()
+	}
+	switch options.Format {
+	case format.Json, "":
+		if len(options.Fields) == 0 {
+			return PrintSearchResults(reader)
+		}
+		return printSearchResultsJsonArray(reader, options.Fields)
+	case format.Ndjson:
+		return printSearchResultsNdjson(reader, options.Fields)
+	case format.Csv:
+		return printSearchResultsCsv(reader, getTabularSearchFields(options.Fields))
+	case format.Table:
+		return printSearchResultsTable(reader, getTabularSearchFields(options.Fields))
+	default:
+		return errorutils.CheckErrorf("unsupported search output format '%s'", options.Format)
+	}
+}
+
+func getTabularSearchFields(fields []string) []string {
+	if len(fields) == 0 {
+		return defaultTabularSearchFields
+	}
+	return fields
+}
+
+func printSearchResultsJsonArray(reader *content.ContentReader, fields []string) error {
+	log.Output("[")
+	previous := ""
+	for searchResult := new(SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(SearchResult) {
+		data, err := marshalSearchResultFields(searchResult, fields)
+		if err != nil {
+			return err
+		}
+		// Each result is printed once the next one is read, to know whether a comma should follow it.
+		if previous != "" {
+			log.Output("  " + previous + ",")
+		}
+		previous = clientutils.IndentJsonArray(data)
+	}
+	if previous != "" {
+		log.Output("  " + previous)
+	}
+	log.Output("]")
+	return resetSearchResultsReader(reader)
+}
+
+func printSearchResultsNdjson(reader *content.ContentReader, fields []string) error {
+	for searchResult := new(SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(SearchResult) {
+		var data []byte
+		var err error
+		if len(fields) == 0 {
+			data, err = json.Marshal(searchResult)
+			err = errorutils.CheckError(err)
+		} else {
+			data, err = marshalSearchResultFields(searchResult, fields)
+		}
+		if err != nil {
+			return err
+		}
+		log.Output(string(data))
+	}
+	return resetSearchResultsReader(reader)
+}
+
+func printSearchResultsCsv(reader *content.ContentReader, fields []string) error {
+	if err := printCsvRecord(fields); err != nil {
+		return err
+	}
+	for searchResult := new(SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(SearchResult) {
+		if err := printCsvRecord(getSearchResultStrings(searchResult, fields)); err != nil {
+			return err
+		}
+	}
+	return resetSearchResultsReader(reader)
+}
+
+func printCsvRecord(record []string) error {
+	csvRecord, err := coreutils.ToCsvRecord(record...)
+	if err != nil {
+		return err
+	}
+	log.Output(csvRecord)
+	return nil
+}
+
+func printSearchResultsTable(reader *content.ContentReader, fields []string) error {
+	tableWriter := table.NewWriter()
+	tableWriter.SetStyle(table.StyleLight)
+	header := make(table.Row, len(fields))
+	for i, field := range fields {
+		header[i] = field
+	}
+	tableWriter.AppendHeader(header)
+	for searchResult := new(SearchResult); reader.NextRecord(searchResult) == nil; searchResult = new(SearchResult) {
+		values := getSearchResultStrings(searchResult, fields)
+		row := make(table.Row, len(values))
+		for i, value := range values {
+			row[i] = value
+		}
+		tableWriter.AppendRow(row)
+	}
+	if err := resetSearchResultsReader(reader); err != nil {
+		return err
+	}
+	log.Output(tableWriter.Render())
+	return nil
+}
+
+func resetSearchResultsReader(reader *content.ContentReader) error {
+	if err := reader.GetError(); err != nil {
+		return err
+	}
+	reader.Reset()
+	return nil
+}
+
+// Marshals the selected fields of the search result into a JSON object, keeping the order of the fields.
+func marshalSearchResultFields(searchResult *SearchResult, fields []string) ([]byte, error) {
+	buffer := bytes.NewBufferString("{")
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		key, err := json.Marshal(field)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		value, err := json.Marshal(getSearchResultField(searchResult, field))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+// Returns the value of a field of the search result. The values of a property are returned for 'props.<key>' fields.
+func getSearchResultField(searchResult *SearchResult, field string) any {
+	if getField, ok := searchResultFields[field]; ok {
+		return getField(searchResult)
+	}
+	return searchResult.Props[strings.TrimPrefix(field, propsFieldPrefix)]
+}
+
+func getSearchResultStrings(searchResult *SearchResult, fields []string) []string {
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = searchFieldToString(getSearchResultField(searchResult, field))
+	}
+	return values
+}
+
+// Formats a field value for the table and CSV formats. Properties are formatted as in Artifactory: 'key1=value1,value2;key2=value3'.
+func searchFieldToString(value any) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case int64:
+		return strconv.FormatInt(typedValue, 10)
+	case int:
+		return strconv.Itoa(typedValue)
+	case []string:
+		return strings.Join(typedValue, ",")
+	case map[string][]string:
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		props := make([]string, len(keys))
+		for i, key := range keys {
+			props[i] = key + "=" + strings.Join(typedValue[key], ",")
+		}
+		return strings.Join(props, ";")
+	}
+	return fmt.Sprint(value)
+}
+
+// Sorts the search results by a field, without holding all of them in memory.
+// Numeric fields are compared as numbers, and results with equal values are sorted by their paths.
+func sortSearchResults(reader *content.ContentReader, field string, descending bool) (*content.ContentReader, error) {
+	getSortKey := func(record any) (string, error) {
+		searchResult := new(SearchResult)
+		if err := content.ConvertToStruct(record, searchResult); err != nil {
+			return "", err
+		}
+		var key string
+		switch value := getSearchResultField(searchResult, field).(type) {
+		case int64:
+			key = fmt.Sprintf("%020d", value)
+		case int:
+			key = fmt.Sprintf("%020d", value)
+		default:
+			key = searchFieldToString(value)
+		}
+		return key + "\x00" + searchResult.Path, nil
+	}
+	return content.SortContentReaderByCalculatedKey(reader, getSortKey, !descending)
+}
@@ -23,6 +23,8 @@ type UploadConfiguration struct {
 	SplitCount            int
 	MinSplitSizeMB        int64
 	ChunkSizeMB           int64
+	// Skips sending the content of files which already exist in Artifactory, and uploads the large files in resumable parts.
+	Deduplicate bool
 }
 
 func GetMinChecksumDeploySize() (int64, error) {
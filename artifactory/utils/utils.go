@@ -29,6 +29,7 @@ import (
 	clientUtils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	ioUtils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
 func GetProjectDir(global bool) (string, error) {
@@ -332,3 +333,16 @@ func GetRtMajorVersion(servicesManager artifactory.ArtifactoryServicesManager) (
 	majorVersion, err := strconv.Atoi(artVersionSlice[0])
 	return majorVersion, errorutils.CheckError(err)
 }
+
+// Returns false if no HTTP response could be received from Artifactory, for example when the server is down or there is no network connection.
+// An HTTP error response means that Artifactory is reachable.
+func IsArtifactoryReachable(servicesManager artifactory.ArtifactoryServicesManager) bool {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	resp, _, _, err := servicesManager.Client().SendGet(serviceDetails.GetUrl()+"api/system/ping", true, &httpClientDetails)
+	if err != nil && resp == nil {
+		log.Debug("Artifactory is unreachable:", err.Error())
+		return false
+	}
+	return true
+}
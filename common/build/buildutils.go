@@ -58,7 +58,9 @@ func PrepareBuildPrerequisites(buildConfiguration *BuildConfiguration) (build *b
 		build, err = buildInfoService.GetOrCreateBuildWithProject(buildName, buildNumber, projectKey)
 		if err != nil {
 			err = errorutils.CheckError(err)
+			return
 		}
+		err = SaveBuildIdentity(buildName, buildNumber, projectKey)
 	}
 
 	return
@@ -93,11 +95,16 @@ func getPartialsBuildDir(buildName, buildNumber, projectKey string) (string, err
 		return "", err
 	}
 	buildDir = filepath.Join(buildDir, "partials")
+	exists, err := fileutils.IsDirExists(buildDir, false)
+	if err != nil || exists {
+		return buildDir, err
+	}
 	err = os.MkdirAll(buildDir, 0777)
 	if errorutils.CheckError(err) != nil {
 		return "", err
 	}
-	return buildDir, nil
+	// The identity of the build is saved once, when its partials directory is created.
+	return buildDir, writeBuildIdentity(buildDir, buildName, buildNumber, projectKey)
 }
 
 func saveBuildData(action interface{}, buildName, buildNumber, projectKey string) (err error) {
@@ -0,0 +1,298 @@
+package build
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/stringutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+type GraphFormat string
+
+const (
+	TreeGraphFormat    GraphFormat = "tree"
+	DotGraphFormat     GraphFormat = "dot"
+	MermaidGraphFormat GraphFormat = "mermaid"
+
+	// Marks a dependency in the tree, whose dependencies were already printed above.
+	repeatedNodeMarker = " (*)"
+	cycleNodeMarker    = " (cycle)"
+)
+
+var GraphFormats = []string{string(TreeGraphFormat), string(DotGraphFormat), string(MermaidGraphFormat)}
+
+func GetGraphFormat(graphFormat string) (GraphFormat, error) {
+	switch GraphFormat(strings.ToLower(graphFormat)) {
+	case "", TreeGraphFormat:
+		return TreeGraphFormat, nil
+	case DotGraphFormat:
+		return DotGraphFormat, nil
+	case MermaidGraphFormat:
+		return MermaidGraphFormat, nil
+	}
+	return "", errorutils.CheckErrorf("unsupported graph format '%s'. Supported formats: %s", graphFormat, strings.Join(GraphFormats, ", "))
+}
+
+// The dependency graph of a build, built from the 'requestedBy' paths of the build-info dependencies.
+type DependencyGraph struct {
+	Name    string
+	Modules []*ModuleGraph
+}
+
+type ModuleGraph struct {
+	Id string
+	// Maps each node (the module or a dependency) to the dependencies it requested.
+	children map[string][]string
+	// Maps each dependency to the nodes which requested it.
+	parents map[string][]string
+}
+
+type DependencyGraphFilter struct {
+	// A wildcard pattern of the modules to include.
+	Module string
+	// Include only dependencies with one of these scopes.
+	Scopes []string
+}
+
+func NewDependencyGraph(bi *buildInfo.BuildInfo, filter DependencyGraphFilter) (*DependencyGraph, error) {
+	graph := &DependencyGraph{Name: bi.Name + "/" + bi.Number}
+	for _, module := range bi.Modules {
+		if filter.Module != "" {
+			matched, err := stringutils.MatchWildcardPattern(filter.Module, module.Id)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		graph.Modules = append(graph.Modules, newModuleGraph(module, filter.Scopes))
+	}
+	return graph, nil
+}
+
+func newModuleGraph(module buildInfo.Module, scopes []string) *ModuleGraph {
+	moduleGraph := &ModuleGraph{Id: module.Id, children: make(map[string][]string), parents: make(map[string][]string)}
+	included := make(map[string]bool)
+	for _, dependency := range module.Dependencies {
+		if hasScope(dependency, scopes) {
+			included[dependency.Id] = true
+		}
+	}
+	for _, dependency := range module.Dependencies {
+		if !included[dependency.Id] {
+			continue
+		}
+		direct := len(dependency.RequestedBy) == 0
+		for _, path := range dependency.RequestedBy {
+			// Each path lists the requesting dependencies, from the direct parent up to the module itself.
+			// If the path doesn't end with the module, its last dependency is a direct dependency of the module.
+			child := dependency.Id
+			reachedModule := false
+			for _, parent := range path {
+				if parent == module.Id {
+					moduleGraph.addEdge(module.Id, child)
+					reachedModule = true
+					break
+				}
+				if !included[parent] {
+					// The parent was filtered out, so the dependency is attached to the module.
+					break
+				}
+				moduleGraph.addEdge(parent, child)
+				child = parent
+			}
+			if !reachedModule {
+				moduleGraph.addEdge(module.Id, child)
+			}
+		}
+		if direct {
+			moduleGraph.addEdge(module.Id, dependency.Id)
+		}
+	}
+	for node := range moduleGraph.children {
+		sort.Strings(moduleGraph.children[node])
+	}
+	return moduleGraph
+}
+
+func hasScope(dependency buildInfo.Dependency, scopes []string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+	for _, scope := range scopes {
+		for _, dependencyScope := range dependency.Scopes {
+			if strings.EqualFold(scope, dependencyScope) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (mg *ModuleGraph) addEdge(parent, child string) {
+	for _, existing := range mg.children[parent] {
+		if existing == child {
+			return
+		}
+	}
+	mg.children[parent] = append(mg.children[parent], child)
+	mg.parents[child] = append(mg.parents[child], parent)
+}
+
+func (mg *ModuleGraph) Children(node string) []string {
+	return mg.children[node]
+}
+
+// Returns every path from the module to the dependencies matching the wildcard pattern.
+// Each path starts with the module and ends with the matching dependency.
+func (mg *ModuleGraph) PathsTo(dependencyPattern string) ([][]string, error) {
+	var paths [][]string
+	var dependencies []string
+	for dependency := range mg.parents {
+		matched, err := stringutils.MatchWildcardPattern(dependencyPattern, dependency)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	sort.Strings(dependencies)
+	for _, dependency := range dependencies {
+		paths = append(paths, mg.pathsFromModule(dependency, map[string]bool{})...)
+	}
+	return paths, nil
+}
+
+func (mg *ModuleGraph) pathsFromModule(node string, visiting map[string]bool) (paths [][]string) {
+	if node == mg.Id {
+		return [][]string{{node}}
+	}
+	if visiting[node] {
+		return nil
+	}
+	visiting[node] = true
+	defer delete(visiting, node)
+	parents := append([]string{}, mg.parents[node]...)
+	sort.Strings(parents)
+	for _, parent := range parents {
+		for _, path := range mg.pathsFromModule(parent, visiting) {
+			paths = append(paths, append(path, node))
+		}
+	}
+	return
+}
+
+func (dg *DependencyGraph) Render(graphFormat GraphFormat) string {
+	switch graphFormat {
+	case DotGraphFormat:
+		return dg.ToDot()
+	case MermaidGraphFormat:
+		return dg.ToMermaid()
+	default:
+		return dg.ToTree()
+	}
+}
+
+// Renders the graph as an indented tree. Dependencies which appear more than once are expanded only the first time.
+func (dg *DependencyGraph) ToTree() string {
+	var tree strings.Builder
+	for _, module := range dg.Modules {
+		tree.WriteString(module.Id + "\n")
+		expanded := make(map[string]bool)
+		module.writeTree(&tree, module.Id, "", expanded, map[string]bool{module.Id: true})
+	}
+	return strings.TrimSuffix(tree.String(), "\n")
+}
+
+func (mg *ModuleGraph) writeTree(tree *strings.Builder, node, indent string, expanded, ancestors map[string]bool) {
+	children := mg.children[node]
+	for i, child := range children {
+		branch, childIndent := "├── ", indent+"│   "
+		if i == len(children)-1 {
+			branch, childIndent = "└── ", indent+"    "
+		}
+		tree.WriteString(indent + branch + child)
+		switch {
+		case ancestors[child]:
+			tree.WriteString(cycleNodeMarker + "\n")
+		case expanded[child] && len(mg.children[child]) > 0:
+			tree.WriteString(repeatedNodeMarker + "\n")
+		default:
+			tree.WriteString("\n")
+			expanded[child] = true
+			ancestors[child] = true
+			mg.writeTree(tree, child, childIndent, expanded, ancestors)
+			delete(ancestors, child)
+		}
+	}
+}
+
+// Renders the graph in the Graphviz DOT language.
+func (dg *DependencyGraph) ToDot() string {
+	var dot strings.Builder
+	dot.WriteString("digraph " + strconv.Quote(dg.Name) + " {\n")
+	dot.WriteString("  rankdir=LR;\n")
+	for _, module := range dg.Modules {
+		dot.WriteString(fmt.Sprintf("  %s [shape=box];\n", strconv.Quote(module.Id)))
+	}
+	dg.forEachEdge(func(parent, child string) {
+		dot.WriteString(fmt.Sprintf("  %s -> %s;\n", strconv.Quote(parent), strconv.Quote(child)))
+	})
+	dot.WriteString("}")
+	return dot.String()
+}
+
+// Renders the graph as a Mermaid flowchart, wrapped in a markdown code block.
+func (dg *DependencyGraph) ToMermaid() string {
+	var mermaid strings.Builder
+	mermaid.WriteString("```mermaid\ngraph LR\n")
+	nodeIds := make(map[string]string)
+	getNodeId := func(node string) string {
+		if nodeId, exists := nodeIds[node]; exists {
+			return nodeId
+		}
+		nodeId := "n" + strconv.Itoa(len(nodeIds))
+		nodeIds[node] = nodeId
+		// Mermaid labels can't contain double quotes.
+		mermaid.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", nodeId, strings.ReplaceAll(node, `"`, "#quot;")))
+		return nodeId
+	}
+	for _, module := range dg.Modules {
+		getNodeId(module.Id)
+	}
+	dg.forEachEdge(func(parent, child string) {
+		parentId, childId := getNodeId(parent), getNodeId(child)
+		mermaid.WriteString(fmt.Sprintf("  %s --> %s\n", parentId, childId))
+	})
+	mermaid.WriteString("```")
+	return mermaid.String()
+}
+
+// Visits the graph edges in a deterministic order, starting from the modules. Edges shared by several modules are visited once.
+func (dg *DependencyGraph) forEachEdge(visit func(parent, child string)) {
+	visited := make(map[[2]string]bool)
+	for _, module := range dg.Modules {
+		queue := []string{module.Id}
+		queued := map[string]bool{module.Id: true}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+			for _, child := range module.children[node] {
+				if edge := [2]string{node, child}; !visited[edge] {
+					visited[edge] = true
+					visit(node, child)
+				}
+				if !queued[child] {
+					queued[child] = true
+					queue = append(queue, child)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package build
+
+import (
+	"testing"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGraphBuildInfo() *buildInfo.BuildInfo {
+	return &buildInfo.BuildInfo{Name: "build", Number: "1", Modules: []buildInfo.Module{
+		{Id: "app", Dependencies: []buildInfo.Dependency{
+			{Id: "a", Scopes: []string{"compile"}},
+			{Id: "b", Scopes: []string{"compile"}, RequestedBy: [][]string{{"app"}}},
+			{Id: "c", Scopes: []string{"compile"}, RequestedBy: [][]string{{"a", "app"}, {"b", "app"}}},
+			{Id: "d", Scopes: []string{"compile"}, RequestedBy: [][]string{{"c", "a", "app"}, {"c", "b", "app"}}},
+			{Id: "junit", Scopes: []string{"test"}},
+		}},
+		{Id: "lib", Dependencies: []buildInfo.Dependency{{Id: "a"}}},
+	}}
+}
+
+func TestDependencyGraphTree(t *testing.T) {
+	graph, err := NewDependencyGraph(newTestGraphBuildInfo(), DependencyGraphFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, `app
+├── a
+│   └── c
+│       └── d
+├── b
+│   └── c (*)
+└── junit
+lib
+└── a`, graph.Render(TreeGraphFormat))
+}
+
+func TestDependencyGraphFilters(t *testing.T) {
+	graph, err := NewDependencyGraph(newTestGraphBuildInfo(), DependencyGraphFilter{Module: "ap*", Scopes: []string{"test"}})
+	require.NoError(t, err)
+	assert.Equal(t, "app\n└── junit", graph.ToTree())
+}
+
+func TestDependencyGraphPaths(t *testing.T) {
+	graph, err := NewDependencyGraph(newTestGraphBuildInfo(), DependencyGraphFilter{})
+	require.NoError(t, err)
+	paths, err := graph.Modules[0].PathsTo("d")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"app", "a", "c", "d"}, {"app", "b", "c", "d"}}, paths)
+	paths, err = graph.Modules[1].PathsTo("d")
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestDependencyGraphDotAndMermaid(t *testing.T) {
+	bi := newTestGraphBuildInfo()
+	bi.Modules = bi.Modules[1:]
+	graph, err := NewDependencyGraph(bi, DependencyGraphFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, "digraph \"build/1\" {\n  rankdir=LR;\n  \"lib\" [shape=box];\n  \"lib\" -> \"a\";\n}", graph.Render(DotGraphFormat))
+	assert.Equal(t, "```mermaid\ngraph LR\n  n0[\"lib\"]\n  n1[\"a\"]\n  n0 --> n1\n```", graph.Render(MermaidGraphFormat))
+
+	_, err = GetGraphFormat("svg")
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package build
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const InTotoPayloadType = "application/vnd.in-toto+json"
+
+// DSSE envelope, as defined in https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+type DsseEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []DsseSignature `json:"signatures"`
+}
+
+type DsseSignature struct {
+	KeyId string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// Wraps the provenance statement in an unsigned DSSE envelope.
+func CreateDsseEnvelope(statement *ProvenanceStatement) (*DsseEnvelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return &DsseEnvelope{
+		PayloadType: InTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures:  []DsseSignature{},
+	}, nil
+}
+
+// Signs the pre-authentication encoding of the envelope's payload, and adds the signature to the envelope.
+func (de *DsseEnvelope) Sign(signer crypto.Signer, keyId string) error {
+	payload, err := base64.StdEncoding.DecodeString(de.Payload)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	message := preAuthEncoding(de.PayloadType, payload)
+	var sig []byte
+	switch signer.(type) {
+	case ed25519.PrivateKey:
+		sig, err = signer.Sign(rand.Reader, message, crypto.Hash(0))
+	default:
+		digest := sha256.Sum256(message)
+		sig, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	de.Signatures = append(de.Signatures, DsseSignature{KeyId: keyId, Sig: base64.StdEncoding.EncodeToString(sig)})
+	return nil
+}
+
+// Implements the DSSE pre-authentication encoding: "DSSEv1" SP LEN(type) SP type SP LEN(body) SP body
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	return append([]byte(fmt.Sprintf("DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))), payload...)
+}
+
+// Reads a PEM encoded private key (PKCS #8, PKCS #1 or SEC 1), to be used for signing DSSE envelopes.
+func ReadSigningKey(keyPath string) (crypto.Signer, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("failed to decode the PEM private key at '%s'", keyPath)
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		switch signer := key.(type) {
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
+			return signer.(crypto.Signer), nil
+		}
+		return nil, errorutils.CheckErrorf("unsupported private key type at '%s'", keyPath)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errorutils.CheckErrorf("unsupported private key format at '%s'", keyPath)
+}
@@ -0,0 +1,305 @@
+package build
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/stringutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"golang.org/x/exp/maps"
+)
+
+// The build directory name is a hash of the build name, number and project.
+// This file keeps them, to allow listing the local builds.
+// Its name ends with BuildInfoDetails, so that it is skipped when reading the partials.
+const BuildInfoIdentity = "identity." + BuildInfoDetails
+
+type BuildIdentity struct {
+	Name    string `json:"name,omitempty"`
+	Number  string `json:"number,omitempty"`
+	Project string `json:"project,omitempty"`
+}
+
+type LocalBuild struct {
+	BuildIdentity
+	Started  string `json:"started,omitempty"`
+	Partials int    `json:"partials"`
+	Dir      string `json:"dir"`
+}
+
+// A partial build-info, along with the path of the file it was read from.
+type partialFile struct {
+	path    string
+	partial *buildInfo.Partial
+}
+
+// Saves the identity of a build, which its partials directory was created by build-info-go, if it wasn't saved yet.
+// The identity of builds which their partials are saved by this module is saved when their partials directory is created.
+func SaveBuildIdentity(buildName, buildNumber, projectKey string) error {
+	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber, projectKey)
+	if err != nil {
+		return err
+	}
+	exists, err := fileutils.IsFileExists(filepath.Join(partialsBuildDir, BuildInfoIdentity), false)
+	if err != nil || exists {
+		return err
+	}
+	return writeBuildIdentity(partialsBuildDir, buildName, buildNumber, projectKey)
+}
+
+func writeBuildIdentity(partialsBuildDir, buildName, buildNumber, projectKey string) error {
+	content, err := json.Marshal(&BuildIdentity{Name: buildName, Number: buildNumber, Project: projectKey})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(filepath.Join(partialsBuildDir, BuildInfoIdentity), content, 0600))
+}
+
+// Lists the builds, which were collected locally and not yet published or cleaned.
+// Builds which were created before their identity started being saved have an empty name and number.
+func ListLocalBuilds() ([]LocalBuild, error) {
+	buildsDir := filepath.Join(coreutils.GetCliPersistentTempDirPath(), BuildTempPath)
+	exists, err := fileutils.IsDirExists(buildsDir, false)
+	if err != nil || !exists {
+		return []LocalBuild{}, err
+	}
+	entries, err := os.ReadDir(buildsDir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	localBuilds := []LocalBuild{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		localBuild, err := readLocalBuild(filepath.Join(buildsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if localBuild.Name == "" && localBuild.Started == "" && localBuild.Partials == 0 {
+			// An empty build directory.
+			continue
+		}
+		localBuilds = append(localBuilds, *localBuild)
+	}
+	sort.Slice(localBuilds, func(i, j int) bool {
+		return localBuilds[i].Started > localBuilds[j].Started
+	})
+	return localBuilds, nil
+}
+
+func readLocalBuild(buildDir string) (*LocalBuild, error) {
+	localBuild := &LocalBuild{Dir: buildDir}
+	partialsDir := filepath.Join(buildDir, "partials")
+	files, err := listFilesIfDirExists(partialsDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		switch filepath.Base(file) {
+		case BuildInfoIdentity:
+			if err = readJsonFile(file, &localBuild.BuildIdentity); err != nil {
+				return nil, err
+			}
+		case BuildInfoDetails:
+			details := new(buildInfo.General)
+			if err = readJsonFile(file, details); err != nil {
+				return nil, err
+			}
+			localBuild.Started = details.Timestamp.Format(buildInfo.TimeFormat)
+		default:
+			localBuild.Partials++
+		}
+	}
+	return localBuild, nil
+}
+
+func listFilesIfDirExists(dir string) ([]string, error) {
+	exists, err := fileutils.IsDirExists(dir, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return fileutils.ListFiles(dir, false)
+}
+
+func readJsonFile(filePath string, target interface{}) error {
+	content, err := fileutils.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckError(json.Unmarshal(content, target))
+}
+
+func readPartialFiles(buildName, buildNumber, projectKey string) ([]partialFile, error) {
+	partialsBuildDir, err := getPartialsBuildDir(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	buildFiles, err := fileutils.ListFiles(partialsBuildDir, false)
+	if err != nil {
+		return nil, err
+	}
+	var partialFiles []partialFile
+	for _, buildFile := range buildFiles {
+		dir, err := fileutils.IsDirExists(buildFile, false)
+		if err != nil {
+			return nil, err
+		}
+		if dir || strings.HasSuffix(buildFile, BuildInfoDetails) {
+			continue
+		}
+		partial := new(buildInfo.Partial)
+		if err = readJsonFile(buildFile, partial); err != nil {
+			return nil, err
+		}
+		partialFiles = append(partialFiles, partialFile{path: buildFile, partial: partial})
+	}
+	return partialFiles, nil
+}
+
+func (pf *partialFile) save() error {
+	content, err := json.MarshalIndent(pf.partial, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(pf.path, content, 0600))
+}
+
+func (pf *partialFile) remove() error {
+	return errorutils.CheckError(os.Remove(pf.path))
+}
+
+// Returns the module ID, as it will appear in the published build-info.
+func (pf *partialFile) moduleId(buildName string) string {
+	if pf.partial.ModuleId == "" {
+		return buildName
+	}
+	return pf.partial.ModuleId
+}
+
+// Removes all the partials of the modules matching the given wildcard pattern.
+// Returns the IDs of the removed modules.
+func RemoveModules(buildName, buildNumber, projectKey, modulePattern string) ([]string, error) {
+	partialFiles, err := readPartialFiles(buildName, buildNumber, projectKey)
+	if err != nil {
+		return nil, err
+	}
+	removed := make(map[string]bool)
+	for i := range partialFiles {
+		if partialFiles[i].partial.ModuleType == "" {
+			continue
+		}
+		moduleId := partialFiles[i].moduleId(buildName)
+		match, err := stringutils.MatchWildcardPattern(modulePattern, moduleId)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if !match {
+			continue
+		}
+		log.Debug("Removing a partial of module", moduleId, "from", partialFiles[i].path)
+		if err = partialFiles[i].remove(); err != nil {
+			return nil, err
+		}
+		removed[moduleId] = true
+	}
+	removedModules := maps.Keys(removed)
+	slices.Sort(removedModules)
+	return removedModules, nil
+}
+
+// Removes the artifacts whose name or path match the given wildcard pattern.
+// Returns the number of removed artifacts.
+func RemoveArtifacts(buildName, buildNumber, projectKey, artifactPattern string) (int, error) {
+	partialFiles, err := readPartialFiles(buildName, buildNumber, projectKey)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for i := range partialFiles {
+		partial := partialFiles[i].partial
+		if len(partial.Artifacts) == 0 {
+			continue
+		}
+		var kept []buildInfo.Artifact
+		for _, artifact := range partial.Artifacts {
+			match, err := matchArtifact(artifactPattern, artifact)
+			if err != nil {
+				return 0, err
+			}
+			if !match {
+				kept = append(kept, artifact)
+			}
+		}
+		if len(kept) == len(partial.Artifacts) {
+			continue
+		}
+		removed += len(partial.Artifacts) - len(kept)
+		partial.Artifacts = kept
+		if err = partialFiles[i].save(); err != nil {
+			return 0, err
+		}
+	}
+	return removed, nil
+}
+
+func matchArtifact(pattern string, artifact buildInfo.Artifact) (bool, error) {
+	for _, value := range []string{artifact.Name, artifact.Path} {
+		if value == "" {
+			continue
+		}
+		match, err := stringutils.MatchWildcardPattern(pattern, value)
+		if err != nil || match {
+			return match, errorutils.CheckError(err)
+		}
+	}
+	return false, nil
+}
+
+// Renames a module in all the partials it appears in.
+// Returns the number of updated partials.
+func RenameModule(buildName, buildNumber, projectKey, moduleId, newModuleId string) (int, error) {
+	if newModuleId == "" {
+		return 0, errorutils.CheckErrorf("the new module name cannot be empty")
+	}
+	partialFiles, err := readPartialFiles(buildName, buildNumber, projectKey)
+	if err != nil {
+		return 0, err
+	}
+	renamed := 0
+	for i := range partialFiles {
+		if partialFiles[i].partial.ModuleType == "" || partialFiles[i].moduleId(buildName) != moduleId {
+			continue
+		}
+		partialFiles[i].partial.ModuleId = newModuleId
+		if err = partialFiles[i].save(); err != nil {
+			return 0, err
+		}
+		renamed++
+	}
+	if renamed == 0 {
+		return 0, errorutils.CheckErrorf("module '%s' was not found in build %s/%s", moduleId, buildName, buildNumber)
+	}
+	return renamed, nil
+}
+
+// Adds properties to the build-info. The properties are saved as a partial, similarly to collected environment variables.
+func AddBuildProperties(buildName, buildNumber, projectKey string, properties map[string]string) error {
+	if len(properties) == 0 {
+		return nil
+	}
+	if err := SaveBuildGeneralDetails(buildName, buildNumber, projectKey); err != nil {
+		return err
+	}
+	return SavePartialBuildInfo(buildName, buildNumber, projectKey, func(partial *buildInfo.Partial) {
+		partial.Env = properties
+	})
+}
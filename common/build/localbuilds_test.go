@@ -0,0 +1,81 @@
+package build
+
+import (
+	"testing"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditLocalBuild(t *testing.T) {
+	buildName, buildNumber := "local-build-"+timestamp, "1"
+	defer func() {
+		assert.NoError(t, RemoveBuildDir(buildName, buildNumber, ""))
+	}()
+	assert.NoError(t, SaveBuildGeneralDetails(buildName, buildNumber, ""))
+	assert.NoError(t, SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildInfo.Partial) {
+		partial.ModuleId = "module-a"
+		partial.ModuleType = buildInfo.Generic
+		partial.Artifacts = []buildInfo.Artifact{{Name: "a.jar", Path: "dir/a.jar"}, {Name: "a.pom", Path: "dir/a.pom"}}
+	}))
+	assert.NoError(t, SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildInfo.Partial) {
+		partial.ModuleId = "module-b"
+		partial.ModuleType = buildInfo.Generic
+		partial.Dependencies = []buildInfo.Dependency{{Id: "dep"}}
+	}))
+	assert.NoError(t, SavePartialBuildInfo(buildName, buildNumber, "", func(partial *buildInfo.Partial) {
+		partial.ModuleType = buildInfo.Generic
+		partial.Artifacts = []buildInfo.Artifact{{Name: "c.zip"}}
+	}))
+
+	// The build should be listed with its identity.
+	localBuilds, err := ListLocalBuilds()
+	assert.NoError(t, err)
+	var found *LocalBuild
+	for i := range localBuilds {
+		if localBuilds[i].Name == buildName {
+			found = &localBuilds[i]
+		}
+	}
+	if assert.NotNil(t, found) {
+		assert.Equal(t, buildNumber, found.Number)
+		assert.Equal(t, 3, found.Partials)
+		assert.NotEmpty(t, found.Started)
+	}
+
+	removedArtifacts, err := RemoveArtifacts(buildName, buildNumber, "", "*.pom")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removedArtifacts)
+
+	removedModules, err := RemoveModules(buildName, buildNumber, "", "module-b")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"module-b"}, removedModules)
+
+	// A partial without a module ID belongs to a module named after the build.
+	renamed, err := RenameModule(buildName, buildNumber, "", buildName, "module-c")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, renamed)
+	_, err = RenameModule(buildName, buildNumber, "", "missing", "other")
+	assert.Error(t, err)
+
+	assert.NoError(t, AddBuildProperties(buildName, buildNumber, "", map[string]string{"key": "value"}))
+
+	partials, err := ReadPartialBuildInfoFiles(buildName, buildNumber, "")
+	assert.NoError(t, err)
+	assert.Len(t, partials, 3)
+	modules := make(map[string][]buildInfo.Artifact)
+	properties := make(map[string]string)
+	for _, partial := range partials {
+		if partial.ModuleType != "" {
+			modules[partial.ModuleId] = partial.Artifacts
+		}
+		for key, value := range partial.Env {
+			properties[key] = value
+		}
+	}
+	assert.Equal(t, map[string][]buildInfo.Artifact{
+		"module-a": {{Name: "a.jar", Path: "dir/a.jar"}},
+		"module-c": {{Name: "c.zip"}},
+	}, modules)
+	assert.Equal(t, map[string]string{"key": "value"}, properties)
+}
@@ -0,0 +1,204 @@
+package build
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	InTotoStatementType     = "https://in-toto.io/Statement/v1"
+	SlsaProvenancePredicate = "https://slsa.dev/provenance/v1"
+	ProvenanceBuildType     = "https://jfrog.com/build-info/provenance/v1"
+	DefaultBuilderId        = "https://github.com/jfrog/jfrog-cli"
+)
+
+// In-toto statement, holding a SLSA v1 provenance predicate.
+type ProvenanceStatement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     ProvenancePredicate  `json:"predicate"`
+}
+
+type ResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	Uri    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type ProvenancePredicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type ExternalParameters struct {
+	BuildName   string `json:"buildName"`
+	BuildNumber string `json:"buildNumber"`
+	Project     string `json:"project,omitempty"`
+}
+
+type RunDetails struct {
+	Builder  Builder       `json:"builder"`
+	Metadata BuildMetadata `json:"metadata"`
+}
+
+type Builder struct {
+	Id string `json:"id"`
+}
+
+type BuildMetadata struct {
+	InvocationId string `json:"invocationId,omitempty"`
+	StartedOn    string `json:"startedOn,omitempty"`
+	FinishedOn   string `json:"finishedOn,omitempty"`
+}
+
+// Creates a SLSA provenance statement from the given partials.
+// Subjects are the artifacts which have a sha256 checksum, and materials are the dependencies and the VCS entries.
+func NewProvenanceStatement(buildName, buildNumber, projectKey string, generalDetails *buildInfo.General, partials buildInfo.Partials) *ProvenanceStatement {
+	sort.Sort(partials)
+	env := make(map[string]string)
+	var subjects, materials []ResourceDescriptor
+	subjectsSet := make(map[string]bool)
+	materialsSet := make(map[string]bool)
+	for _, partial := range partials {
+		for key, value := range partial.Env {
+			env[strings.TrimPrefix(key, buildInfo.BuildInfoEnvPrefix)] = value
+		}
+		for _, artifact := range partial.Artifacts {
+			if artifact.Sha256 == "" {
+				log.Debug("Skipping artifact '" + artifact.Name + "' as a provenance subject, since it has no sha256 checksum.")
+				continue
+			}
+			if subjectsSet[artifact.Sha256] {
+				continue
+			}
+			subjectsSet[artifact.Sha256] = true
+			subjects = append(subjects, ResourceDescriptor{Name: getArtifactSubjectName(artifact), Digest: map[string]string{"sha256": artifact.Sha256}})
+		}
+		for _, dependency := range partial.Dependencies {
+			if materialsSet[dependency.Id] {
+				continue
+			}
+			materialsSet[dependency.Id] = true
+			materials = append(materials, ResourceDescriptor{Name: dependency.Id, Digest: checksumToDigest(dependency.Checksum)})
+		}
+		for _, vcs := range partial.VcsList {
+			uri := vcsToUri(vcs)
+			if uri == "" || materialsSet[uri] {
+				continue
+			}
+			materialsSet[uri] = true
+			material := ResourceDescriptor{Uri: uri}
+			if vcs.Revision != "" {
+				material.Digest = map[string]string{"gitCommit": vcs.Revision}
+			}
+			materials = append(materials, material)
+		}
+	}
+
+	metadata := BuildMetadata{InvocationId: getInvocationId(env), FinishedOn: time.Now().UTC().Format(time.RFC3339)}
+	if generalDetails != nil && !generalDetails.Timestamp.IsZero() {
+		metadata.StartedOn = generalDetails.Timestamp.UTC().Format(time.RFC3339)
+	}
+	return &ProvenanceStatement{
+		Type:          InTotoStatementType,
+		Subject:       subjects,
+		PredicateType: SlsaProvenancePredicate,
+		Predicate: ProvenancePredicate{
+			BuildDefinition: BuildDefinition{
+				BuildType:            ProvenanceBuildType,
+				ExternalParameters:   ExternalParameters{BuildName: buildName, BuildNumber: buildNumber, Project: projectKey},
+				ResolvedDependencies: materials,
+			},
+			RunDetails: RunDetails{
+				Builder:  Builder{Id: GetBuilderId(env)},
+				Metadata: metadata,
+			},
+		},
+	}
+}
+
+// Returns the ID of the CI builder, based on the environment variables collected by the 'build-collect-env' command.
+// If the CI server cannot be identified, DefaultBuilderId is returned.
+func GetBuilderId(env map[string]string) string {
+	switch {
+	case env["GITHUB_SERVER_URL"] != "" && env["GITHUB_WORKFLOW_REF"] != "":
+		return env["GITHUB_SERVER_URL"] + "/" + env["GITHUB_WORKFLOW_REF"]
+	case env["GITLAB_CI"] != "" && env["CI_SERVER_URL"] != "":
+		return env["CI_SERVER_URL"] + "/" + env["CI_PROJECT_PATH"] + "/-/runners/" + env["CI_RUNNER_ID"]
+	case env["JENKINS_URL"] != "":
+		return env["JENKINS_URL"]
+	case env["SYSTEM_TEAMFOUNDATIONCOLLECTIONURI"] != "":
+		return env["SYSTEM_TEAMFOUNDATIONCOLLECTIONURI"] + env["SYSTEM_TEAMPROJECT"]
+	case env["CIRCLE_BUILD_URL"] != "":
+		return "https://circleci.com/" + env["CIRCLE_PROJECT_USERNAME"] + "/" + env["CIRCLE_PROJECT_REPONAME"]
+	case env["BITBUCKET_WORKSPACE"] != "":
+		return "https://bitbucket.org/" + env["BITBUCKET_WORKSPACE"] + "/" + env["BITBUCKET_REPO_SLUG"] + "/pipelines"
+	case env["JFROG_CLI_BUILD_URL"] != "":
+		return env["JFROG_CLI_BUILD_URL"]
+	}
+	return DefaultBuilderId
+}
+
+func getInvocationId(env map[string]string) string {
+	switch {
+	case env["GITHUB_RUN_ID"] != "":
+		return env["GITHUB_SERVER_URL"] + "/" + env["GITHUB_REPOSITORY"] + "/actions/runs/" + env["GITHUB_RUN_ID"] + "/attempts/" + env["GITHUB_RUN_ATTEMPT"]
+	case env["CI_JOB_URL"] != "":
+		return env["CI_JOB_URL"]
+	case env["BUILD_URL"] != "":
+		return env["BUILD_URL"]
+	case env["CIRCLE_BUILD_URL"] != "":
+		return env["CIRCLE_BUILD_URL"]
+	}
+	return ""
+}
+
+func getArtifactSubjectName(artifact buildInfo.Artifact) string {
+	if artifact.Path != "" {
+		return artifact.Path
+	}
+	return artifact.Name
+}
+
+func checksumToDigest(checksum buildInfo.Checksum) map[string]string {
+	digest := make(map[string]string)
+	if checksum.Sha256 != "" {
+		digest["sha256"] = checksum.Sha256
+	}
+	if checksum.Sha1 != "" {
+		digest["sha1"] = checksum.Sha1
+	}
+	if checksum.Md5 != "" {
+		digest["md5"] = checksum.Md5
+	}
+	if len(digest) == 0 {
+		return nil
+	}
+	return digest
+}
+
+// Returns the VCS entry as a SLSA git URI, such as git+https://github.com/org/repo@refs/heads/main
+func vcsToUri(vcs buildInfo.Vcs) string {
+	if vcs.Url == "" {
+		return ""
+	}
+	uri := vcs.Url
+	if !strings.HasPrefix(uri, "git+") {
+		uri = "git+" + uri
+	}
+	if vcs.Branch != "" {
+		uri += "@refs/heads/" + vcs.Branch
+	}
+	return uri
+}
@@ -0,0 +1,142 @@
+package build
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestPartials() buildInfo.Partials {
+	return buildInfo.Partials{
+		{
+			Timestamp: 1,
+			Env: buildInfo.Env{
+				"buildInfo.env.GITHUB_SERVER_URL":   "https://github.com",
+				"buildInfo.env.GITHUB_WORKFLOW_REF": "jfrog/repo/.github/workflows/build.yml@refs/heads/main",
+				"buildInfo.env.GITHUB_REPOSITORY":   "jfrog/repo",
+				"buildInfo.env.GITHUB_RUN_ID":       "123",
+				"buildInfo.env.GITHUB_RUN_ATTEMPT":  "1",
+			},
+		},
+		{
+			Timestamp: 2,
+			Artifacts: []buildInfo.Artifact{
+				{Name: "a.zip", Path: "dir/a.zip", OriginalDeploymentRepo: "repo", Checksum: buildInfo.Checksum{Sha256: "sha-a", Sha1: "sha1-a"}},
+				{Name: "b.zip", Path: "dir/b.zip", Checksum: buildInfo.Checksum{Sha1: "sha1-b"}},
+			},
+			Dependencies: []buildInfo.Dependency{
+				{Id: "dep:1.0.0", Checksum: buildInfo.Checksum{Sha256: "sha-dep", Sha1: "sha1-dep"}},
+			},
+		},
+		{
+			Timestamp: 3,
+			Artifacts: []buildInfo.Artifact{{Name: "a.zip", Path: "dir/a.zip", Checksum: buildInfo.Checksum{Sha256: "sha-a"}}},
+			Dependencies: []buildInfo.Dependency{
+				{Id: "dep:1.0.0", Checksum: buildInfo.Checksum{Sha256: "sha-dep"}},
+			},
+			VcsList: []buildInfo.Vcs{{Url: "https://github.com/jfrog/repo.git", Revision: "abc123", Branch: "main"}},
+		},
+	}
+}
+
+func TestNewProvenanceStatement(t *testing.T) {
+	started := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	statement := NewProvenanceStatement("name", "1", "proj", &buildInfo.General{Timestamp: started}, createTestPartials())
+
+	assert.Equal(t, InTotoStatementType, statement.Type)
+	assert.Equal(t, SlsaProvenancePredicate, statement.PredicateType)
+	assert.Equal(t, []ResourceDescriptor{{Name: "dir/a.zip", Digest: map[string]string{"sha256": "sha-a"}}}, statement.Subject)
+
+	definition := statement.Predicate.BuildDefinition
+	assert.Equal(t, ExternalParameters{BuildName: "name", BuildNumber: "1", Project: "proj"}, definition.ExternalParameters)
+	assert.Equal(t, []ResourceDescriptor{
+		{Name: "dep:1.0.0", Digest: map[string]string{"sha256": "sha-dep", "sha1": "sha1-dep"}},
+		{Uri: "git+https://github.com/jfrog/repo.git@refs/heads/main", Digest: map[string]string{"gitCommit": "abc123"}},
+	}, definition.ResolvedDependencies)
+
+	runDetails := statement.Predicate.RunDetails
+	assert.Equal(t, "https://github.com/jfrog/repo/.github/workflows/build.yml@refs/heads/main", runDetails.Builder.Id)
+	assert.Equal(t, "https://github.com/jfrog/repo/actions/runs/123/attempts/1", runDetails.Metadata.InvocationId)
+	assert.Equal(t, "2024-01-02T03:04:05Z", runDetails.Metadata.StartedOn)
+}
+
+func TestGetBuilderId(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		expected string
+	}{
+		{"jenkins", map[string]string{"JENKINS_URL": "https://jenkins.local/"}, "https://jenkins.local/"},
+		{"gitlab", map[string]string{"GITLAB_CI": "true", "CI_SERVER_URL": "https://gitlab.com", "CI_PROJECT_PATH": "org/proj", "CI_RUNNER_ID": "7"}, "https://gitlab.com/org/proj/-/runners/7"},
+		{"unknown", map[string]string{"HOME": "/root"}, DefaultBuilderId},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, GetBuilderId(testCase.env))
+		})
+	}
+}
+
+func TestDsseEnvelope(t *testing.T) {
+	statement := NewProvenanceStatement("name", "1", "", nil, createTestPartials())
+	envelope, err := CreateDsseEnvelope(statement)
+	assert.NoError(t, err)
+	assert.Equal(t, InTotoPayloadType, envelope.PayloadType)
+	assert.Empty(t, envelope.Signatures)
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	assert.NoError(t, err)
+	decoded := new(ProvenanceStatement)
+	assert.NoError(t, json.Unmarshal(payload, decoded))
+	assert.Equal(t, statement.Subject, decoded.Subject)
+
+	// Sign with an ed25519 key.
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.NoError(t, envelope.Sign(privateKey, "ed-key"))
+	assert.Len(t, envelope.Signatures, 1)
+	assert.Equal(t, "ed-key", envelope.Signatures[0].KeyId)
+	sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	assert.NoError(t, err)
+	assert.True(t, ed25519.Verify(publicKey, preAuthEncoding(envelope.PayloadType, payload), sig))
+}
+
+func TestReadSigningKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	assert.NoError(t, err)
+	keyPath := filepath.Join(t.TempDir(), "key.pem")
+	assert.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600))
+
+	signer, err := ReadSigningKey(keyPath)
+	assert.NoError(t, err)
+	envelope, err := CreateDsseEnvelope(NewProvenanceStatement("name", "1", "", nil, nil))
+	assert.NoError(t, err)
+	assert.NoError(t, envelope.Sign(signer, ""))
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	assert.NoError(t, err)
+	sig, err := base64.StdEncoding.DecodeString(envelope.Signatures[0].Sig)
+	assert.NoError(t, err)
+	digest := sha256.Sum256(preAuthEncoding(envelope.PayloadType, payload))
+	assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, digest[:], sig))
+
+	// Invalid key file.
+	assert.NoError(t, os.WriteFile(keyPath, []byte("not a key"), 0600))
+	_, err = ReadSigningKey(keyPath)
+	assert.Error(t, err)
+}
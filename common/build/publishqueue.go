@@ -0,0 +1,138 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	buildInfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	queuedBuildFileSuffix = ".json"
+	// The delay before the first retry. The delay is doubled after every failed attempt, up to maxPublishRetryDelay.
+	initialPublishRetryDelay = time.Minute
+	maxPublishRetryDelay     = 6 * time.Hour
+)
+
+// A build-info which could not be published, along with the parameters required for publishing it later.
+// Credentials are not kept. The server is resolved by its ID when the queue is flushed.
+type QueuedBuildPublish struct {
+	BuildInfo *buildInfo.BuildInfo `json:"buildInfo"`
+	Project   string               `json:"project,omitempty"`
+	ServerId  string               `json:"serverId,omitempty"`
+	// If true, the build number is replaced by the next available build number when the build is published.
+	UseNextBuildNumber bool      `json:"useNextBuildNumber,omitempty"`
+	QueuedAt           time.Time `json:"queuedAt"`
+	Attempts           int       `json:"attempts"`
+	NextAttempt        time.Time `json:"nextAttempt"`
+	LastError          string    `json:"lastError,omitempty"`
+	filePath           string
+}
+
+// Returns the key which identifies the build in the queue. A build queued twice replaces the previous entry.
+func (qbp *QueuedBuildPublish) Key() string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{qbp.BuildInfo.Name, qbp.BuildInfo.Number, qbp.BuildInfo.Started, qbp.Project}, "_")))
+	return hex.EncodeToString(hash[:])
+}
+
+func (qbp *QueuedBuildPublish) IsDue(now time.Time) bool {
+	return !now.Before(qbp.NextAttempt)
+}
+
+// Records a failed publishing attempt, and schedules the next attempt using an exponential backoff.
+func (qbp *QueuedBuildPublish) RecordFailure(err error, now time.Time) {
+	qbp.Attempts++
+	qbp.LastError = err.Error()
+	delay := initialPublishRetryDelay
+	for i := 1; i < qbp.Attempts && delay < maxPublishRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxPublishRetryDelay {
+		delay = maxPublishRetryDelay
+	}
+	qbp.NextAttempt = now.Add(delay)
+}
+
+// The offline queue of build-info publishing, kept under the JFrog home directory.
+type PublishQueue struct {
+	dir string
+}
+
+func NewPublishQueue() (*PublishQueue, error) {
+	dir, err := coreutils.GetJfrogBuildPublishQueueDir()
+	if err != nil {
+		return nil, err
+	}
+	return &PublishQueue{dir: dir}, nil
+}
+
+func (pq *PublishQueue) Dir() string {
+	return pq.dir
+}
+
+// Adds the build to the queue. If the same build (name, number, started and project) is already queued, it is replaced.
+func (pq *PublishQueue) Add(queuedBuild *QueuedBuildPublish) error {
+	if err := fileutils.CreateDirIfNotExist(pq.dir); err != nil {
+		return err
+	}
+	if queuedBuild.QueuedAt.IsZero() {
+		queuedBuild.QueuedAt = time.Now()
+	}
+	queuedBuild.filePath = filepath.Join(pq.dir, queuedBuild.Key()+queuedBuildFileSuffix)
+	return pq.Update(queuedBuild)
+}
+
+// Saves the changes of a queued build, such as its attempts count.
+func (pq *PublishQueue) Update(queuedBuild *QueuedBuildPublish) error {
+	content, err := json.MarshalIndent(queuedBuild, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(queuedBuild.filePath, content, 0600))
+}
+
+func (pq *PublishQueue) Remove(queuedBuild *QueuedBuildPublish) error {
+	return errorutils.CheckError(os.Remove(queuedBuild.filePath))
+}
+
+// Returns the queued builds, ordered by the time they were queued.
+func (pq *PublishQueue) List() ([]*QueuedBuildPublish, error) {
+	exists, err := fileutils.IsDirExists(pq.dir, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	files, err := fileutils.ListFiles(pq.dir, false)
+	if err != nil {
+		return nil, err
+	}
+	var queuedBuilds []*QueuedBuildPublish
+	for _, file := range files {
+		if !strings.HasSuffix(file, queuedBuildFileSuffix) {
+			continue
+		}
+		queuedBuild := new(QueuedBuildPublish)
+		if err = readJsonFile(file, queuedBuild); err != nil {
+			log.Warn("Skipping the invalid queued build file", file+":", err.Error())
+			continue
+		}
+		if queuedBuild.BuildInfo == nil {
+			continue
+		}
+		queuedBuild.filePath = file
+		queuedBuilds = append(queuedBuilds, queuedBuild)
+	}
+	sort.Slice(queuedBuilds, func(i, j int) bool {
+		return queuedBuilds[i].QueuedAt.Before(queuedBuilds[j].QueuedAt)
+	})
+	return queuedBuilds, nil
+}
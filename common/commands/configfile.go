@@ -153,11 +153,11 @@ func handleInteractiveConfigCreation(configFile *ConfigFile, confType project.Pr
 	switch confType {
 	case project.Go:
 		return configFile.setDeployerResolver()
-	case project.Pip, project.Pipenv, project.Poetry:
+	case project.Pip, project.Pipenv, project.Poetry, project.Uv, project.Pdm:
 		return configFile.setResolver(false)
 	case project.Yarn:
 		return configFile.setResolver(false)
-	case project.Npm:
+	case project.Npm, project.Cargo, project.Conan:
 		return configFile.setDeployerResolver()
 	case project.Nuget, project.Dotnet:
 		return configFile.configDotnet()
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Prints the resolved spec of a spec file template, after replacing its variables, including its included spec files
+// and evaluating the 'when' conditions of its file groups.
+type SpecRenderCommand struct {
+	specFilePath string
+	specVars     map[string]string
+}
+
+func NewSpecRenderCommand() *SpecRenderCommand {
+	return &SpecRenderCommand{}
+}
+
+func (src *SpecRenderCommand) SetSpecFilePath(specFilePath string) *SpecRenderCommand {
+	src.specFilePath = specFilePath
+	return src
+}
+
+func (src *SpecRenderCommand) SetSpecVars(specVars map[string]string) *SpecRenderCommand {
+	src.specVars = specVars
+	return src
+}
+
+func (src *SpecRenderCommand) Run() error {
+	rendered, err := spec.RenderSpecFile(src.specFilePath, src.specVars)
+	if err != nil {
+		return err
+	}
+	log.Output(string(rendered))
+	return nil
+}
+
+func (src *SpecRenderCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (src *SpecRenderCommand) CommandName() string {
+	return "spec_render"
+}
+
+// Validates a spec file template, without running the command which uses it.
+type SpecValidateCommand struct {
+	specFilePath      string
+	specVars          map[string]string
+	isTargetMandatory bool
+	isSearchBasedSpec bool
+}
+
+func NewSpecValidateCommand() *SpecValidateCommand {
+	return &SpecValidateCommand{}
+}
+
+func (svc *SpecValidateCommand) SetSpecFilePath(specFilePath string) *SpecValidateCommand {
+	svc.specFilePath = specFilePath
+	return svc
+}
+
+func (svc *SpecValidateCommand) SetSpecVars(specVars map[string]string) *SpecValidateCommand {
+	svc.specVars = specVars
+	return svc
+}
+
+// Set to true to validate a spec of a command which requires a target, such as upload.
+func (svc *SpecValidateCommand) SetTargetMandatory(isTargetMandatory bool) *SpecValidateCommand {
+	svc.isTargetMandatory = isTargetMandatory
+	return svc
+}
+
+// Set to true to validate a spec of a command which searches Artifactory, such as download, which doesn't require a pattern.
+func (svc *SpecValidateCommand) SetSearchBasedSpec(isSearchBasedSpec bool) *SpecValidateCommand {
+	svc.isSearchBasedSpec = isSearchBasedSpec
+	return svc
+}
+
+func (svc *SpecValidateCommand) Run() error {
+	if err := spec.ValidateSpecFile(svc.specFilePath, svc.specVars, svc.isTargetMandatory, svc.isSearchBasedSpec); err != nil {
+		return err
+	}
+	log.Info("The spec file " + svc.specFilePath + " is valid.")
+	return nil
+}
+
+func (svc *SpecValidateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (svc *SpecValidateCommand) CommandName() string {
+	return "spec_validate"
+}
@@ -15,6 +15,10 @@ const (
 	Json       OutputFormat = "json"
 	SimpleJson OutputFormat = "simple-json"
 	Sarif      OutputFormat = "sarif"
+	// The following formats aren't included in OutputFormats. Commands which support them pass them to GetSupportedOutputFormat.
+	Csv OutputFormat = "csv"
+	// Newline delimited JSON, one JSON object per line.
+	Ndjson OutputFormat = "ndjson"
 )
 
 var OutputFormats = []string{string(Table), string(Json), string(SimpleJson), string(Sarif)}
@@ -33,8 +37,27 @@ func GetOutputFormat(formatFlagVal string) (format OutputFormat, err error) {
 		case string(Sarif):
 			format = Sarif
 		default:
-			err = errorutils.CheckErrorf("only the following output formats are supported: " + coreutils.ListToText(OutputFormats))
+			err = errorutils.CheckErrorf("only the following output formats are supported: %s", coreutils.ListToText(OutputFormats))
 		}
 	}
 	return
 }
+
+// Returns the output format of the flag value, out of the formats a command supports.
+// The first supported format is the default. If no formats are given, all the output formats are supported, as in GetOutputFormat.
+func GetSupportedOutputFormat(formatFlagVal string, supportedFormats ...OutputFormat) (OutputFormat, error) {
+	if len(supportedFormats) == 0 {
+		return GetOutputFormat(formatFlagVal)
+	}
+	if formatFlagVal == "" {
+		return supportedFormats[0], nil
+	}
+	supportedFormatsNames := make([]string, 0, len(supportedFormats))
+	for _, supportedFormat := range supportedFormats {
+		if strings.EqualFold(formatFlagVal, string(supportedFormat)) {
+			return supportedFormat, nil
+		}
+		supportedFormatsNames = append(supportedFormatsNames, string(supportedFormat))
+	}
+	return "", errorutils.CheckErrorf("only the following output formats are supported: %s", coreutils.ListToText(supportedFormatsNames))
+}
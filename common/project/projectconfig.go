@@ -39,6 +39,10 @@ const (
 	Dotnet
 	Build
 	Terraform
+	Cargo
+	Conan
+	Uv
+	Pdm
 )
 
 type ConfigType string
@@ -62,6 +66,10 @@ var ProjectTypes = []string{
 	"dotnet",
 	"build",
 	"terraform",
+	"cargo",
+	"conan",
+	"uv",
+	"pdm",
 }
 
 func (projectType ProjectType) String() string {
@@ -0,0 +1,303 @@
+package promotionpolicy
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/stringutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	RequireVcsRevisionRule       = "requireVcsRevision"
+	MinModulesRule               = "minModules"
+	ForbiddenDependencyReposRule = "forbiddenDependencyRepos"
+	AllowedIssueStatusesRule     = "allowedIssueStatuses"
+	MaxBuildAgeRule              = "maxBuildAge"
+	RequiredPropertiesRule       = "requiredProperties"
+
+	passedStatus = "PASSED"
+	failedStatus = "FAILED"
+)
+
+type RuleResult struct {
+	Rule    string `json:"rule" col-name:"Rule"`
+	Build   string `json:"build" col-name:"Build"`
+	Status  string `json:"status" col-name:"Status"`
+	Message string `json:"message,omitempty" col-name:"Details"`
+	Passed  bool   `json:"passed"`
+}
+
+type Report struct {
+	Results []RuleResult `json:"results"`
+}
+
+func (r *Report) Passed() bool {
+	return len(r.FailedResults()) == 0
+}
+
+func (r *Report) FailedResults() (failed []RuleResult) {
+	for _, result := range r.Results {
+		if !result.Passed {
+			failed = append(failed, result)
+		}
+	}
+	return
+}
+
+func (r *Report) Print() error {
+	return coreutils.PrintTable(r.Results, "Promotion Policy", "The promotion policy has no rules", false)
+}
+
+// Returns an error describing the failed rules, or nil if all the rules passed.
+func (r *Report) Error() error {
+	failed := r.FailedResults()
+	if len(failed) == 0 {
+		return nil
+	}
+	var details strings.Builder
+	for _, result := range failed {
+		details.WriteString(fmt.Sprintf("\n- %s (%s): %s", result.Rule, result.Build, result.Message))
+	}
+	return errorutils.CheckErrorf("the promotion policy is not satisfied. %d rules failed:%s", len(failed), details.String())
+}
+
+// Evaluates a promotion policy against build-infos.
+type Evaluator struct {
+	policy                  *Policy
+	dependencyReposResolver DependencyRepositoriesResolver
+	issueStatusResolver     IssueStatusResolver
+	now                     func() time.Time
+}
+
+func NewEvaluator(policy *Policy) *Evaluator {
+	evaluator := &Evaluator{policy: policy, now: time.Now}
+	if tracker := policy.IssueTracker; tracker != nil {
+		token := ""
+		if tracker.TokenEnv != "" {
+			token = os.Getenv(tracker.TokenEnv)
+		}
+		evaluator.issueStatusResolver = NewJiraIssueStatusResolver(tracker.Url, tracker.Username, token)
+	}
+	return evaluator
+}
+
+// Required if the policy includes the forbiddenDependencyRepos rule.
+func (e *Evaluator) SetDependencyRepositoriesResolver(resolver DependencyRepositoriesResolver) *Evaluator {
+	e.dependencyReposResolver = resolver
+	return e
+}
+
+// Overrides the issue tracker configured in the policy.
+func (e *Evaluator) SetIssueStatusResolver(resolver IssueStatusResolver) *Evaluator {
+	e.issueStatusResolver = resolver
+	return e
+}
+
+// Evaluates the policy rules against each of the build-infos.
+// A rule which fails is reported in the returned report. An error is returned only if a rule could not be evaluated.
+func (e *Evaluator) Evaluate(buildInfos ...*buildinfo.BuildInfo) (*Report, error) {
+	rules := e.policy.Rules
+	if len(rules.ForbiddenDependencyRepos) > 0 && e.dependencyReposResolver == nil {
+		return nil, errorutils.CheckErrorf("the '%s' promotion policy rule requires a dependency repositories resolver", ForbiddenDependencyReposRule)
+	}
+	if len(rules.AllowedIssueStatuses) > 0 && e.issueStatusResolver == nil {
+		return nil, errorutils.CheckErrorf("the '%s' promotion policy rule requires an issue tracker. Add the 'issueTracker' details to the policy", AllowedIssueStatusesRule)
+	}
+	report := &Report{}
+	for _, buildInfo := range buildInfos {
+		buildString := buildInfo.Name + "/" + buildInfo.Number
+		log.Debug("Evaluating the promotion policy against build", buildString)
+		addResult := func(rule, failure string) {
+			result := RuleResult{Rule: rule, Build: buildString, Status: passedStatus, Passed: failure == ""}
+			if !result.Passed {
+				result.Status, result.Message = failedStatus, failure
+			}
+			report.Results = append(report.Results, result)
+		}
+		if rules.RequireVcsRevision {
+			addResult(RequireVcsRevisionRule, checkVcsRevision(buildInfo))
+		}
+		if rules.MinModules > 0 {
+			addResult(MinModulesRule, checkMinModules(buildInfo, rules.MinModules))
+		}
+		if len(rules.ForbiddenDependencyRepos) > 0 {
+			failure, err := e.checkDependencyRepos(buildInfo)
+			if err != nil {
+				return nil, err
+			}
+			addResult(ForbiddenDependencyReposRule, failure)
+		}
+		if len(rules.AllowedIssueStatuses) > 0 {
+			failure, err := e.checkIssueStatuses(buildInfo)
+			if err != nil {
+				return nil, err
+			}
+			addResult(AllowedIssueStatusesRule, failure)
+		}
+		if rules.MaxBuildAge != "" {
+			failure, err := e.checkBuildAge(buildInfo)
+			if err != nil {
+				return nil, err
+			}
+			addResult(MaxBuildAgeRule, failure)
+		}
+		if len(rules.RequiredProperties) > 0 {
+			failure, err := checkRequiredProperties(buildInfo, rules.RequiredProperties)
+			if err != nil {
+				return nil, err
+			}
+			addResult(RequiredPropertiesRule, failure)
+		}
+	}
+	return report, nil
+}
+
+func checkVcsRevision(buildInfo *buildinfo.BuildInfo) string {
+	for _, vcs := range buildInfo.VcsList {
+		if vcs.Revision != "" {
+			return ""
+		}
+	}
+	return "the build-info has no VCS revision"
+}
+
+func checkMinModules(buildInfo *buildinfo.BuildInfo, minModules int) string {
+	if len(buildInfo.Modules) < minModules {
+		return fmt.Sprintf("the build-info has %d modules, while at least %d are required", len(buildInfo.Modules), minModules)
+	}
+	return ""
+}
+
+func (e *Evaluator) checkDependencyRepos(buildInfo *buildinfo.BuildInfo) (string, error) {
+	// Map each checksum to the dependencies which have it.
+	dependencies := make(map[string][]string)
+	var sha1s []string
+	for _, module := range buildInfo.Modules {
+		for _, dependency := range module.Dependencies {
+			if dependency.Sha1 == "" {
+				log.Debug("Skipping dependency", dependency.Id, "which has no sha1 checksum.")
+				continue
+			}
+			if _, exists := dependencies[dependency.Sha1]; !exists {
+				sha1s = append(sha1s, dependency.Sha1)
+			}
+			dependencies[dependency.Sha1] = appendIfMissing(dependencies[dependency.Sha1], dependency.Id)
+		}
+	}
+	if len(sha1s) == 0 {
+		return "", nil
+	}
+	repositories, err := e.dependencyReposResolver.ResolveRepositories(sha1s)
+	if err != nil {
+		return "", err
+	}
+	var violations []string
+	for _, sha1 := range sha1s {
+		for _, repo := range repositories[sha1] {
+			forbidden, err := matchAny(e.policy.Rules.ForbiddenDependencyRepos, repo)
+			if err != nil {
+				return "", err
+			}
+			if forbidden {
+				for _, dependencyId := range dependencies[sha1] {
+					violations = append(violations, fmt.Sprintf("%s (%s)", dependencyId, repo))
+				}
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return "dependencies from forbidden repositories: " + strings.Join(violations, ", "), nil
+	}
+	return "", nil
+}
+
+func (e *Evaluator) checkIssueStatuses(buildInfo *buildinfo.BuildInfo) (string, error) {
+	if buildInfo.Issues == nil {
+		return "", nil
+	}
+	var violations []string
+	for _, issue := range buildInfo.Issues.AffectedIssues {
+		status, err := e.issueStatusResolver.ResolveStatus(issue.Key, issue.Url)
+		if err != nil {
+			return "", err
+		}
+		allowed := false
+		for _, allowedStatus := range e.policy.Rules.AllowedIssueStatuses {
+			if strings.EqualFold(allowedStatus, status) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, fmt.Sprintf("%s (%s)", issue.Key, status))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Sprintf("issues not in the allowed statuses [%s]: %s", strings.Join(e.policy.Rules.AllowedIssueStatuses, ", "), strings.Join(violations, ", ")), nil
+	}
+	return "", nil
+}
+
+func (e *Evaluator) checkBuildAge(buildInfo *buildinfo.BuildInfo) (string, error) {
+	maxAge, err := ParseAge(e.policy.Rules.MaxBuildAge)
+	if err != nil {
+		return "", err
+	}
+	started, err := time.Parse(buildinfo.TimeFormat, buildInfo.Started)
+	if err != nil {
+		return fmt.Sprintf("the build start time '%s' could not be parsed", buildInfo.Started), nil
+	}
+	if age := e.now().Sub(started); age > maxAge {
+		return fmt.Sprintf("the build started %s ago, which exceeds the maximal age of %s", age.Round(time.Minute), e.policy.Rules.MaxBuildAge), nil
+	}
+	return "", nil
+}
+
+func checkRequiredProperties(buildInfo *buildinfo.BuildInfo, requiredProperties map[string]string) (string, error) {
+	keys := make([]string, 0, len(requiredProperties))
+	for key := range requiredProperties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var violations []string
+	for _, key := range keys {
+		value, exists := buildInfo.Properties[key]
+		if !exists {
+			violations = append(violations, key+" is missing")
+			continue
+		}
+		pattern := requiredProperties[key]
+		if pattern == "" {
+			continue
+		}
+		matched, err := stringutils.MatchWildcardPattern(pattern, value)
+		if err != nil {
+			return "", err
+		}
+		if !matched {
+			violations = append(violations, fmt.Sprintf("%s=%s doesn't match %s", key, value, strconv.Quote(pattern)))
+		}
+	}
+	if len(violations) > 0 {
+		return "required properties: " + strings.Join(violations, ", "), nil
+	}
+	return "", nil
+}
+
+func matchAny(patterns []string, value string) (bool, error) {
+	for _, pattern := range patterns {
+		matched, err := stringutils.MatchWildcardPattern(pattern, value)
+		if err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
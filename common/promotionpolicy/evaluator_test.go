@@ -0,0 +1,137 @@
+package promotionpolicy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+rules:
+  requireVcsRevision: true
+  minModules: 2
+  forbiddenDependencyRepos: ["*-snapshot-local"]
+  allowedIssueStatuses: ["Done"]
+  maxBuildAge: 7d
+  requiredProperties:
+    buildInfo.env.CI: "tr*"
+    approver: ""
+`
+
+type mockReposResolver map[string][]string
+
+func (m mockReposResolver) ResolveRepositories(sha1s []string) (map[string][]string, error) {
+	return m, nil
+}
+
+type mockIssueStatusResolver map[string]string
+
+func (m mockIssueStatusResolver) ResolveStatus(issueKey, _ string) (string, error) {
+	return m[issueKey], nil
+}
+
+func TestEvaluate(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	now := time.Now()
+	newBuildInfo := func() *buildinfo.BuildInfo {
+		return &buildinfo.BuildInfo{
+			Name:    "build",
+			Number:  "1",
+			Started: now.Add(-48 * time.Hour).Format(buildinfo.TimeFormat),
+			VcsList: []buildinfo.Vcs{{Url: "https://github.com/jfrog/repo.git", Revision: "abc"}},
+			Modules: []buildinfo.Module{
+				{Id: "a", Dependencies: []buildinfo.Dependency{{Id: "dep-a", Checksum: buildinfo.Checksum{Sha1: "sha-a"}}}},
+				{Id: "b", Dependencies: []buildinfo.Dependency{{Id: "dep-b", Checksum: buildinfo.Checksum{Sha1: "sha-b"}}}},
+			},
+			Issues:     &buildinfo.Issues{AffectedIssues: []buildinfo.AffectedIssue{{Key: "JIRA-1"}}},
+			Properties: buildinfo.Env{"buildInfo.env.CI": "true", "approver": "me"},
+		}
+	}
+	newEvaluator := func() *Evaluator {
+		evaluator := NewEvaluator(policy).
+			SetDependencyRepositoriesResolver(mockReposResolver{"sha-a": {"libs-release-local"}, "sha-b": {"libs-remote"}}).
+			SetIssueStatusResolver(mockIssueStatusResolver{"JIRA-1": "done"})
+		evaluator.now = func() time.Time { return now }
+		return evaluator
+	}
+
+	report, err := newEvaluator().Evaluate(newBuildInfo())
+	require.NoError(t, err)
+	assert.Len(t, report.Results, 6)
+	assert.True(t, report.Passed())
+	assert.NoError(t, report.Error())
+
+	failing := newBuildInfo()
+	failing.VcsList = nil
+	failing.Modules = failing.Modules[:1]
+	failing.Started = now.Add(-8 * 24 * time.Hour).Format(buildinfo.TimeFormat)
+	failing.Properties = buildinfo.Env{"buildInfo.env.CI": "false"}
+	evaluator := newEvaluator().
+		SetDependencyRepositoriesResolver(mockReposResolver{"sha-a": {"libs-snapshot-local"}}).
+		SetIssueStatusResolver(mockIssueStatusResolver{"JIRA-1": "In Progress"})
+	report, err = evaluator.Evaluate(failing)
+	require.NoError(t, err)
+	assert.False(t, report.Passed())
+	failed := make(map[string]string)
+	for _, result := range report.FailedResults() {
+		failed[result.Rule] = result.Message
+	}
+	assert.Len(t, failed, 6)
+	assert.Contains(t, failed[ForbiddenDependencyReposRule], "dep-a (libs-snapshot-local)")
+	assert.Contains(t, failed[AllowedIssueStatusesRule], "JIRA-1 (In Progress)")
+	assert.Contains(t, failed[RequiredPropertiesRule], "approver is missing")
+	assert.ErrorContains(t, report.Error(), "6 rules failed")
+}
+
+func TestEvaluateMissingResolver(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	_, err = NewEvaluator(policy).Evaluate(&buildinfo.BuildInfo{})
+	assert.ErrorContains(t, err, ForbiddenDependencyReposRule)
+}
+
+func TestParsePolicy(t *testing.T) {
+	_, err := ParsePolicy([]byte("rules:\n  maxBuildAge: week"))
+	assert.Error(t, err)
+	_, err = ParsePolicy([]byte("rules:\n  minModules: -1"))
+	assert.Error(t, err)
+	_, err = ParsePolicy([]byte("rules:\n  requireVcsRevison: true"))
+	assert.ErrorContains(t, err, "field requireVcsRevison not found")
+	_, err = ParsePolicy(nil)
+	assert.NoError(t, err)
+
+	age, err := ParseAge("2d")
+	assert.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, age)
+	age, err = ParseAge("90m")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, age)
+}
+
+func TestJiraIssueStatusResolver(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, token, _ := r.BasicAuth()
+		if r.URL.Path != "/rest/api/2/issue/JIRA-1" || username != "user" || token != "token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write([]byte(`{"key":"JIRA-1","fields":{"status":{"name":"Done"}}}`))
+		assert.NoError(t, err)
+	}))
+	defer testServer.Close()
+
+	t.Setenv("TEST_JIRA_TOKEN", "token")
+	policy := &Policy{IssueTracker: &IssueTracker{Url: testServer.URL + "/", Username: "user", TokenEnv: "TEST_JIRA_TOKEN"}}
+	resolver := NewEvaluator(policy).issueStatusResolver
+	status, err := resolver.ResolveStatus("JIRA-1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "Done", status)
+	_, err = resolver.ResolveStatus("JIRA-2", "")
+	assert.Error(t, err)
+}
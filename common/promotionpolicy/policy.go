@@ -0,0 +1,108 @@
+package promotionpolicy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+// A promotion policy, evaluated against the build-info before a build or a release bundle is promoted.
+// Example policy file:
+//
+//	rules:
+//	  requireVcsRevision: true
+//	  minModules: 2
+//	  forbiddenDependencyRepos: ["*-snapshot-local", "untrusted-remote"]
+//	  allowedIssueStatuses: ["Done", "Closed"]
+//	  maxBuildAge: 7d
+//	  requiredProperties:
+//	    buildInfo.env.CI: "true"
+//	    release.approver: ""
+//	issueTracker:
+//	  url: https://company.atlassian.net
+//	  username: ci-user
+//	  tokenEnv: JIRA_TOKEN
+type Policy struct {
+	Rules Rules `yaml:"rules"`
+	// The Jira server used to resolve the issues' statuses. Required by the allowedIssueStatuses rule.
+	IssueTracker *IssueTracker `yaml:"issueTracker,omitempty"`
+}
+
+type IssueTracker struct {
+	Url      string `yaml:"url"`
+	Username string `yaml:"username,omitempty"`
+	// The name of the environment variable holding the API token. The token itself is not kept in the policy file.
+	TokenEnv string `yaml:"tokenEnv,omitempty"`
+}
+
+type Rules struct {
+	// The build-info must contain at least one VCS entry with a revision.
+	RequireVcsRevision bool `yaml:"requireVcsRevision,omitempty"`
+	// The minimal number of modules in the build-info.
+	MinModules int `yaml:"minModules,omitempty"`
+	// Wildcard patterns of repositories, from which dependencies must not be resolved.
+	ForbiddenDependencyRepos []string `yaml:"forbiddenDependencyRepos,omitempty"`
+	// All the issues of the build-info must be in one of these statuses.
+	AllowedIssueStatuses []string `yaml:"allowedIssueStatuses,omitempty"`
+	// The maximal time since the build started, such as 12h or 7d.
+	MaxBuildAge string `yaml:"maxBuildAge,omitempty"`
+	// Properties which the build-info must contain. The values are wildcard patterns, and an empty value matches any value.
+	RequiredProperties map[string]string `yaml:"requiredProperties,omitempty"`
+}
+
+func LoadPolicy(policyFilePath string) (*Policy, error) {
+	content, err := os.ReadFile(policyFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParsePolicy(content)
+}
+
+func ParsePolicy(content []byte) (*Policy, error) {
+	policy := new(Policy)
+	// Unknown keys are rejected, since a misspelled rule would otherwise be ignored, and the promotion would pass.
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errorutils.CheckErrorf("failed to parse the promotion policy: %s", err.Error())
+	}
+	return policy, policy.Validate()
+}
+
+func (p *Policy) Validate() error {
+	if p.Rules.MinModules < 0 {
+		return errorutils.CheckErrorf("the promotion policy 'minModules' rule must not be negative")
+	}
+	if p.IssueTracker != nil && p.IssueTracker.Url == "" {
+		return errorutils.CheckErrorf("the promotion policy 'issueTracker' must include a url")
+	}
+	if p.Rules.MaxBuildAge != "" {
+		if _, err := ParseAge(p.Rules.MaxBuildAge); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parses a duration, which in addition to the time.ParseDuration units, may be expressed in days, such as 7d.
+func ParseAge(age string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(age, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, errorutils.CheckErrorf("invalid age '%s'", age)
+		}
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+	duration, err := time.ParseDuration(age)
+	if err != nil || duration < 0 {
+		return 0, errorutils.CheckErrorf("invalid age '%s'. The age should be expressed in days (7d) or as a duration (12h)", age)
+	}
+	return duration, nil
+}
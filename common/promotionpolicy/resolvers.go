@@ -0,0 +1,139 @@
+package promotionpolicy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	servicesutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	// The number of checksums searched by a single AQL query.
+	aqlChecksumsBatchSize = 100
+	// The timeout of a request to the issue tracker, so that an unresponsive issue tracker doesn't block the promotion.
+	issueTrackerRequestTimeout = 30 * time.Second
+)
+
+// Resolves the repositories in which dependencies are stored.
+// The build-info doesn't record the repository a dependency was resolved from, so it is resolved by the dependency checksum.
+type DependencyRepositoriesResolver interface {
+	// Returns a map from each sha1 checksum to the repositories which contain a file with this checksum.
+	ResolveRepositories(sha1s []string) (map[string][]string, error)
+}
+
+// Resolves the current status of issues in the issue tracker.
+type IssueStatusResolver interface {
+	ResolveStatus(issueKey, issueUrl string) (string, error)
+}
+
+// Resolves the dependencies' repositories using AQL.
+type AqlDependencyRepositoriesResolver struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+}
+
+func NewAqlDependencyRepositoriesResolver(servicesManager artifactory.ArtifactoryServicesManager) *AqlDependencyRepositoriesResolver {
+	return &AqlDependencyRepositoriesResolver{servicesManager: servicesManager}
+}
+
+func (adr *AqlDependencyRepositoriesResolver) ResolveRepositories(sha1s []string) (map[string][]string, error) {
+	repositories := make(map[string][]string)
+	for start := 0; start < len(sha1s); start += aqlChecksumsBatchSize {
+		end := min(start+aqlChecksumsBatchSize, len(sha1s))
+		results, err := adr.searchChecksums(sha1s[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			repositories[result.Actual_Sha1] = appendIfMissing(repositories[result.Actual_Sha1], result.Repo)
+		}
+	}
+	return repositories, nil
+}
+
+func (adr *AqlDependencyRepositoriesResolver) searchChecksums(sha1s []string) (results []servicesutils.ResultItem, err error) {
+	conditions := make([]string, 0, len(sha1s))
+	for _, sha1 := range sha1s {
+		conditions = append(conditions, fmt.Sprintf(`{"actual_sha1":"%s"}`, sha1))
+	}
+	query := fmt.Sprintf(`items.find({"$or":[%s]}).include("repo","actual_sha1")`, strings.Join(conditions, ","))
+	reader, err := adr.servicesManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(reader.Close()))
+	}()
+	respBody, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	result := &servicesutils.AqlSearchResult{}
+	if err = json.Unmarshal(respBody, result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
+
+// Resolves the issues' statuses using the Jira REST API.
+type JiraIssueStatusResolver struct {
+	url      string
+	username string
+	token    string
+	client   *http.Client
+}
+
+func NewJiraIssueStatusResolver(jiraUrl, username, token string) *JiraIssueStatusResolver {
+	return &JiraIssueStatusResolver{url: strings.TrimSuffix(jiraUrl, "/"), username: username, token: token, client: &http.Client{Timeout: issueTrackerRequestTimeout}}
+}
+
+func (jir *JiraIssueStatusResolver) ResolveStatus(issueKey, _ string) (status string, err error) {
+	req, err := http.NewRequest(http.MethodGet, jir.url+"/rest/api/2/issue/"+url.PathEscape(issueKey)+"?fields=status", nil)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if jir.token != "" {
+		req.SetBasicAuth(jir.username, jir.token)
+	}
+	resp, err := jir.client.Do(req)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(resp.Body.Close()))
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errorutils.CheckErrorf("failed to get the status of issue %s from Jira. Status: %s, response: %s", issueKey, resp.Status, string(body))
+	}
+	issue := struct {
+		Fields struct {
+			Status struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}{}
+	if err = json.Unmarshal(body, &issue); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return issue.Fields.Status.Name, nil
+}
+
+func appendIfMissing(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -37,11 +37,17 @@ func (distributionRule *DistributionRule) IsEmpty() bool {
 	return distributionRule.SiteName == "" && distributionRule.CityName == "" && len(distributionRule.CountryCodes) == 0
 }
 
+// Reads the distribution rules from a JSON or YAML file, by its extension.
 func CreateDistributionRulesFromFile(distributionSpecPath string) (*DistributionRules, error) {
 	content, err := fileutils.ReadFile(distributionSpecPath)
 	if err != nil {
 		return nil, errorutils.CheckError(err)
 	}
+	if isYamlFile(distributionSpecPath) {
+		if content, _, err = convertYamlToJson(content); err != nil {
+			return nil, err
+		}
+	}
 	distributionRules := new(DistributionRules)
 	err = json.Unmarshal(content, distributionRules)
 	if err != nil {
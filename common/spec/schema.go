@@ -0,0 +1,297 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// The values of the boolean options of the spec, which are strings parsed by strconv.ParseBool. An empty value means the default.
+var specBoolValues = []string{"", "true", "false", "True", "False", "TRUE", "FALSE", "t", "f", "T", "F", "1", "0"}
+
+// The allowed values of string options, by their names. An empty value means the option isn't set.
+var specEnumValues = map[string][]string{
+	"sortOrder": {"", "asc", "desc"},
+	"archive":   {"", "zip"},
+}
+
+// The subset of JSON Schema which describes specs.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Minimum              *int                   `json:"minimum,omitempty"`
+	Definitions          map[string]*jsonSchema `json:"definitions,omitempty"`
+}
+
+// Returns the JSON Schema of file specs, generated from the File struct, to be used by editors for completion and validation.
+func FileSpecSchema() ([]byte, error) {
+	content, err := json.MarshalIndent(getFileSpecSchema(), "", "  ")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return append(content, '\n'), nil
+}
+
+func getFileSpecSchema() *jsonSchema {
+	noAdditionalProperties := false
+	return &jsonSchema{
+		Schema: jsonSchemaDraft,
+		Title:  "JFrog CLI File Spec",
+		Type:   "object",
+		Properties: map[string]*jsonSchema{
+			// The schema which editors validate the spec file against.
+			"$schema": {Type: "string"},
+			"files":   {Type: "array", Items: &jsonSchema{Ref: "#/definitions/file"}},
+			"include": {Type: "array", Items: &jsonSchema{Type: "string"}},
+		},
+		AdditionalProperties: &noAdditionalProperties,
+		Definitions:          map[string]*jsonSchema{"file": getFileSchema()},
+	}
+}
+
+func getFileSchema() *jsonSchema {
+	noAdditionalProperties := false
+	schema := &jsonSchema{
+		Type: "object",
+		// The condition of a spec file template.
+		Properties:           map[string]*jsonSchema{"when": {Type: "string"}},
+		AdditionalProperties: &noAdditionalProperties,
+	}
+	fileType := reflect.TypeOf(File{})
+	for i := 0; i < fileType.NumField(); i++ {
+		field := fileType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := getSchemaPropertyName(field)
+		switch {
+		case field.Name == "Aql":
+			// The AQL query is kept as raw JSON, so it can't be described by its struct.
+			schema.Properties[name] = &jsonSchema{
+				Type:                 "object",
+				Properties:           map[string]*jsonSchema{"items.find": {Type: "object"}},
+				Required:             []string{"items.find"},
+				AdditionalProperties: &noAdditionalProperties,
+			}
+		case field.Type.Kind() == reflect.String:
+			schema.Properties[name] = getStringSchema(fileType, field.Name, name)
+		default:
+			schema.Properties[name] = getTypeSchema(field.Type)
+		}
+	}
+	return schema
+}
+
+// The options which have an 'Is<Option>' method are booleans.
+func getStringSchema(fileType reflect.Type, fieldName, propertyName string) *jsonSchema {
+	if _, isBool := fileType.MethodByName("Is" + fieldName); isBool {
+		return &jsonSchema{Type: "string", Enum: specBoolValues}
+	}
+	return &jsonSchema{Type: "string", Enum: specEnumValues[propertyName]}
+}
+
+func getTypeSchema(fieldType reflect.Type) *jsonSchema {
+	switch fieldType.Kind() {
+	case reflect.Int:
+		minimum := 0
+		return &jsonSchema{Type: "integer", Minimum: &minimum}
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: getTypeSchema(fieldType.Elem())}
+	case reflect.Struct:
+		noAdditionalProperties := false
+		schema := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}, AdditionalProperties: &noAdditionalProperties}
+		for i := 0; i < fieldType.NumField(); i++ {
+			schema.Properties[getSchemaPropertyName(fieldType.Field(i))] = getTypeSchema(fieldType.Field(i).Type)
+		}
+		return schema
+	}
+	return &jsonSchema{Type: "string"}
+}
+
+// Returns the name of the JSON tag of the field, or its name in lower camel case.
+func getSchemaPropertyName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+	runes := []rune(field.Name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// A violation of the schema, at the path of the invalid value in the spec.
+type schemaError struct {
+	path    []any
+	message string
+}
+
+func (e *schemaError) Error() string {
+	if len(e.path) == 0 {
+		return e.message
+	}
+	return e.formatPath() + ": " + e.message
+}
+
+// Formats the path as in 'files[1].flat'.
+func (e *schemaError) formatPath() string {
+	builder := new(strings.Builder)
+	for _, element := range e.path {
+		if index, ok := element.(int); ok {
+			builder.WriteString(fmt.Sprintf("[%d]", index))
+			continue
+		}
+		if builder.Len() > 0 {
+			builder.WriteString(".")
+		}
+		builder.WriteString(element.(string))
+	}
+	return builder.String()
+}
+
+// Returns the index of the file group of the invalid value, or -1 if it's not in a file group.
+func (e *schemaError) fileGroupIndex() int {
+	if len(e.path) > 1 {
+		if key, ok := e.path[0].(string); ok && strings.EqualFold(key, "files") {
+			if index, ok := e.path[1].(int); ok {
+				return index
+			}
+		}
+	}
+	return -1
+}
+
+// Validates a value decoded with json.Decoder.UseNumber against the schema.
+// Property names are matched case-insensitively, and null values are ignored, as json.Unmarshal does.
+func validateSchema(value any, schema, root *jsonSchema, path []any) *schemaError {
+	if value == nil {
+		return nil
+	}
+	if strings.HasPrefix(schema.Ref, "#/definitions/") {
+		schema = root.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+	}
+	newError := func(format string, args ...any) *schemaError {
+		return &schemaError{path: slices.Clone(path), message: fmt.Sprintf(format, args...)}
+	}
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return newError("expected an object")
+		}
+		for _, required := range schema.Required {
+			if _, found := object[required]; !found {
+				return newError("missing the required property '%s'", required)
+			}
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			propertySchema := getPropertySchema(schema, key)
+			if propertySchema == nil {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					return &schemaError{path: append(slices.Clone(path), key), message: "unknown property"}
+				}
+				continue
+			}
+			if err := validateSchema(object[key], propertySchema, root, append(path, key)); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return newError("expected an array")
+		}
+		for i, item := range array {
+			if err := validateSchema(item, schema.Items, root, append(path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return newError("expected a string")
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, str) {
+			return newError("invalid value '%s'. Expected one of: %s", str, strings.Join(slices.DeleteFunc(slices.Clone(schema.Enum), func(s string) bool { return s == "" }), ", "))
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return newError("expected an integer")
+		}
+		integer, err := strconv.Atoi(number.String())
+		if err != nil {
+			return newError("expected an integer")
+		}
+		if schema.Minimum != nil && integer < *schema.Minimum {
+			return newError("expected an integer of at least %d", *schema.Minimum)
+		}
+	}
+	return nil
+}
+
+// Returns the properties which the schema doesn't allow, ignoring the other violations of the schema.
+func getUnknownProperties(value any, schema, root *jsonSchema, path []any) (unknownProperties []*schemaError) {
+	if strings.HasPrefix(schema.Ref, "#/definitions/") {
+		schema = root.Definitions[strings.TrimPrefix(schema.Ref, "#/definitions/")]
+	}
+	switch typedValue := value.(type) {
+	case map[string]any:
+		if schema.Type != "object" {
+			return nil
+		}
+		keys := make([]string, 0, len(typedValue))
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			propertySchema := getPropertySchema(schema, key)
+			if propertySchema == nil {
+				if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+					unknownProperties = append(unknownProperties, &schemaError{path: append(slices.Clone(path), key), message: "unknown property"})
+				}
+				continue
+			}
+			unknownProperties = append(unknownProperties, getUnknownProperties(typedValue[key], propertySchema, root, append(path, key))...)
+		}
+	case []any:
+		if schema.Type != "array" {
+			return nil
+		}
+		for i, item := range typedValue {
+			unknownProperties = append(unknownProperties, getUnknownProperties(item, schema.Items, root, append(path, i))...)
+		}
+	}
+	return
+}
+
+func getPropertySchema(schema *jsonSchema, key string) *jsonSchema {
+	if propertySchema, ok := schema.Properties[key]; ok {
+		return propertySchema
+	}
+	for name, propertySchema := range schema.Properties {
+		if strings.EqualFold(name, key) {
+			return propertySchema
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package spec
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fileSpecSchemaPath = "filespec.schema.json"
+
+var updateSchema = flag.Bool("update-schema", false, "Regenerate "+fileSpecSchemaPath+" from the File struct")
+
+// The published schema must match the File struct. Run 'go test ./common/spec -run TestFileSpecSchemaUpToDate -update-schema' after changing it.
+func TestFileSpecSchemaUpToDate(t *testing.T) {
+	schema, err := FileSpecSchema()
+	require.NoError(t, err)
+	if *updateSchema {
+		require.NoError(t, os.WriteFile(fileSpecSchemaPath, schema, 0644))
+	}
+	published, err := os.ReadFile(fileSpecSchemaPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(schema), string(published), "the published schema is outdated")
+}
+
+func TestFileSpecSchemaProperties(t *testing.T) {
+	fileSchema := getFileSpecSchema().Definitions["file"]
+	assert.Equal(t, specBoolValues, fileSchema.Properties["flat"].Enum)
+	assert.Equal(t, specBoolValues, fileSchema.Properties["recursive"].Enum)
+	assert.Equal(t, specBoolValues, fileSchema.Properties["bypassArchiveInspection"].Enum)
+	assert.Empty(t, fileSchema.Properties["pattern"].Enum)
+	assert.Equal(t, []string{"", "asc", "desc"}, fileSchema.Properties["sortOrder"].Enum)
+	assert.Equal(t, "array", fileSchema.Properties["sortBy"].Type)
+	assert.Equal(t, "integer", fileSchema.Properties["limit"].Type)
+	assert.Contains(t, fileSchema.Properties, "gpg-key")
+	assert.Contains(t, fileSchema.Properties["pathMapping"].Properties, "input")
+	assert.NotContains(t, fileSchema.Properties, "include")
+}
+
+func TestValidateSpecSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"valid", `{"$schema":"filespec.schema.json","files":[{"Pattern":"a/*","flat":"True","limit":3,"target":null},{"aql":{"items.find":{"repo":"a"}}}]}`, ""},
+		{"unknown top level", `{"file":[]}`, "spec.json: file: unknown property"},
+		{"unknown property", "{\"files\":[\n{\"pattern\":\"a/*\"},\n{\"patern\":\"a/*\"}]}", "spec.json:3: files[1].patern: unknown property"},
+		{"boolean", `{"files":[{"pattern":"a/*","flat":"yes"}]}`, "files[0].flat: invalid value 'yes'. Expected one of: true, false"},
+		{"enum", `{"files":[{"pattern":"a/*","sortBy":["name"],"sortOrder":"up"}]}`, "files[0].sortOrder: invalid value 'up'. Expected one of: asc, desc"},
+		{"integer", `{"files":[{"pattern":"a/*","offset":-1}]}`, "files[0].offset: expected an integer of at least 0"},
+		{"array", `{"files":[{"pattern":"a/*","exclusions":"b"}]}`, "files[0].exclusions: expected an array"},
+		{"aql", `{"files":[{"aql":{}}]}`, "files[0].aql: missing the required property 'items.find'"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			specFilePath := writeSpecFile(t, t.TempDir(), "spec.json", test.content)
+			err := ValidateSpecFile(specFilePath, nil, false, true)
+			if test.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, filepath.Dir(specFilePath))
+				assert.ErrorContains(t, err, test.expected)
+			}
+		})
+	}
+}
+
+// Loading a spec file only warns about unknown properties, which are ignored.
+func TestCreateSpecFromFileUnknownProperties(t *testing.T) {
+	specFilePath := writeSpecFile(t, t.TempDir(), "spec.json", `{"$schema":"filespec.schema.json","comment":"a","files":[{"pattern":"a/*","patern":"b/*"}]}`)
+	spec, err := CreateSpecFromFile(specFilePath, nil)
+	require.NoError(t, err)
+	require.Len(t, spec.Files, 1)
+	assert.Equal(t, "a/*", spec.Files[0].Pattern)
+
+	var value any
+	require.NoError(t, json.Unmarshal([]byte(`{"$schema":"a","comment":"a","files":[{"pattern":"a/*","patern":"b/*","flat":"yes"}]}`), &value))
+	schema := getFileSpecSchema()
+	var unknownProperties []string
+	for _, unknownProperty := range getUnknownProperties(value, schema, schema, nil) {
+		unknownProperties = append(unknownProperties, unknownProperty.Error())
+	}
+	assert.Equal(t, []string{"comment: unknown property", "files[0].patern: unknown property"}, unknownProperties)
+}
+
+func TestCreateSpecFromYamlFile(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "base.json", `{"files":[{"pattern":"libs/base/*"}]}`)
+	specFilePath := writeSpecFile(t, dir, "spec.yaml", `include:
+  - base.json
+files:
+  - pattern: ${repo:-libs}/a/*
+    target: out/
+    flat: true
+    limit: 10
+    aql:
+      items.find:
+        repo: libs
+  - pattern: libs/b/*
+    when: "false"
+`)
+	spec, err := CreateSpecFromFile(specFilePath, nil)
+	require.NoError(t, err)
+	require.Len(t, spec.Files, 2)
+	assert.Equal(t, "libs/base/*", spec.Files[0].Pattern)
+	assert.Equal(t, "libs/a/*", spec.Files[1].Pattern)
+	assert.Equal(t, "true", spec.Files[1].Flat)
+	assert.Equal(t, 10, spec.Files[1].Limit)
+	assert.JSONEq(t, `{"repo":"libs"}`, spec.Files[1].Aql.ItemsFind)
+
+	// Errors point to the line of the file group.
+	specFilePath = writeSpecFile(t, dir, "invalid.yml", `files:
+  - pattern: libs/a/*
+  - pattern: libs/b/*
+    recursive: maybe
+`)
+	err = ValidateSpecFile(specFilePath, nil, false, false)
+	assert.EqualError(t, err, specFilePath+":3: files[1].recursive: invalid value 'maybe'. Expected one of: true, false, True, False, TRUE, FALSE, t, f, T, F, 1, 0")
+}
+
+func TestCreateDistributionRulesFromYamlFile(t *testing.T) {
+	rulesPath := writeSpecFile(t, t.TempDir(), "rules.yaml", `distribution_rules:
+  - site_name: "*"
+    country_codes: ["US", "IL"]
+`)
+	rules, err := CreateDistributionRulesFromFile(rulesPath)
+	require.NoError(t, err)
+	assert.Equal(t, []DistributionRule{{SiteName: "*", CountryCodes: []string{"US", "IL"}}}, rules.DistributionRules)
+}
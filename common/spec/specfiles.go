@@ -1,13 +1,9 @@
 package spec
 
 import (
-	"encoding/json"
-
-	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/utils"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
-	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
 )
 
 type SpecFiles struct {
@@ -21,22 +17,17 @@ func (spec *SpecFiles) Get(index int) *File {
 	return new(File)
 }
 
-func CreateSpecFromFile(specFilePath string, specVars map[string]string) (spec *SpecFiles, err error) {
-	spec = new(SpecFiles)
-	content, err := fileutils.ReadFile(specFilePath)
-	if errorutils.CheckError(err) != nil {
-		return
-	}
-
-	if len(specVars) > 0 {
-		content = coreutils.ReplaceVars(content, specVars)
+// Creates a spec from a spec file template. See loadSpecFileGroups for the supported templating.
+func CreateSpecFromFile(specFilePath string, specVars map[string]string) (*SpecFiles, error) {
+	groups, err := loadSpecFileGroups(specFilePath, specVars, false)
+	if err != nil {
+		return nil, err
 	}
-
-	err = json.Unmarshal(content, spec)
-	if errorutils.CheckError(err) != nil {
-		return
+	spec := new(SpecFiles)
+	for _, group := range groups {
+		spec.Files = append(spec.Files, group.file)
 	}
-	return
+	return spec, nil
 }
 
 type File struct {
@@ -170,111 +161,117 @@ func ValidateSpec(files []File, isTargetMandatory, isSearchBasedSpec bool) error
 	if len(files) == 0 {
 		return errorutils.CheckErrorf("spec must include at least one file group")
 	}
-
 	for _, file := range files {
-		isAql := len(file.Aql.ItemsFind) > 0
-		isPathMapping := len(file.PathMapping.Input) > 0 && len(file.PathMapping.Output) > 0
-		isPattern := len(file.Pattern) > 0
-		isExclusions := len(file.Exclusions) > 0 && len(file.Exclusions[0]) > 0
-		isTarget := len(file.Target) > 0
-		isSortOrder := len(file.SortOrder) > 0
-		isSortBy := len(file.SortBy) > 0
-		isBuild := len(file.Build) > 0
-		isExcludeArtifacts, _ := file.IsExcludeArtifacts(false)
-		isIncludeDeps, _ := file.IsIncludeDeps(false)
-		isBundle := len(file.Bundle) > 0
-		isGPGKey := len(file.PublicGpgKey) > 0
-		isOffset := file.Offset > 0
-		isLimit := file.Limit > 0
-		isValidSortOrder := file.SortOrder == "asc" || file.SortOrder == "desc"
-		isExcludeProps := len(file.ExcludeProps) > 0
-		isArchive := len(file.Archive) > 0
-		isValidArchive := file.Archive == "zip"
-		isSymlinks, _ := file.IsSymlinks(false)
-		isRegexp := file.Regexp == "true"
-		isAnt := file.Ant == "true"
-		isExplode, _ := file.IsExplode(false)
-		isBypassArchiveInspection, _ := file.IsBypassArchiveInspection(false)
-		isTransitive, _ := file.IsTransitive(false)
-		if isPathMapping {
-			if !isAql {
-				return errorutils.CheckErrorf("pathMapping is supported only with aql")
-			}
-			if isTarget {
-				return fileSpecValidationError("pathMapping", "target")
-			}
-			if isPattern {
-				return fileSpecValidationError("pathMapping", "pattern")
-			}
-		}
-		if isTargetMandatory && !isTarget {
-			return errorutils.CheckErrorf("spec must include target")
-		}
-		if !isSearchBasedSpec && !isPattern {
-			return errorutils.CheckErrorf("spec must include a pattern")
-		}
-		if isBuild && isBundle {
-			return fileSpecValidationError("build", "bundle")
-		}
-		if isSearchBasedSpec && !isAql && !isPattern && !isBuild && !isBundle {
-			return errorutils.CheckErrorf("spec must include either aql, pattern, build or bundle")
+		if err := validateFile(file, isTargetMandatory, isSearchBasedSpec); err != nil {
+			return err
 		}
-		if isOffset {
-			if isBuild {
-				return fileSpecValidationError("build", "offset")
-			}
-			if isBundle {
-				return fileSpecValidationError("bundle", "offset")
-			}
-		}
-		if isTransitive && isOffset {
-			return fileSpecValidationError("transitive", "offset")
-		}
-		if isLimit {
-			if isBuild {
-				return fileSpecValidationError("build", "limit")
-			}
-			if isBundle {
-				return fileSpecValidationError("bundle", "limit")
-			}
-		}
-		if isAql && isPattern {
-			return fileSpecValidationError("aql", "pattern")
-		}
-		if isAql && isExclusions {
-			return fileSpecValidationError("aql", "exclusions")
-		}
-		if isAql && isExcludeProps {
-			return fileSpecValidationError("aql", "excludeProps")
-		}
-		if !isSortBy && isSortOrder {
-			return errorutils.CheckErrorf("spec cannot include 'sort-order' if 'sort-by' is not included")
-		}
-		if isSortOrder && !isValidSortOrder {
-			return errorutils.CheckErrorf("the value of 'sort-order' can only be 'asc' or 'desc'")
-		}
-		if isTransitive && isSortBy {
-			return fileSpecValidationError("transitive", "sort-by")
+	}
+	return nil
+}
+
+func validateFile(file File, isTargetMandatory, isSearchBasedSpec bool) error {
+	isAql := len(file.Aql.ItemsFind) > 0
+	isPathMapping := len(file.PathMapping.Input) > 0 && len(file.PathMapping.Output) > 0
+	isPattern := len(file.Pattern) > 0
+	isExclusions := len(file.Exclusions) > 0 && len(file.Exclusions[0]) > 0
+	isTarget := len(file.Target) > 0
+	isSortOrder := len(file.SortOrder) > 0
+	isSortBy := len(file.SortBy) > 0
+	isBuild := len(file.Build) > 0
+	isExcludeArtifacts, _ := file.IsExcludeArtifacts(false)
+	isIncludeDeps, _ := file.IsIncludeDeps(false)
+	isBundle := len(file.Bundle) > 0
+	isGPGKey := len(file.PublicGpgKey) > 0
+	isOffset := file.Offset > 0
+	isLimit := file.Limit > 0
+	isValidSortOrder := file.SortOrder == "asc" || file.SortOrder == "desc"
+	isExcludeProps := len(file.ExcludeProps) > 0
+	isArchive := len(file.Archive) > 0
+	isValidArchive := file.Archive == "zip"
+	isSymlinks, _ := file.IsSymlinks(false)
+	isRegexp := file.Regexp == "true"
+	isAnt := file.Ant == "true"
+	isExplode, _ := file.IsExplode(false)
+	isBypassArchiveInspection, _ := file.IsBypassArchiveInspection(false)
+	isTransitive, _ := file.IsTransitive(false)
+	if isPathMapping {
+		if !isAql {
+			return errorutils.CheckErrorf("pathMapping is supported only with aql")
 		}
-		if !isBuild && (isExcludeArtifacts || isIncludeDeps) {
-			return errorutils.CheckErrorf("spec cannot include 'exclude-artifacts' or 'include-deps' if 'build' is not included")
+		if isTarget {
+			return fileSpecValidationError("pathMapping", "target")
 		}
-		if isRegexp && isAnt {
-			return errorutils.CheckErrorf("can not use the option of regexp and ant together")
+		if isPattern {
+			return fileSpecValidationError("pathMapping", "pattern")
 		}
-		if isArchive && isSymlinks && isExplode {
-			return errorutils.CheckErrorf("symlinks cannot be stored in an archive that will be exploded in artifactory.\\nWhen uploading a symlink to Artifactory, the symlink is represented in Artifactory as 0 size filewith properties describing the symlink.\\nThis symlink representation is not yet supported by Artifactory when exploding symlinks from a zip")
+	}
+	if isTargetMandatory && !isTarget {
+		return errorutils.CheckErrorf("spec must include target")
+	}
+	if !isSearchBasedSpec && !isPattern {
+		return errorutils.CheckErrorf("spec must include a pattern")
+	}
+	if isBuild && isBundle {
+		return fileSpecValidationError("build", "bundle")
+	}
+	if isSearchBasedSpec && !isAql && !isPattern && !isBuild && !isBundle {
+		return errorutils.CheckErrorf("spec must include either aql, pattern, build or bundle")
+	}
+	if isOffset {
+		if isBuild {
+			return fileSpecValidationError("build", "offset")
 		}
-		if isArchive && !isValidArchive {
-			return errorutils.CheckErrorf("the value of 'archive' (if provided) must be 'zip'")
+		if isBundle {
+			return fileSpecValidationError("bundle", "offset")
 		}
-		if isGPGKey && !isBundle {
-			return errorutils.CheckErrorf("spec cannot include 'gpg-key' if 'bundle' is not included")
+	}
+	if isTransitive && isOffset {
+		return fileSpecValidationError("transitive", "offset")
+	}
+	if isLimit {
+		if isBuild {
+			return fileSpecValidationError("build", "limit")
 		}
-		if isBypassArchiveInspection && !isExplode {
-			return errorutils.CheckErrorf("spec cannot include 'bypass-archive-inspection' if 'explode' is not included")
+		if isBundle {
+			return fileSpecValidationError("bundle", "limit")
 		}
 	}
+	if isAql && isPattern {
+		return fileSpecValidationError("aql", "pattern")
+	}
+	if isAql && isExclusions {
+		return fileSpecValidationError("aql", "exclusions")
+	}
+	if isAql && isExcludeProps {
+		return fileSpecValidationError("aql", "excludeProps")
+	}
+	if !isSortBy && isSortOrder {
+		return errorutils.CheckErrorf("spec cannot include 'sort-order' if 'sort-by' is not included")
+	}
+	if isSortOrder && !isValidSortOrder {
+		return errorutils.CheckErrorf("the value of 'sort-order' can only be 'asc' or 'desc'")
+	}
+	if isTransitive && isSortBy {
+		return fileSpecValidationError("transitive", "sort-by")
+	}
+	if !isBuild && (isExcludeArtifacts || isIncludeDeps) {
+		return errorutils.CheckErrorf("spec cannot include 'exclude-artifacts' or 'include-deps' if 'build' is not included")
+	}
+	if isRegexp && isAnt {
+		return errorutils.CheckErrorf("can not use the option of regexp and ant together")
+	}
+	if isArchive && isSymlinks && isExplode {
+		return errorutils.CheckErrorf("symlinks cannot be stored in an archive that will be exploded in artifactory.\\nWhen uploading a symlink to Artifactory, the symlink is represented in Artifactory as 0 size filewith properties describing the symlink.\\nThis symlink representation is not yet supported by Artifactory when exploding symlinks from a zip")
+	}
+	if isArchive && !isValidArchive {
+		return errorutils.CheckErrorf("the value of 'archive' (if provided) must be 'zip'")
+	}
+	if isGPGKey && !isBundle {
+		return errorutils.CheckErrorf("spec cannot include 'gpg-key' if 'bundle' is not included")
+	}
+	if isBypassArchiveInspection && !isExplode {
+		return errorutils.CheckErrorf("spec cannot include 'bypass-archive-inspection' if 'explode' is not included")
+	}
 	return nil
 }
 
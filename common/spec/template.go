@@ -0,0 +1,340 @@
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// Matches ${var} and ${var:-default}.
+var specVarRegexp = regexp.MustCompile(`\$\{([^{}:]+)(?::-([^{}]*))?\}`)
+
+// The top level of a spec file template. The file groups of the included spec files precede the file groups of the including spec file.
+type specTemplate struct {
+	Include []string
+	Files   []json.RawMessage
+}
+
+// A file group of a spec file, with its location in the spec file.
+type specFileGroup struct {
+	raw    json.RawMessage
+	file   File
+	source string
+	line   int
+}
+
+func (group *specFileGroup) location() string {
+	return fmt.Sprintf("%s:%d", group.source, group.line)
+}
+
+// Reads a JSON or YAML spec file template and returns its file groups, after replacing the variables, including the included spec files
+// and dropping the file groups which their 'when' condition is false.
+// The variables are replaced by the spec vars, the environment variables or their default values, in this order.
+// Variables which aren't resolved remain as they are.
+// In strict mode, the spec files are validated against the file spec schema. Otherwise, their unknown properties are logged as warnings.
+func loadSpecFileGroups(specFilePath string, specVars map[string]string, strict bool) ([]*specFileGroup, error) {
+	return loadSpecFileGroupsRecursively(specFilePath, specVars, strict, nil)
+}
+
+func loadSpecFileGroupsRecursively(specFilePath string, specVars map[string]string, strict bool, includingFiles []string) ([]*specFileGroup, error) {
+	absPath, err := filepath.Abs(specFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, includingFile := range includingFiles {
+		if includingFile == absPath {
+			return nil, errorutils.CheckErrorf("circular include of the spec file %s: %s", specFilePath, strings.Join(append(includingFiles, absPath), " -> "))
+		}
+	}
+	content, err := os.ReadFile(specFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	document, err := parseSpecDocument(specFilePath, expandSpecVars(content, specVars), strict)
+	if err != nil {
+		return nil, err
+	}
+	template := new(specTemplate)
+	if err = json.Unmarshal(document.content, template); err != nil {
+		return nil, document.parseError(-1, err)
+	}
+
+	var groups []*specFileGroup
+	for _, include := range template.Include {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(specFilePath), include)
+		}
+		includedGroups, err := loadSpecFileGroupsRecursively(include, specVars, strict, append(includingFiles, absPath))
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, includedGroups...)
+	}
+	for i, raw := range template.Files {
+		group := &specFileGroup{raw: raw, source: specFilePath, line: document.getLine(i)}
+		include, err := group.evaluateCondition()
+		if err != nil {
+			return nil, err
+		}
+		if !include {
+			log.Debug(fmt.Sprintf("Skipping the file group in %s, since its 'when' condition is false.", group.location()))
+			continue
+		}
+		if err = json.Unmarshal(group.raw, &group.file); err != nil {
+			return nil, document.parseError(i, err)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// The content of a JSON or YAML spec file, converted to JSON, with the locations of its file groups.
+type specDocument struct {
+	path    string
+	content []byte
+	isYaml  bool
+	// The offsets of the file groups in a JSON spec file.
+	offsets []int64
+	// The lines of the file groups in a YAML spec file.
+	lines []int
+}
+
+// Parses a JSON or YAML spec file, by its extension. In strict mode, the spec file is validated against the file spec schema.
+// Otherwise, its unknown properties are only logged as warnings, and the rest of the schema isn't enforced.
+func parseSpecDocument(specFilePath string, content []byte, strict bool) (*specDocument, error) {
+	document := &specDocument{path: specFilePath, content: content, isYaml: isYamlFile(specFilePath)}
+	if document.isYaml {
+		var root *yaml.Node
+		var err error
+		if document.content, root, err = convertYamlToJson(content); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the spec file %s: %s", specFilePath, err.Error())
+		}
+		document.lines = getYamlFileGroupsLines(root)
+	} else {
+		document.offsets = getFileGroupsOffsets(content)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(document.content))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, document.parseError(-1, err)
+	}
+	schema := getFileSpecSchema()
+	if !strict {
+		for _, unknownProperty := range getUnknownProperties(value, schema, schema, nil) {
+			log.Warn(document.locateSchemaError(unknownProperty))
+		}
+		return document, nil
+	}
+	if schemaErr := validateSchema(value, schema, schema, nil); schemaErr != nil {
+		return nil, errorutils.CheckError(errors.New(document.locateSchemaError(schemaErr)))
+	}
+	return document, nil
+}
+
+// Returns the schema error, prefixed by the spec file and the line of its file group.
+func (document *specDocument) locateSchemaError(schemaErr *schemaError) string {
+	if index := schemaErr.fileGroupIndex(); index >= 0 {
+		return fmt.Sprintf("%s:%d: %s", document.path, document.getLine(index), schemaErr.Error())
+	}
+	return fmt.Sprintf("%s: %s", document.path, schemaErr.Error())
+}
+
+// Returns the line of a file group in the spec file, or 0 if it's unknown.
+func (document *specDocument) getLine(index int) int {
+	if document.isYaml {
+		if index < len(document.lines) {
+			return document.lines[index]
+		}
+		return 0
+	}
+	if index < len(document.offsets) {
+		line, _ := getLineAndColumn(document.content, document.offsets[index])
+		return line
+	}
+	return 0
+}
+
+// Returns an error with the location in the spec file. The index of the parsed file group is -1 if the whole spec file is parsed.
+// The line and column of syntax and type errors are reported in JSON spec files, and the line of the file group in YAML spec files.
+func (document *specDocument) parseError(index int, err error) error {
+	if document.isYaml {
+		if line := document.getLine(index); index >= 0 && line > 0 {
+			return errorutils.CheckErrorf("failed to parse the spec file %s:%d: %s", document.path, line, err.Error())
+		}
+		return errorutils.CheckErrorf("failed to parse the spec file %s: %s", document.path, err.Error())
+	}
+	var offset int64
+	if index >= 0 && index < len(document.offsets) {
+		offset = document.offsets[index]
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset += syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset += typeErr.Offset
+	}
+	line, column := getLineAndColumn(document.content, offset)
+	return errorutils.CheckErrorf("failed to parse the spec file %s:%d:%d: %s", document.path, line, column, err.Error())
+}
+
+// Replaces the ${var} and ${var:-default} variables in the content of a spec file.
+func expandSpecVars(content []byte, specVars map[string]string) []byte {
+	return specVarRegexp.ReplaceAllFunc(content, func(match []byte) []byte {
+		submatches := specVarRegexp.FindSubmatch(match)
+		name := string(submatches[1])
+		if value, ok := specVars[name]; ok {
+			log.Debug(fmt.Sprintf("Replacing '%s' with '%s'", match, value))
+			return []byte(value)
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			log.Debug(fmt.Sprintf("Replacing '%s' with the value of the environment variable", match))
+			return []byte(value)
+		}
+		if bytes.Contains(match, []byte(":-")) {
+			return submatches[2]
+		}
+		return match
+	})
+}
+
+// Evaluates the 'when' condition of the file group, and removes it from the file group.
+func (group *specFileGroup) evaluateCondition() (bool, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(group.raw, &fields); err != nil {
+		// The error is reported with its location when the file group is parsed.
+		return true, nil
+	}
+	var condition *string
+	for key, value := range fields {
+		if strings.EqualFold(key, "when") {
+			if err := json.Unmarshal(value, &condition); err != nil {
+				return false, errorutils.CheckErrorf("%s: the 'when' condition must be a string", group.location())
+			}
+			delete(fields, key)
+		}
+	}
+	if condition == nil {
+		return true, nil
+	}
+	result, err := evaluateSpecCondition(*condition)
+	if err != nil {
+		return false, errorutils.CheckErrorf("%s: %s", group.location(), err.Error())
+	}
+	if group.raw, err = json.Marshal(fields); err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return result, nil
+}
+
+// Evaluates a condition in the form of 'value', '!value', 'a == b' or 'a != b'. A value is either 'true', 'false' or empty, which is false.
+func evaluateSpecCondition(condition string) (bool, error) {
+	if left, right, found := strings.Cut(condition, "!="); found {
+		return strings.TrimSpace(left) != strings.TrimSpace(right), nil
+	}
+	if left, right, found := strings.Cut(condition, "=="); found {
+		return strings.TrimSpace(left) == strings.TrimSpace(right), nil
+	}
+	condition = strings.TrimSpace(condition)
+	negate := strings.HasPrefix(condition, "!")
+	condition = strings.TrimSpace(strings.TrimPrefix(condition, "!"))
+	if condition == "" {
+		return negate, nil
+	}
+	result, err := strconv.ParseBool(condition)
+	if err != nil {
+		return false, errorutils.CheckErrorf("invalid 'when' condition '%s'. Expected 'true', 'false', '!value', 'a == b' or 'a != b'", condition)
+	}
+	return result != negate, nil
+}
+
+// Returns the offsets of the file groups in the content of a spec file.
+func getFileGroupsOffsets(content []byte) (offsets []int64) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil
+		}
+		if name, ok := key.(string); ok && strings.EqualFold(name, "files") {
+			if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+				return nil
+			}
+			for decoder.More() {
+				offset := decoder.InputOffset()
+				// The offset is right after the previous token, so the separating comma and white spaces are skipped.
+				for offset < int64(len(content)) && strings.ContainsRune(", \t\r\n", rune(content[offset])) {
+					offset++
+				}
+				offsets = append(offsets, offset)
+				if err = decoder.Decode(new(json.RawMessage)); err != nil {
+					return nil
+				}
+			}
+			return offsets
+		}
+		if err = decoder.Decode(new(json.RawMessage)); err != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+func getLineAndColumn(content []byte, offset int64) (line, column int) {
+	offset = min(offset, int64(len(content)))
+	before := content[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	return
+}
+
+// Returns the resolved spec, after replacing the variables, including the included spec files
+// and dropping the file groups which their 'when' condition is false.
+func RenderSpecFile(specFilePath string, specVars map[string]string) ([]byte, error) {
+	groups, err := loadSpecFileGroups(specFilePath, specVars, false)
+	if err != nil {
+		return nil, err
+	}
+	rendered := struct {
+		Files []json.RawMessage `json:"files"`
+	}{Files: []json.RawMessage{}}
+	for _, group := range groups {
+		rendered.Files = append(rendered.Files, group.raw)
+	}
+	content, err := json.MarshalIndent(rendered, "", "  ")
+	return content, errorutils.CheckError(err)
+}
+
+// Validates a spec file template against the file spec schema and the rules of the spec, reporting the location of the invalid file group.
+// Unlike loading the spec file, unknown properties are errors.
+func ValidateSpecFile(specFilePath string, specVars map[string]string, isTargetMandatory, isSearchBasedSpec bool) error {
+	groups, err := loadSpecFileGroups(specFilePath, specVars, true)
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return errorutils.CheckErrorf("spec must include at least one file group")
+	}
+	for _, group := range groups {
+		if err = validateFile(group.file, isTargetMandatory, isSearchBasedSpec); err != nil {
+			return errorutils.CheckErrorf("%s: %s", group.location(), err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSpecFile(t *testing.T, dir, name, content string) string {
+	specFilePath := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(specFilePath, []byte(content), 0644))
+	return specFilePath
+}
+
+func TestExpandSpecVars(t *testing.T) {
+	t.Setenv("SPEC_TEST_ENV", "from-env")
+	content := `${repo}/${SPEC_TEST_ENV}/${missing:-default}/${empty:-}/${repo:-ignored}/${unresolved}`
+	assert.Equal(t, "libs/from-env/default//libs/${unresolved}", string(expandSpecVars([]byte(content), map[string]string{"repo": "libs"})))
+}
+
+func TestEvaluateSpecCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		expected  bool
+	}{
+		{"true", true},
+		{"false", false},
+		{"", false},
+		{"!", true},
+		{"!true", false},
+		{" ! false ", true},
+		{"prod == prod", true},
+		{"prod == dev", false},
+		{"prod != dev", true},
+		{" == ", true},
+	}
+	for _, test := range tests {
+		t.Run(test.condition, func(t *testing.T) {
+			result, err := evaluateSpecCondition(test.condition)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+	_, err := evaluateSpecCondition("yes please")
+	assert.ErrorContains(t, err, "invalid 'when' condition")
+}
+
+func TestCreateSpecFromFileTemplate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "common"), 0755))
+	writeSpecFile(t, filepath.Join(dir, "common"), "base.json", `{
+  "files": [
+    {"pattern": "${repo}/base/*", "target": "base/"}
+  ]
+}`)
+	specFilePath := writeSpecFile(t, dir, "spec.json", `{
+  "include": ["common/base.json"],
+  "files": [
+    {"pattern": "${repo}/prod/*", "target": "prod/", "when": "${env:-dev} == prod"},
+    {"pattern": "${repo}/dev/*", "target": "dev/", "when": "${env:-dev} != prod"}
+  ]
+}`)
+
+	spec, err := CreateSpecFromFile(specFilePath, map[string]string{"repo": "libs"})
+	require.NoError(t, err)
+	assert.Equal(t, []File{{Pattern: "libs/base/*", Target: "base/"}, {Pattern: "libs/dev/*", Target: "dev/"}}, spec.Files)
+
+	spec, err = CreateSpecFromFile(specFilePath, map[string]string{"repo": "libs", "env": "prod"})
+	require.NoError(t, err)
+	assert.Equal(t, []File{{Pattern: "libs/base/*", Target: "base/"}, {Pattern: "libs/prod/*", Target: "prod/"}}, spec.Files)
+
+	rendered, err := RenderSpecFile(specFilePath, map[string]string{"repo": "libs"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"files":[{"pattern":"libs/base/*","target":"base/"},{"pattern":"libs/dev/*","target":"dev/"}]}`, string(rendered))
+}
+
+func TestCreateSpecFromFileCircularInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFile(t, dir, "a.json", `{"include": ["b.json"], "files": []}`)
+	writeSpecFile(t, dir, "b.json", `{"include": ["a.json"], "files": []}`)
+	_, err := CreateSpecFromFile(filepath.Join(dir, "a.json"), nil)
+	assert.ErrorContains(t, err, "circular include")
+}
+
+func TestValidateSpecFileLocation(t *testing.T) {
+	dir := t.TempDir()
+	specFilePath := writeSpecFile(t, dir, "spec.json", `{
+  "files": [
+    {
+      "pattern": "libs/*",
+      "target": "out/"
+    },
+    {
+      "pattern": "libs/*",
+      "sortOrder": "asc"
+    }
+  ]
+}`)
+	err := ValidateSpecFile(specFilePath, nil, false, true)
+	assert.EqualError(t, err, specFilePath+":7: spec cannot include 'sort-order' if 'sort-by' is not included")
+
+	// Syntax and type errors are reported with their line and column.
+	specFilePath = writeSpecFile(t, dir, "syntax.json", "{\n  \"files\": [\n    {\"pattern\": \"libs/*\",}\n  ]\n}")
+	assert.ErrorContains(t, ValidateSpecFile(specFilePath, nil, false, true), specFilePath+":3:")
+	specFilePath = writeSpecFile(t, dir, "type.json", "{\n  \"files\": [\n    {\"pattern\": \"libs/*\"},\n    {\"pattern\": \"libs/*\", \"limit\": \"10\"}\n  ]\n}")
+	assert.ErrorContains(t, ValidateSpecFile(specFilePath, nil, false, true), specFilePath+":4:")
+}
@@ -0,0 +1,91 @@
+package spec
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+func isYamlFile(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Converts YAML content to JSON, to be parsed as the JSON files are.
+// YAML booleans are converted to strings, since the boolean options of the spec are strings.
+func convertYamlToJson(content []byte) ([]byte, *yaml.Node, error) {
+	root := new(yaml.Node)
+	if err := yaml.Unmarshal(content, root); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	value, err := convertYamlNode(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	jsonContent, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	return jsonContent, root, nil
+}
+
+func convertYamlNode(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return convertYamlNode(node.Content[0])
+	case yaml.AliasNode:
+		return convertYamlNode(node.Alias)
+	case yaml.MappingNode:
+		mapping := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := convertYamlNode(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			mapping[node.Content[i].Value] = value
+		}
+		return mapping, nil
+	case yaml.SequenceNode:
+		sequence := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			value, err := convertYamlNode(item)
+			if err != nil {
+				return nil, err
+			}
+			sequence = append(sequence, value)
+		}
+		return sequence, nil
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, errorutils.CheckErrorf("line %d: %s", node.Line, err.Error())
+	}
+	if boolValue, ok := value.(bool); ok {
+		return strconv.FormatBool(boolValue), nil
+	}
+	return value, nil
+}
+
+// Returns the lines of the file groups in a YAML spec file.
+func getYamlFileGroupsLines(root *yaml.Node) (lines []int) {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	mapping := root.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, "files") && mapping.Content[i+1].Kind == yaml.SequenceNode {
+			for _, item := range mapping.Content[i+1].Content {
+				lines = append(lines, item.Line)
+			}
+			return lines
+		}
+	}
+	return nil
+}
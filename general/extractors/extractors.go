@@ -0,0 +1,162 @@
+package extractors
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli-core/v2/common/project"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+var extractorProjectTypes = map[dependencies.ExtractorType]project.ProjectType{
+	dependencies.MavenExtractor:  project.Maven,
+	dependencies.GradleExtractor: project.Gradle,
+}
+
+type cachedExtractorRow struct {
+	Type    string `col-name:"Type"`
+	Version string `col-name:"Version"`
+	Size    string `col-name:"Size (Bytes)"`
+	InUse   string `col-name:"In Use"`
+	Path    string `col-name:"Path"`
+}
+
+// Lists the Maven and Gradle build-info extractor versions in the local cache.
+type ExtractorsListCommand struct {
+	outputFormat format.OutputFormat
+}
+
+func NewExtractorsListCommand() *ExtractorsListCommand {
+	return &ExtractorsListCommand{outputFormat: format.Table}
+}
+
+func (elc *ExtractorsListCommand) SetOutputFormat(outputFormat format.OutputFormat) *ExtractorsListCommand {
+	elc.outputFormat = outputFormat
+	return elc
+}
+
+func (elc *ExtractorsListCommand) CommandName() string {
+	return "extractors_list"
+}
+
+func (elc *ExtractorsListCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (elc *ExtractorsListCommand) Run() error {
+	cached, err := forEachExtractorManager(func(extractorManager *dependencies.ExtractorManager) ([]dependencies.CachedExtractor, error) {
+		return extractorManager.List()
+	})
+	if err != nil {
+		return err
+	}
+	return printCachedExtractors(cached, elc.outputFormat, "Cached Build-Info Extractors", "No build-info extractors are cached")
+}
+
+// Removes the cached build-info extractor versions, which neither this CLI version nor the pinned versions of the current project use.
+type ExtractorsPruneCommand struct {
+	dryRun bool
+}
+
+func NewExtractorsPruneCommand() *ExtractorsPruneCommand {
+	return &ExtractorsPruneCommand{}
+}
+
+func (epc *ExtractorsPruneCommand) SetDryRun(dryRun bool) *ExtractorsPruneCommand {
+	epc.dryRun = dryRun
+	return epc
+}
+
+func (epc *ExtractorsPruneCommand) CommandName() string {
+	return "extractors_prune"
+}
+
+func (epc *ExtractorsPruneCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (epc *ExtractorsPruneCommand) Run() error {
+	pruned, err := forEachExtractorManager(func(extractorManager *dependencies.ExtractorManager) ([]dependencies.CachedExtractor, error) {
+		return extractorManager.Prune(epc.dryRun)
+	})
+	if err != nil {
+		return err
+	}
+	title := "Pruned Build-Info Extractors"
+	if epc.dryRun {
+		title = "Build-Info Extractors To Prune (dry run)"
+	}
+	return printCachedExtractors(pruned, format.Table, title, "No unused build-info extractors were found")
+}
+
+func forEachExtractorManager(action func(extractorManager *dependencies.ExtractorManager) ([]dependencies.CachedExtractor, error)) (results []dependencies.CachedExtractor, err error) {
+	for _, extractorType := range []dependencies.ExtractorType{dependencies.MavenExtractor, dependencies.GradleExtractor} {
+		extractorManager, err := dependencies.NewExtractorManager(extractorType)
+		if err != nil {
+			return nil, err
+		}
+		pinnedVersion, err := getPinnedVersion(extractorProjectTypes[extractorType])
+		if err != nil {
+			return nil, err
+		}
+		extractorManager.SetPinnedVersion(pinnedVersion)
+		cached, err := action(extractorManager)
+		results = append(results, cached...)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// Returns the extractor version pinned in the project configuration, if there is one.
+func getPinnedVersion(projectType project.ProjectType) (string, error) {
+	confFilePath, exists, err := project.GetProjectConfFilePath(projectType)
+	if err != nil || !exists {
+		return "", err
+	}
+	vConfig, err := project.ReadConfigFile(confFilePath, project.YAML)
+	if err != nil {
+		return "", err
+	}
+	return vConfig.GetString(dependencies.ExtractorVersionConfigKey), nil
+}
+
+func printCachedExtractors(cached []dependencies.CachedExtractor, outputFormat format.OutputFormat, title, emptyTableMessage string) error {
+	switch outputFormat {
+	case format.Json:
+		if cached == nil {
+			cached = []dependencies.CachedExtractor{}
+		}
+		content, err := json.Marshal(cached)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		log.Output(clientutils.IndentJson(content))
+		return nil
+	case format.Table, "":
+		var rows []cachedExtractorRow
+		for _, extractor := range cached {
+			inUse := "No"
+			if extractor.InUse {
+				inUse = "Yes"
+			}
+			rows = append(rows, cachedExtractorRow{
+				Type:    string(extractor.Type),
+				Version: extractor.Version,
+				Size:    strconv.FormatInt(extractor.Size, 10),
+				InUse:   inUse,
+				Path:    extractor.Path,
+			})
+		}
+		return coreutils.PrintTable(rows, title, emptyTableMessage, false)
+	default:
+		return errorutils.CheckErrorf("unsupported output format '%s'. Only '%s' and '%s' are supported", outputFormat, format.Table, format.Json)
+	}
+}
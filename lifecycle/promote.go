@@ -2,18 +2,32 @@ package lifecycle
 
 import (
 	"encoding/json"
+	"sort"
+
+	buildinfo "github.com/jfrog/build-info-go/entities"
+	rtUtils "github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/promotionpolicy"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	rtServices "github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/lifecycle"
 	"github.com/jfrog/jfrog-client-go/lifecycle/services"
 	"github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
+const (
+	buildNamePropertyKey   = "build.name"
+	buildNumberPropertyKey = "build.number"
+)
+
 type ReleaseBundlePromoteCommand struct {
 	releaseBundleCmd
 	signingKeyName       string
 	environment          string
 	includeReposPatterns []string
 	excludeReposPatterns []string
+	policyFilePath       string
 }
 
 func NewReleaseBundlePromoteCommand() *ReleaseBundlePromoteCommand {
@@ -65,6 +79,12 @@ func (rbp *ReleaseBundlePromoteCommand) SetExcludeReposPatterns(excludeReposPatt
 	return rbp
 }
 
+// A promotion policy file, evaluated against the builds included in the release bundle before it is promoted.
+func (rbp *ReleaseBundlePromoteCommand) SetPolicyFilePath(policyFilePath string) *ReleaseBundlePromoteCommand {
+	rbp.policyFilePath = policyFilePath
+	return rbp
+}
+
 func (rbp *ReleaseBundlePromoteCommand) CommandName() string {
 	return "rb_promote"
 }
@@ -83,6 +103,12 @@ func (rbp *ReleaseBundlePromoteCommand) Run() error {
 		return err
 	}
 
+	if rbp.policyFilePath != "" {
+		if err = rbp.evaluatePolicy(servicesManager, rbDetails); err != nil {
+			return err
+		}
+	}
+
 	promotionParams := services.RbPromotionParams{
 		Environment:            rbp.environment,
 		IncludedRepositoryKeys: rbp.includeReposPatterns,
@@ -100,3 +126,74 @@ func (rbp *ReleaseBundlePromoteCommand) Run() error {
 	log.Output(utils.IndentJson(content))
 	return nil
 }
+
+// Evaluates the promotion policy against the builds from which the release bundle artifacts were created.
+// The builds are identified by the build.name and build.number properties of the artifacts.
+func (rbp *ReleaseBundlePromoteCommand) evaluatePolicy(servicesManager *lifecycle.LifecycleServicesManager, rbDetails services.ReleaseBundleDetails) error {
+	policy, err := promotionpolicy.LoadPolicy(rbp.policyFilePath)
+	if err != nil {
+		return err
+	}
+	rbSpec, err := servicesManager.GetReleaseBundleSpecification(rbDetails)
+	if err != nil {
+		return err
+	}
+	builds := make(map[rtServices.BuildInfoParams]bool)
+	for _, artifact := range rbSpec.Artifacts {
+		var buildNames, buildNumbers []string
+		for _, property := range artifact.Properties {
+			switch property.Key {
+			case buildNamePropertyKey:
+				buildNames = property.Values
+			case buildNumberPropertyKey:
+				buildNumbers = property.Values
+			}
+		}
+		if len(buildNames) == 1 && len(buildNumbers) == 1 {
+			builds[rtServices.BuildInfoParams{BuildName: buildNames[0], BuildNumber: buildNumbers[0], ProjectKey: rbp.rbProjectKey}] = true
+		}
+	}
+	if len(builds) == 0 {
+		return errorutils.CheckErrorf("the promotion policy could not be evaluated, since the release bundle %s/%s doesn't include artifacts of any build", rbDetails.ReleaseBundleName, rbDetails.ReleaseBundleVersion)
+	}
+
+	rtServicesManager, err := rtUtils.CreateServiceManager(rbp.serverDetails, 3, 0, false)
+	if err != nil {
+		return err
+	}
+	buildsParams := make([]rtServices.BuildInfoParams, 0, len(builds))
+	for buildParams := range builds {
+		buildsParams = append(buildsParams, buildParams)
+	}
+	sort.Slice(buildsParams, func(i, j int) bool {
+		if buildsParams[i].BuildName != buildsParams[j].BuildName {
+			return buildsParams[i].BuildName < buildsParams[j].BuildName
+		}
+		return buildsParams[i].BuildNumber < buildsParams[j].BuildNumber
+	})
+	var buildInfos []*buildinfo.BuildInfo
+	for _, buildParams := range buildsParams {
+		publishedBuildInfo, found, err := rtServicesManager.GetBuildInfo(buildParams)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return errorutils.CheckErrorf("build %s/%s, included in the release bundle, was not found in Artifactory", buildParams.BuildName, buildParams.BuildNumber)
+		}
+		buildInfos = append(buildInfos, &publishedBuildInfo.BuildInfo)
+	}
+
+	evaluator := promotionpolicy.NewEvaluator(policy).SetDependencyRepositoriesResolver(promotionpolicy.NewAqlDependencyRepositoriesResolver(rtServicesManager))
+	report, err := evaluator.Evaluate(buildInfos...)
+	if err != nil {
+		return err
+	}
+	if err = report.Print(); err != nil {
+		return err
+	}
+	if err = report.Error(); err != nil {
+		return err
+	}
+	log.Info("The release bundle satisfies the promotion policy.")
+	return nil
+}
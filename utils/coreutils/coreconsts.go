@@ -11,7 +11,9 @@ const (
 
 	// Home Dir
 	JfrogBackupDirName                  = "backup"
+	JfrogBuildPublishQueueDirName       = "build-publish-queue"
 	JfrogCertsDirName                   = "certs"
+	JfrogConanHomeDirName               = "conan-home"
 	JfrogConfigFile                     = "jfrog-cli.conf"
 	JfrogDependenciesDirName            = "dependencies"
 	JfrogLocksDirName                   = "locks"
@@ -20,6 +22,7 @@ const (
 	JfrogPluginsFileName                = "plugins.yml"
 	JfrogSecurityConfFile               = "security.yaml"
 	JfrogSecurityDirName                = "security"
+	JfrogSyncDirName                    = "sync"
 	JfrogTransferDelaysDirName          = "delays"
 	JfrogTransferDirName                = "transfer"
 	JfrogTransferErrorsDirName          = "errors"
@@ -32,6 +35,7 @@ const (
 	JfrogTransferSkippedErrorsDirName   = "skipped"
 	JfrogTransferSnapshotDirName        = "snapshot"
 	JfrogTransferStateFileName          = "state.json"
+	JfrogUploadsJournalDirName          = "uploads"
 	PluginsExecDirName                  = "bin"
 	PluginsResourcesDirName             = "resources"
 	//#nosec G101
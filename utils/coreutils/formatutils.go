@@ -0,0 +1,36 @@
+package coreutils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const sizeUnits = "KMGTPE"
+
+// Returns a human-readable size, in KiB at least, such as '1.5 KiB'.
+func SizeToString(sizeInBytes int64) string {
+	var divider int64 = 1024
+	sizeUnitIndex := 0
+	for ; sizeUnitIndex < len(sizeUnits)-1 && (sizeInBytes >= divider<<10); sizeUnitIndex++ {
+		divider <<= 10
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(sizeInBytes)/float64(divider), sizeUnits[sizeUnitIndex])
+}
+
+// Returns a single CSV record, without a trailing newline.
+func ToCsvRecord(fields ...string) (string, error) {
+	buffer := new(bytes.Buffer)
+	csvWriter := csv.NewWriter(buffer)
+	if err := csvWriter.Write(fields); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
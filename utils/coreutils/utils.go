@@ -34,6 +34,14 @@ const (
 	// DeprecatedExtractorsRemoteEnv is deprecated, it is replaced with ReleasesRemoteEnv.
 	// Its functionality was similar to ReleasesRemoteEnv, but it proxies releases.jfrog.io/artifactory/oss-release-local instead.
 	DeprecatedExtractorsRemoteEnv = "JFROG_CLI_EXTRACTORS_REMOTE"
+	// ExtractorsOfflineDirEnv stores a pre-seeded directory of build-info extractor jars, for runners without network access.
+	// When set, the extractors are copied from this directory and are never downloaded.
+	ExtractorsOfflineDirEnv = "JFROG_CLI_EXTRACTORS_OFFLINE_DIR"
+	// ExtractorsManifestEnv stores the path to a signed manifest of the build-info extractors sha256 checksums.
+	// The signature is read from the '<manifest>.sig' file, and is verified with the public key in ExtractorsManifestKeyEnv.
+	ExtractorsManifestEnv = "JFROG_CLI_EXTRACTORS_MANIFEST"
+	// ExtractorsManifestKeyEnv stores the path to the PEM public key which signed the extractors manifest.
+	ExtractorsManifestKeyEnv = "JFROG_CLI_EXTRACTORS_MANIFEST_KEY"
 	// JFrog releases URL
 	JfrogReleasesUrl = "https://releases.jfrog.io/artifactory/"
 )
@@ -361,6 +369,41 @@ func GetJfrogBackupDir() (string, error) {
 	return filepath.Join(homeDir, JfrogBackupDirName), nil
 }
 
+func GetJfrogBuildPublishQueueDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogBuildPublishQueueDirName), nil
+}
+
+// Returns the directory of the states of the sync command, which hold the checksums of the files of the last sync.
+func GetJfrogSyncDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogSyncDirName), nil
+}
+
+// Returns the directory of the journals of the resumable uploads, which hold the parts uploaded so far.
+func GetJfrogUploadsJournalDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogUploadsJournalDirName), nil
+}
+
+// Returns the Conan home directory used by the Conan commands, which is isolated from the user's Conan home.
+func GetJfrogConanHomeDir() (string, error) {
+	homeDir, err := GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, JfrogConanHomeDirName), nil
+}
+
 func GetJfrogPluginsDir() (string, error) {
 	homeDir, err := GetJfrogHomeDir()
 	if err != nil {
@@ -0,0 +1,423 @@
+package dependencies
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/build-info-go/build"
+	biutils "github.com/jfrog/build-info-go/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type ExtractorType string
+
+const (
+	MavenExtractor  ExtractorType = "maven"
+	GradleExtractor ExtractorType = "gradle"
+
+	// The key in the Maven and Gradle project configuration, which pins the version of the build-info extractor.
+	ExtractorVersionConfigKey = "extractorVersion"
+	// The Gradle extractor versions used by build-info-go. Gradle 6.8.1 and above uses the version 5 extractor.
+	// build-info-go doesn't export them, so TestGradleExtractorVersionsMatchBuildInfoGo verifies they match after upgrading it.
+	gradleExtractor4Version = "4.33.21"
+	gradleExtractor5Version = "5.2.5"
+	manifestSignatureSuffix = ".sig"
+)
+
+type extractorDetails struct {
+	displayName string
+	// The jar file name and its path in releases.jfrog.io, formatted with the extractor version.
+	fileName   string
+	remotePath string
+	// The versions this CLI version runs.
+	versions []string
+}
+
+var extractors = map[ExtractorType]extractorDetails{
+	MavenExtractor: {
+		displayName: "Maven",
+		fileName:    build.MavenExtractorFileName,
+		remotePath:  build.MavenExtractorRemotePath,
+		versions:    []string{build.MavenExtractorDependencyVersion},
+	},
+	GradleExtractor: {
+		displayName: "Gradle",
+		fileName:    "build-info-extractor-gradle-%s-uber.jar",
+		remotePath:  "org/jfrog/buildinfo/build-info-extractor-gradle/%s",
+		versions:    []string{gradleExtractor4Version, gradleExtractor5Version},
+	},
+}
+
+// A manifest of the sha256 checksums of the build-info extractor jars.
+type ExtractorsManifest struct {
+	Extractors []ExtractorChecksum `json:"extractors"`
+}
+
+type ExtractorChecksum struct {
+	Type    ExtractorType `json:"type"`
+	Version string        `json:"version"`
+	Sha256  string        `json:"sha256"`
+}
+
+func (em *ExtractorsManifest) getSha256(extractorType ExtractorType, version string) string {
+	for _, extractor := range em.Extractors {
+		if extractor.Type == extractorType && extractor.Version == version {
+			return strings.ToLower(extractor.Sha256)
+		}
+	}
+	return ""
+}
+
+// Reads the extractors manifest, after verifying its signature in '<manifestPath>.sig' with the PEM public key.
+// The signature is the base64 encoded signature of the manifest content. RSA and ECDSA signatures are of its sha256 digest.
+func ReadExtractorsManifest(manifestPath, publicKeyPath string) (*ExtractorsManifest, error) {
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the extractors manifest: %s", err.Error())
+	}
+	encodedSignature, err := os.ReadFile(manifestPath + manifestSignatureSuffix)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to read the signature of the extractors manifest: %s", err.Error())
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedSignature)))
+	if err != nil {
+		return nil, errorutils.CheckErrorf("the signature of the extractors manifest isn't base64 encoded: %s", err.Error())
+	}
+	publicKey, err := readPublicKey(publicKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	if !verifySignature(publicKey, content, signature) {
+		return nil, errorutils.CheckErrorf("the signature of the extractors manifest '%s' is invalid", manifestPath)
+	}
+	manifest := new(ExtractorsManifest)
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the extractors manifest: %s", err.Error())
+	}
+	return manifest, nil
+}
+
+func readPublicKey(keyPath string) (any, error) {
+	content, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errorutils.CheckErrorf("failed to decode the PEM public key at '%s'", keyPath)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("unsupported public key format at '%s': %s", keyPath, err.Error())
+	}
+	return publicKey, nil
+}
+
+func verifySignature(publicKey any, content, signature []byte) bool {
+	digest := sha256.Sum256(content)
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, content, signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest[:], signature)
+	}
+	return false
+}
+
+// A build-info extractor jar in the local cache.
+type CachedExtractor struct {
+	Type    ExtractorType `json:"type"`
+	Version string        `json:"version"`
+	Path    string        `json:"path"`
+	Size    int64         `json:"size"`
+	// Whether this CLI version, or the pinned version of the project, uses the extractor.
+	InUse bool `json:"inUse"`
+}
+
+// Manages the build-info extractor jars of a build tool in the local dependencies directory.
+// The jars are cached in a directory per version, and are fetched from releases.jfrog.io, from the remote repository
+// in JFROG_CLI_RELEASES_REPO, or from the offline directory in JFROG_CLI_EXTRACTORS_OFFLINE_DIR.
+// When a manifest is configured, every jar is verified against its sha256 checksum before it's used.
+type ExtractorManager struct {
+	extractorType ExtractorType
+	localPath     string
+	offlineDir    string
+	pinnedVersion string
+	manifest      *ExtractorsManifest
+}
+
+// Creates the manager of the extractor type, according to the environment variables.
+func NewExtractorManager(extractorType ExtractorType) (*ExtractorManager, error) {
+	if _, ok := extractors[extractorType]; !ok {
+		return nil, errorutils.CheckErrorf("unsupported build-info extractor type: %s", extractorType)
+	}
+	dependenciesPath, err := config.GetJfrogDependenciesPath()
+	if err != nil {
+		return nil, err
+	}
+	em := &ExtractorManager{extractorType: extractorType, localPath: filepath.Join(dependenciesPath, string(extractorType)), offlineDir: os.Getenv(coreutils.ExtractorsOfflineDirEnv)}
+	if manifestPath := os.Getenv(coreutils.ExtractorsManifestEnv); manifestPath != "" {
+		publicKeyPath := os.Getenv(coreutils.ExtractorsManifestKeyEnv)
+		if publicKeyPath == "" {
+			return nil, errorutils.CheckErrorf("the '%s' environment variable must be set to the public key which signed the extractors manifest in '%s'", coreutils.ExtractorsManifestKeyEnv, coreutils.ExtractorsManifestEnv)
+		}
+		if em.manifest, err = ReadExtractorsManifest(manifestPath, publicKeyPath); err != nil {
+			return nil, err
+		}
+	}
+	return em, nil
+}
+
+func (em *ExtractorManager) SetLocalPath(localPath string) *ExtractorManager {
+	em.localPath = localPath
+	return em
+}
+
+func (em *ExtractorManager) SetOfflineDir(offlineDir string) *ExtractorManager {
+	em.offlineDir = offlineDir
+	return em
+}
+
+func (em *ExtractorManager) SetManifest(manifest *ExtractorsManifest) *ExtractorManager {
+	em.manifest = manifest
+	return em
+}
+
+// Pins the extractor version of the project. The build fails if this CLI version runs another extractor version.
+func (em *ExtractorManager) SetPinnedVersion(pinnedVersion string) *ExtractorManager {
+	em.pinnedVersion = pinnedVersion
+	return em
+}
+
+// The directory in which the extractor jars are cached, with a subdirectory per version.
+func (em *ExtractorManager) LocalPath() string {
+	return em.localPath
+}
+
+// Returns the directory of the extractor version in the local cache.
+func (em *ExtractorManager) VersionPath(version string) string {
+	return filepath.Join(em.localPath, version)
+}
+
+// Makes sure the extractors are ready to be used by build-info-go before the build runs:
+// The pinned version is one of the versions this CLI runs, and the cached jars match the manifest.
+// Cached jars with mismatching checksums are removed, so that they are fetched again.
+// If the extractor version is known in advance, as for Maven, the jar is also fetched, to fail fast when it's missing.
+func (em *ExtractorManager) Prepare() error {
+	details := extractors[em.extractorType]
+	if em.pinnedVersion != "" && !slices.Contains(details.versions, em.pinnedVersion) {
+		return errorutils.CheckErrorf("the project pins the %s build-info extractor version %s, but this JFrog CLI version runs the version %s. "+
+			"Update the '%s' value in the project configuration, or use a JFrog CLI version which runs the pinned version",
+			details.displayName, em.pinnedVersion, strings.Join(details.versions, " or "), ExtractorVersionConfigKey)
+	}
+	for _, version := range details.versions {
+		jarPath := filepath.Join(em.VersionPath(version), fmt.Sprintf(details.fileName, version))
+		exists, err := fileutils.IsFileExists(jarPath, false)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if err = em.verify(jarPath, version); err != nil {
+			log.Warn(err.Error(), "\nRemoving it from the local cache...")
+			if err = errorutils.CheckError(os.Remove(jarPath)); err != nil {
+				return err
+			}
+		}
+	}
+	if len(details.versions) == 1 {
+		return em.ensure(details.versions[0])
+	}
+	return nil
+}
+
+// Fetches the extractor version to the local cache, unless it's already there.
+func (em *ExtractorManager) ensure(version string) error {
+	details := extractors[em.extractorType]
+	fileName := fmt.Sprintf(details.fileName, version)
+	jarPath := filepath.Join(em.VersionPath(version), fileName)
+	exists, err := fileutils.IsFileExists(jarPath, false)
+	if err != nil || exists {
+		return err
+	}
+	if err = os.MkdirAll(em.VersionPath(version), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return em.Download(jarPath, path.Join(fmt.Sprintf(details.remotePath, version), fileName))
+}
+
+// Fetches an extractor jar to targetPath, and verifies it before it's used.
+// This function is passed to build-info-go, which calls it when the jar isn't cached.
+//
+// targetPath: The local path of the jar.
+// downloadPath: The path of the jar in releases.jfrog.io.
+func (em *ExtractorManager) Download(targetPath, downloadPath string) (err error) {
+	details := extractors[em.extractorType]
+	version := path.Base(path.Dir(downloadPath))
+	if em.pinnedVersion != "" && version != em.pinnedVersion {
+		return errorutils.CheckErrorf("the project pins the %s build-info extractor version %s, but the version %s is required to run this build. "+
+			"Update the '%s' value in the project configuration", details.displayName, em.pinnedVersion, version, ExtractorVersionConfigKey)
+	}
+	if em.offlineDir != "" {
+		err = em.copyFromOfflineDir(targetPath, downloadPath)
+	} else {
+		err = DownloadExtractor(targetPath, downloadPath)
+	}
+	if err != nil {
+		return err
+	}
+	if err = em.verify(targetPath, version); err != nil {
+		return errors.Join(err, errorutils.CheckError(os.Remove(targetPath)))
+	}
+	return nil
+}
+
+// The offline directory may mirror the paths in releases.jfrog.io, or include the jars directly.
+func (em *ExtractorManager) copyFromOfflineDir(targetPath, downloadPath string) error {
+	details := extractors[em.extractorType]
+	candidates := []string{filepath.Join(em.offlineDir, filepath.FromSlash(downloadPath)), filepath.Join(em.offlineDir, path.Base(downloadPath))}
+	for _, candidate := range candidates {
+		exists, err := fileutils.IsFileExists(candidate, false)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		log.Info("Copying the build-info extractor from", candidate)
+		if err = biutils.CopyFile(filepath.Dir(targetPath), candidate); err != nil {
+			return errorutils.CheckError(err)
+		}
+		if filepath.Base(candidate) != filepath.Base(targetPath) {
+			return errorutils.CheckError(os.Rename(filepath.Join(filepath.Dir(targetPath), filepath.Base(candidate)), targetPath))
+		}
+		return nil
+	}
+	return errorutils.CheckErrorf("the %s build-info extractor %s is missing from the offline extractors directory '%s', and it can't be downloaded while '%s' is set.\n"+
+		"Copy it to '%s' or to '%s', or unset '%s' to download it",
+		details.displayName, path.Base(downloadPath), em.offlineDir, coreutils.ExtractorsOfflineDirEnv, candidates[0], candidates[1], coreutils.ExtractorsOfflineDirEnv)
+}
+
+// Verifies the jar against the sha256 checksum in the manifest. Nothing is verified if no manifest is configured.
+func (em *ExtractorManager) verify(jarPath, version string) error {
+	if em.manifest == nil {
+		return nil
+	}
+	details := extractors[em.extractorType]
+	expectedSha256 := em.manifest.getSha256(em.extractorType, version)
+	if expectedSha256 == "" {
+		return errorutils.CheckErrorf("the %s build-info extractor %s isn't listed in the extractors manifest", details.displayName, version)
+	}
+	actualSha256, err := calcSha256(jarPath)
+	if err != nil {
+		return err
+	}
+	if actualSha256 != expectedSha256 {
+		return errorutils.CheckErrorf("the sha256 checksum of the %s build-info extractor '%s' is %s, while the extractors manifest expects %s",
+			details.displayName, jarPath, actualSha256, expectedSha256)
+	}
+	log.Debug("The", details.displayName, "build-info extractor", version, "matches the extractors manifest.")
+	return nil
+}
+
+func calcSha256(filePath string) (checksum string, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(file.Close()))
+	}()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Lists the extractor versions in the local cache, sorted by version.
+func (em *ExtractorManager) List() ([]CachedExtractor, error) {
+	details := extractors[em.extractorType]
+	entries, err := os.ReadDir(em.localPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var cached []CachedExtractor
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		version := entry.Name()
+		info, err := os.Stat(filepath.Join(em.localPath, version, fmt.Sprintf(details.fileName, version)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errorutils.CheckError(err)
+		}
+		cached = append(cached, CachedExtractor{
+			Type:    em.extractorType,
+			Version: version,
+			Path:    filepath.Join(em.localPath, version),
+			Size:    info.Size(),
+			InUse:   em.isInUse(version),
+		})
+	}
+	sort.Slice(cached, func(i, j int) bool {
+		return cached[i].Version < cached[j].Version
+	})
+	return cached, nil
+}
+
+// Removes the cached extractor versions which aren't in use, and returns them.
+func (em *ExtractorManager) Prune(dryRun bool) ([]CachedExtractor, error) {
+	cached, err := em.List()
+	if err != nil {
+		return nil, err
+	}
+	var pruned []CachedExtractor
+	for _, extractor := range cached {
+		if extractor.InUse {
+			continue
+		}
+		if !dryRun {
+			log.Debug("Removing", extractor.Path)
+			if err = errorutils.CheckError(os.RemoveAll(extractor.Path)); err != nil {
+				return pruned, err
+			}
+		}
+		pruned = append(pruned, extractor)
+	}
+	return pruned, nil
+}
+
+func (em *ExtractorManager) isInUse(version string) bool {
+	return version == em.pinnedVersion || slices.Contains(extractors[em.extractorType].versions, version)
+}
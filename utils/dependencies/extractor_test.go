@@ -0,0 +1,169 @@
+package dependencies
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/build-info-go/build"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testJarContent = []byte("maven extractor")
+	mavenJarName   = fmt.Sprintf(build.MavenExtractorFileName, build.MavenExtractorDependencyVersion)
+)
+
+func createTestManifest(t *testing.T, sha256Checksum string) (manifestPath, publicKeyPath string, privateKey ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	content, err := json.Marshal(ExtractorsManifest{Extractors: []ExtractorChecksum{{Type: MavenExtractor, Version: build.MavenExtractorDependencyVersion, Sha256: sha256Checksum}}})
+	require.NoError(t, err)
+	manifestPath = filepath.Join(dir, "extractors.json")
+	require.NoError(t, os.WriteFile(manifestPath, content, 0644))
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, content))
+	require.NoError(t, os.WriteFile(manifestPath+manifestSignatureSuffix, []byte(signature), 0644))
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicKeyPath = filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}), 0644))
+	return
+}
+
+func testJarSha256() string {
+	checksum := sha256.Sum256(testJarContent)
+	return hex.EncodeToString(checksum[:])
+}
+
+func TestReadExtractorsManifest(t *testing.T) {
+	manifestPath, publicKeyPath, privateKey := createTestManifest(t, testJarSha256())
+	manifest, err := ReadExtractorsManifest(manifestPath, publicKeyPath)
+	require.NoError(t, err)
+	assert.Equal(t, testJarSha256(), manifest.getSha256(MavenExtractor, build.MavenExtractorDependencyVersion))
+	assert.Empty(t, manifest.getSha256(GradleExtractor, build.MavenExtractorDependencyVersion))
+
+	// Tamper with the manifest after it was signed.
+	content, err := json.Marshal(ExtractorsManifest{Extractors: []ExtractorChecksum{{Type: MavenExtractor, Version: build.MavenExtractorDependencyVersion, Sha256: "0000"}}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(manifestPath, content, 0644))
+	_, err = ReadExtractorsManifest(manifestPath, publicKeyPath)
+	assert.ErrorContains(t, err, "is invalid")
+
+	// Signing again makes it valid.
+	require.NoError(t, os.WriteFile(manifestPath+manifestSignatureSuffix, []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, content))), 0644))
+	_, err = ReadExtractorsManifest(manifestPath, publicKeyPath)
+	assert.NoError(t, err)
+}
+
+func TestExtractorManagerOffline(t *testing.T) {
+	offlineDir := t.TempDir()
+	extractorManager := (&ExtractorManager{extractorType: MavenExtractor}).SetLocalPath(t.TempDir()).SetOfflineDir(offlineDir)
+
+	// The jar is missing from the offline directory.
+	err := extractorManager.Prepare()
+	assert.ErrorContains(t, err, "is missing from the offline extractors directory")
+
+	// The jar is copied from the offline directory, and verified against the manifest.
+	require.NoError(t, os.WriteFile(filepath.Join(offlineDir, mavenJarName), testJarContent, 0644))
+	manifestPath, publicKeyPath, _ := createTestManifest(t, testJarSha256())
+	manifest, err := ReadExtractorsManifest(manifestPath, publicKeyPath)
+	require.NoError(t, err)
+	extractorManager.SetManifest(manifest)
+	require.NoError(t, extractorManager.Prepare())
+	content, err := os.ReadFile(filepath.Join(extractorManager.VersionPath(build.MavenExtractorDependencyVersion), mavenJarName))
+	require.NoError(t, err)
+	assert.Equal(t, testJarContent, content)
+
+	// A cached jar which doesn't match the manifest is replaced.
+	require.NoError(t, os.WriteFile(filepath.Join(extractorManager.VersionPath(build.MavenExtractorDependencyVersion), mavenJarName), []byte("tampered"), 0644))
+	require.NoError(t, extractorManager.Prepare())
+	content, err = os.ReadFile(filepath.Join(extractorManager.VersionPath(build.MavenExtractorDependencyVersion), mavenJarName))
+	require.NoError(t, err)
+	assert.Equal(t, testJarContent, content)
+
+	// A jar which doesn't match the manifest isn't left in the cache.
+	require.NoError(t, os.WriteFile(filepath.Join(offlineDir, mavenJarName), []byte("tampered"), 0644))
+	require.NoError(t, os.RemoveAll(extractorManager.LocalPath()))
+	assert.ErrorContains(t, extractorManager.Prepare(), "while the extractors manifest expects")
+	assert.NoFileExists(t, filepath.Join(extractorManager.VersionPath(build.MavenExtractorDependencyVersion), mavenJarName))
+}
+
+func TestExtractorManagerPinnedVersion(t *testing.T) {
+	extractorManager := (&ExtractorManager{extractorType: GradleExtractor}).SetLocalPath(t.TempDir()).SetOfflineDir(t.TempDir())
+	extractorManager.SetPinnedVersion("1.0.0")
+	assert.ErrorContains(t, extractorManager.Prepare(), "this JFrog CLI version runs the version 4.33.21 or 5.2.5")
+
+	extractorManager.SetPinnedVersion(gradleExtractor4Version)
+	assert.NoError(t, extractorManager.Prepare())
+	downloadPath := fmt.Sprintf("org/jfrog/buildinfo/build-info-extractor-gradle/%s/build-info-extractor-gradle-%s-uber.jar", gradleExtractor5Version, gradleExtractor5Version)
+	err := extractorManager.Download(filepath.Join(extractorManager.VersionPath(gradleExtractor5Version), "extractor.jar"), downloadPath)
+	assert.ErrorContains(t, err, "but the version 5.2.5 is required to run this build")
+}
+
+func TestExtractorManagerListAndPrune(t *testing.T) {
+	extractorManager := (&ExtractorManager{extractorType: MavenExtractor}).SetLocalPath(t.TempDir())
+	for _, version := range []string{"2.39.0", "2.40.0", build.MavenExtractorDependencyVersion} {
+		require.NoError(t, os.MkdirAll(extractorManager.VersionPath(version), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(extractorManager.VersionPath(version), fmt.Sprintf(build.MavenExtractorFileName, version)), testJarContent, 0644))
+	}
+	// A version directory without a jar isn't listed.
+	require.NoError(t, os.MkdirAll(extractorManager.VersionPath("2.38.0"), 0755))
+	extractorManager.SetPinnedVersion("2.40.0")
+
+	cached, err := extractorManager.List()
+	require.NoError(t, err)
+	require.Len(t, cached, 3)
+	assert.Equal(t, CachedExtractor{Type: MavenExtractor, Version: "2.39.0", Path: extractorManager.VersionPath("2.39.0"), Size: int64(len(testJarContent))}, cached[0])
+	assert.True(t, cached[1].InUse)
+	assert.True(t, cached[2].InUse)
+
+	pruned, err := extractorManager.Prune(true)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	assert.DirExists(t, extractorManager.VersionPath("2.39.0"))
+
+	pruned, err = extractorManager.Prune(false)
+	require.NoError(t, err)
+	require.Len(t, pruned, 1)
+	assert.Equal(t, "2.39.0", pruned[0].Version)
+	assert.NoDirExists(t, extractorManager.VersionPath("2.39.0"))
+	assert.DirExists(t, extractorManager.VersionPath("2.40.0"))
+}
+
+// The Gradle extractor versions aren't exported by build-info-go, so they are read from its source, to fail when they change after upgrading it.
+func TestGradleExtractorVersionsMatchBuildInfoGo(t *testing.T) {
+	moduleDir, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "github.com/jfrog/build-info-go").Output()
+	require.NoError(t, err)
+	gradleSource, err := parser.ParseFile(token.NewFileSet(), filepath.Join(strings.TrimSpace(string(moduleDir)), "build", "gradle.go"), nil, 0)
+	require.NoError(t, err)
+	versions := make(map[string]string)
+	ast.Inspect(gradleSource, func(node ast.Node) bool {
+		if valueSpec, ok := node.(*ast.ValueSpec); ok && len(valueSpec.Values) == len(valueSpec.Names) {
+			for i, name := range valueSpec.Names {
+				if literal, ok := valueSpec.Values[i].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+					versions[name.Name], _ = strconv.Unquote(literal.Value)
+				}
+			}
+		}
+		return true
+	})
+	assert.Equal(t, versions["gradleExtractor4DependencyVersion"], gradleExtractor4Version)
+	assert.Equal(t, versions["gradleExtractor5DependencyVersion"], gradleExtractor5Version)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -39,20 +38,18 @@ func RunGradle(vConfig *viper.Viper, tasks []string, deployableArtifactsFile str
 	if err != nil {
 		return err
 	}
-	dependencyLocalPath, err := getGradleDependencyLocalPath()
+	extractorManager, err := dependencies.NewExtractorManager(dependencies.GradleExtractor)
 	if err != nil {
 		return err
 	}
-	gradleModule.SetExtractorDetails(dependencyLocalPath, filepath.Join(coreutils.GetCliPersistentTempDirPath(), build.PropertiesTempPath), tasks, wrapper, plugin, dependencies.DownloadExtractor, props)
-	return coreutils.ConvertExitCodeError(gradleModule.CalcDependencies())
-}
-
-func getGradleDependencyLocalPath() (string, error) {
-	dependenciesPath, err := config.GetJfrogDependenciesPath()
-	if err != nil {
-		return "", err
+	extractorManager.SetPinnedVersion(vConfig.GetString(dependencies.ExtractorVersionConfigKey))
+	if !plugin {
+		if err = extractorManager.Prepare(); err != nil {
+			return err
+		}
 	}
-	return filepath.Join(dependenciesPath, "gradle"), nil
+	gradleModule.SetExtractorDetails(extractorManager.LocalPath(), filepath.Join(coreutils.GetCliPersistentTempDirPath(), build.PropertiesTempPath), tasks, wrapper, plugin, extractorManager.Download, props)
+	return coreutils.ConvertExitCodeError(gradleModule.CalcDependencies())
 }
 
 func createGradleRunConfig(vConfig *viper.Viper, deployableArtifactsFile string, threads int, disableDeploy bool) (props map[string]string, wrapper, plugin bool, err error) {
@@ -11,7 +11,6 @@ import (
 
 	buildUtils "github.com/jfrog/jfrog-cli-core/v2/common/build"
 	"github.com/jfrog/jfrog-cli-core/v2/common/project"
-	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/dependencies"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
 	"github.com/spf13/viper"
@@ -102,14 +101,18 @@ func RunMvn(mu *MvnUtils) error {
 	if v, ok := props["buildInfoConfig.artifactoryResolutionEnabled"]; ok {
 		mvnOpts = append(mvnOpts, "-DbuildInfoConfig.artifactoryResolutionEnabled="+v)
 	}
-	dependencyLocalPath, err := getMavenDependencyLocalPath()
+	extractorManager, err := dependencies.NewExtractorManager(dependencies.MavenExtractor)
 	if err != nil {
 		return err
 	}
-	mavenModule.SetExtractorDetails(dependencyLocalPath,
+	extractorManager.SetPinnedVersion(mu.vConfig.GetString(dependencies.ExtractorVersionConfigKey))
+	if err = extractorManager.Prepare(); err != nil {
+		return err
+	}
+	mavenModule.SetExtractorDetails(extractorManager.VersionPath(build.MavenExtractorDependencyVersion),
 		filepath.Join(coreutils.GetCliPersistentTempDirPath(), buildUtils.PropertiesTempPath),
 		mu.goals,
-		dependencies.DownloadExtractor,
+		extractorManager.Download,
 		props,
 		useWrapper).
 		SetOutputWriter(mu.outputWriter)
@@ -127,14 +130,6 @@ func (mu *MvnUtils) GetBuildInfoFilePath() string {
 	return mu.buildInfoFilePath
 }
 
-func getMavenDependencyLocalPath() (string, error) {
-	dependenciesPath, err := config.GetJfrogDependenciesPath()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(dependenciesPath, "maven", build.MavenExtractorDependencyVersion), nil
-}
-
 func createMvnRunProps(vConfig *viper.Viper, buildArtifactsDetailsFile string, threads int, insecureTls, disableDeploy bool) (props map[string]string, useWrapper bool, err error) {
 	useWrapper = vConfig.GetBool("useWrapper")
 	vConfig.Set(buildUtils.InsecureTls, insecureTls)
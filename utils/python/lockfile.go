@@ -0,0 +1,312 @@
+package utils
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/pythonutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	Uv  pythonutils.PythonTool = "uv"
+	Pdm pythonutils.PythonTool = "pdm"
+
+	UvLockFileName     = "uv.lock"
+	PdmLockFileName    = "pdm.lock"
+	PoetryLockFileName = "poetry.lock"
+)
+
+var (
+	requirementNameRegex      = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+	packageNameSeparatorRegex = regexp.MustCompile(`[-_.]+`)
+)
+
+// A package pinned in a uv.lock or a pdm.lock file.
+type LockedPackage struct {
+	// The normalized package name.
+	Name    string
+	Version string
+	// The file which represents the package in the build-info, and its sha256 checksum.
+	FileName string
+	Sha256   string
+	// The normalized names of the packages this package depends on.
+	Dependencies []string
+}
+
+type uvLock struct {
+	Packages []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		Source  struct {
+			Editable string `toml:"editable"`
+			Virtual  string `toml:"virtual"`
+		} `toml:"source"`
+		Sdist                *lockFile                 `toml:"sdist"`
+		Wheels               []lockFile                `toml:"wheels"`
+		Dependencies         []uvDependency            `toml:"dependencies"`
+		OptionalDependencies map[string][]uvDependency `toml:"optional-dependencies"`
+		DevDependencies      map[string][]uvDependency `toml:"dev-dependencies"`
+	} `toml:"package"`
+}
+
+type uvDependency struct {
+	Name string `toml:"name"`
+}
+
+type pdmLock struct {
+	Packages []struct {
+		Name         string     `toml:"name"`
+		Version      string     `toml:"version"`
+		Dependencies []string   `toml:"dependencies"`
+		Files        []lockFile `toml:"files"`
+	} `toml:"package"`
+}
+
+type poetryLock struct {
+	Packages []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		// Maps the names of the required packages to their constraints.
+		Dependencies map[string]any `toml:"dependencies"`
+		Files        []lockFile     `toml:"files"`
+	} `toml:"package"`
+	// Lock files created by Poetry versions older than 1.2 list the package files in the metadata.
+	Metadata struct {
+		Files map[string][]lockFile `toml:"files"`
+	} `toml:"metadata"`
+}
+
+type lockFile struct {
+	File string `toml:"file"`
+	Url  string `toml:"url"`
+	Hash string `toml:"hash"`
+}
+
+// Returns the lock file name of the Python tool, or an empty string if the tool has no supported lock file.
+func GetLockFileName(tool pythonutils.PythonTool) string {
+	switch tool {
+	case Uv:
+		return UvLockFileName
+	case Pdm:
+		return PdmLockFileName
+	case pythonutils.Poetry:
+		return PoetryLockFileName
+	}
+	return ""
+}
+
+// Reads the packages pinned in the lock file of the Python tool in dir.
+// Returns the packages, and the names of the packages which are required directly by the project.
+func ReadLockedPackages(tool pythonutils.PythonTool, dir string) (packages []LockedPackage, topLevelPackages []string, err error) {
+	lockFileName := GetLockFileName(tool)
+	if lockFileName == "" {
+		return nil, nil, errorutils.CheckErrorf("%s has no supported lock file", tool)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, lockFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, errorutils.CheckErrorf("%s was not found in %s", lockFileName, dir)
+		}
+		return nil, nil, errorutils.CheckError(err)
+	}
+	switch tool {
+	case Uv:
+		return parseUvLock(content)
+	case Pdm:
+		return parsePdmLock(content)
+	default:
+		return parsePoetryLock(content)
+	}
+}
+
+// The project itself is locked as an editable or a virtual package in the root directory, and its requirements are the top level packages.
+// Other editable and virtual packages are workspace members, which aren't downloaded and are therefore skipped.
+func parseUvLock(content []byte) (packages []LockedPackage, topLevelPackages []string, err error) {
+	lock := new(uvLock)
+	if _, err = toml.Decode(string(content), lock); err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed to parse %s: %s", UvLockFileName, err.Error())
+	}
+	for _, lockPackage := range lock.Packages {
+		if lockPackage.Source.Editable != "" || lockPackage.Source.Virtual != "" {
+			if lockPackage.Source.Editable == "." || lockPackage.Source.Virtual == "." {
+				topLevelPackages = appendUvDependencies(topLevelPackages, lockPackage.Dependencies)
+				optionalGroups := maps.Keys(lockPackage.OptionalDependencies)
+				slices.Sort(optionalGroups)
+				for _, group := range optionalGroups {
+					topLevelPackages = appendUvDependencies(topLevelPackages, lockPackage.OptionalDependencies[group])
+				}
+				devGroups := maps.Keys(lockPackage.DevDependencies)
+				slices.Sort(devGroups)
+				for _, group := range devGroups {
+					topLevelPackages = appendUvDependencies(topLevelPackages, lockPackage.DevDependencies[group])
+				}
+			}
+			continue
+		}
+		files := lockPackage.Wheels
+		if lockPackage.Sdist != nil {
+			files = append([]lockFile{*lockPackage.Sdist}, files...)
+		}
+		var dependencies []string
+		for _, dependency := range lockPackage.Dependencies {
+			dependencies = append(dependencies, dependency.Name)
+		}
+		packages = append(packages, newLockedPackage(lockPackage.Name, lockPackage.Version, files, dependencies))
+	}
+	return packages, topLevelPackages, nil
+}
+
+func appendUvDependencies(names []string, dependencies []uvDependency) []string {
+	for _, dependency := range dependencies {
+		names = append(names, NormalizePackageName(dependency.Name))
+	}
+	return names
+}
+
+// The project itself isn't locked in pdm.lock, so the packages which no other package requires are considered its requirements.
+func parsePdmLock(content []byte) (packages []LockedPackage, topLevelPackages []string, err error) {
+	lock := new(pdmLock)
+	if _, err = toml.Decode(string(content), lock); err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed to parse %s: %s", PdmLockFileName, err.Error())
+	}
+	for _, lockPackage := range lock.Packages {
+		var dependencies []string
+		for _, requirement := range lockPackage.Dependencies {
+			if match := requirementNameRegex.FindStringSubmatch(requirement); match != nil {
+				dependencies = append(dependencies, match[1])
+			}
+		}
+		packages = append(packages, newLockedPackage(lockPackage.Name, lockPackage.Version, lockPackage.Files, dependencies))
+	}
+	return packages, getUnrequiredPackages(packages), nil
+}
+
+// The project itself isn't locked in poetry.lock, so the packages which no other package requires are considered its requirements.
+func parsePoetryLock(content []byte) (packages []LockedPackage, topLevelPackages []string, err error) {
+	lock := new(poetryLock)
+	if _, err = toml.Decode(string(content), lock); err != nil {
+		return nil, nil, errorutils.CheckErrorf("failed to parse %s: %s", PoetryLockFileName, err.Error())
+	}
+	for _, lockPackage := range lock.Packages {
+		files := lockPackage.Files
+		if len(files) == 0 {
+			files = lock.Metadata.Files[lockPackage.Name]
+		}
+		dependencies := maps.Keys(lockPackage.Dependencies)
+		slices.Sort(dependencies)
+		packages = append(packages, newLockedPackage(lockPackage.Name, lockPackage.Version, files, dependencies))
+	}
+	return packages, getUnrequiredPackages(packages), nil
+}
+
+func newLockedPackage(name, version string, files []lockFile, dependencies []string) LockedPackage {
+	fileNames := make([]string, len(files))
+	hashes := make(map[string]string, len(files))
+	for i, file := range files {
+		fileNames[i] = file.File
+		if fileNames[i] == "" {
+			fileNames[i] = path.Base(file.Url)
+		}
+		hashes[fileNames[i]] = file.Hash
+	}
+	fileName := selectFile(fileNames)
+	lockedPackage := LockedPackage{
+		Name:     NormalizePackageName(name),
+		Version:  version,
+		FileName: fileName,
+		Sha256:   strings.TrimPrefix(hashes[fileName], "sha256:"),
+	}
+	for _, dependency := range dependencies {
+		lockedPackage.Dependencies = append(lockedPackage.Dependencies, NormalizePackageName(dependency))
+	}
+	return lockedPackage
+}
+
+func getUnrequiredPackages(packages []LockedPackage) (names []string) {
+	required := make(map[string]bool)
+	for _, lockedPackage := range packages {
+		for _, dependency := range lockedPackage.Dependencies {
+			required[dependency] = true
+		}
+	}
+	for _, lockedPackage := range packages {
+		if !required[lockedPackage.Name] {
+			names = append(names, lockedPackage.Name)
+		}
+	}
+	return
+}
+
+// Lock files list the files of all the platforms, while only one of them is installed.
+// A universal wheel is preferred, since it's installed on every platform, and then the source distribution.
+func selectFile(fileNames []string) string {
+	if len(fileNames) == 0 {
+		return ""
+	}
+	for _, fileName := range fileNames {
+		if strings.HasSuffix(fileName, "-none-any.whl") {
+			return fileName
+		}
+	}
+	for _, fileName := range fileNames {
+		if !strings.HasSuffix(fileName, ".whl") {
+			return fileName
+		}
+	}
+	return fileNames[0]
+}
+
+// Normalizes a package name, as defined in PEP 503.
+func NormalizePackageName(name string) string {
+	return packageNameSeparatorRegex.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// Returns the files of the locked packages mapped by the package names, in the format of the Python dependencies cache.
+func LockedPackagesToDependenciesMap(packages []LockedPackage) map[string]entities.Dependency {
+	dependenciesMap := make(map[string]entities.Dependency, len(packages))
+	for _, lockedPackage := range packages {
+		if lockedPackage.FileName == "" {
+			continue
+		}
+		dependenciesMap[lockedPackage.Name] = entities.Dependency{Id: lockedPackage.FileName, Checksum: entities.Checksum{Sha256: lockedPackage.Sha256}}
+	}
+	return dependenciesMap
+}
+
+// Returns the build-info dependencies of a module, from the locked packages.
+// The dependency IDs and the requested-by paths are set in the same way as for the packages installed by pip.
+func LockedPackagesToDependencies(packages []LockedPackage, topLevelPackages []string, moduleName string) []entities.Dependency {
+	dependenciesMap := LockedPackagesToDependenciesMap(packages)
+	packageIds := make(map[string]string, len(packages))
+	for _, lockedPackage := range packages {
+		packageIds[lockedPackage.Name] = lockedPackage.Name + ":" + lockedPackage.Version
+	}
+	toIds := func(names []string) (ids []string) {
+		for _, name := range names {
+			if id, ok := packageIds[name]; ok {
+				ids = append(ids, id)
+			}
+		}
+		return
+	}
+	dependenciesGraph := make(map[string][]string, len(packages)+1)
+	for _, lockedPackage := range packages {
+		dependenciesGraph[packageIds[lockedPackage.Name]] = toIds(lockedPackage.Dependencies)
+	}
+	pythonutils.UpdateDepsIdsAndRequestedBy(dependenciesMap, dependenciesGraph, toIds(topLevelPackages), "", moduleName)
+	dependencies := make([]entities.Dependency, 0, len(dependenciesMap))
+	names := maps.Keys(dependenciesMap)
+	slices.Sort(names)
+	for _, name := range names {
+		dependencies = append(dependencies, dependenciesMap[name])
+	}
+	return dependencies
+}
@@ -0,0 +1,130 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/build-info-go/utils/pythonutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUvLock = `version = 1
+requires-python = ">=3.12"
+
+[[package]]
+name = "certifi"
+version = "2024.2.2"
+source = { registry = "https://acme.jfrog.io/artifactory/api/pypi/pypi-virtual/simple" }
+sdist = { url = "https://acme.jfrog.io/files/certifi-2024.2.2.tar.gz", hash = "sha256:c1", size = 160 }
+wheels = [
+    { url = "https://acme.jfrog.io/files/certifi-2024.2.2-py3-none-any.whl", hash = "sha256:c2", size = 163 },
+]
+
+[[package]]
+name = "my-app"
+version = "0.1.0"
+source = { editable = "." }
+dependencies = [
+    { name = "Requests" },
+]
+
+[package.dev-dependencies]
+dev = [
+    { name = "certifi" },
+]
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+source = { registry = "https://acme.jfrog.io/artifactory/api/pypi/pypi-virtual/simple" }
+dependencies = [
+    { name = "certifi" },
+]
+sdist = { url = "https://acme.jfrog.io/files/requests-2.31.0.tar.gz", hash = "sha256:r1", size = 110 }
+`
+
+const testPdmLock = `[metadata]
+groups = ["default"]
+lock_version = "4.4.1"
+
+[[package]]
+name = "certifi"
+version = "2024.2.2"
+groups = ["default"]
+files = [
+    {file = "certifi-2024.2.2-cp312-cp312-manylinux_x86_64.whl", hash = "sha256:c3"},
+    {file = "certifi-2024.2.2.tar.gz", hash = "sha256:c1"},
+]
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+groups = ["default"]
+dependencies = [
+    "certifi>=2017.4.17",
+]
+files = [
+    {file = "requests-2.31.0.tar.gz", hash = "sha256:r1"},
+]
+`
+
+const testPoetryLock = `[[package]]
+name = "certifi"
+version = "2024.2.2"
+files = [
+    {file = "certifi-2024.2.2-py3-none-any.whl", hash = "sha256:c2"},
+    {file = "certifi-2024.2.2.tar.gz", hash = "sha256:c1"},
+]
+
+[[package]]
+name = "requests"
+version = "2.31.0"
+
+[package.dependencies]
+certifi = ">=2017.4.17"
+
+[metadata]
+lock-version = "2.0"
+
+[metadata.files]
+requests = [
+    {file = "requests-2.31.0.tar.gz", hash = "sha256:r1"},
+]
+`
+
+func TestReadLockedPackages(t *testing.T) {
+	testCases := []struct {
+		tool             pythonutils.PythonTool
+		content          string
+		certifiType      string
+		certifiSha256    string
+		certifiRequested [][]string
+	}{
+		// The universal wheel is selected, and certifi is both a direct and a transitive dependency.
+		{Uv, testUvLock, "whl", "c2", [][]string{{"requests:2.31.0", "my-app"}, {"my-app"}}},
+		// The source distribution is selected over the platform wheel.
+		{Pdm, testPdmLock, "tar.gz", "c1", [][]string{{"requests:2.31.0", "my-app"}}},
+		// The files of requests are listed in the metadata, as in lock files of older Poetry versions.
+		{pythonutils.Poetry, testPoetryLock, "whl", "c2", [][]string{{"requests:2.31.0", "my-app"}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(string(testCase.tool), func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, GetLockFileName(testCase.tool)), []byte(testCase.content), 0644))
+			packages, topLevelPackages, err := ReadLockedPackages(testCase.tool, dir)
+			require.NoError(t, err)
+			assert.Equal(t, []entities.Dependency{
+				{Id: "certifi:2024.2.2", Type: testCase.certifiType, Checksum: entities.Checksum{Sha256: testCase.certifiSha256}, RequestedBy: testCase.certifiRequested},
+				{Id: "requests:2.31.0", Type: "tar.gz", Checksum: entities.Checksum{Sha256: "r1"}, RequestedBy: [][]string{{"my-app"}}},
+			}, LockedPackagesToDependencies(packages, topLevelPackages, "my-app"))
+		})
+	}
+}
+
+func TestNormalizePackageName(t *testing.T) {
+	assert.Equal(t, "zope-interface", NormalizePackageName("Zope.Interface"))
+	assert.Equal(t, "typing-extensions", NormalizePackageName("typing__extensions"))
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/jfrog/build-info-go/utils/pythonutils"
 	"github.com/jfrog/gofrog/io"
 	gofrogcmd "github.com/jfrog/gofrog/io"
@@ -133,3 +134,28 @@ func addRepoToPyprojectFile(filepath, poetryRepoName, repoUrl string) error {
 	log.Info(fmt.Sprintf("Added tool.poetry.source name:%q url:%q", poetryRepoName, repoUrl))
 	return err
 }
+
+// Returns the package name of the project from the [project] table of its pyproject.toml file, formatted as "name:version",
+// or an empty string if the name is not set.
+func GetPyprojectPackageName(dir string) (string, error) {
+	var pyprojectContent struct {
+		Project struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"project"`
+	}
+	content, err := os.ReadFile(filepath.Join(dir, pyproject))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", errorutils.CheckError(err)
+	}
+	if _, err = toml.Decode(string(content), &pyprojectContent); err != nil {
+		return "", errorutils.CheckErrorf("failed to parse %s: %s", pyproject, err.Error())
+	}
+	if pyprojectContent.Project.Name == "" || pyprojectContent.Project.Version == "" {
+		return pyprojectContent.Project.Name, nil
+	}
+	return pyprojectContent.Project.Name + ":" + pyprojectContent.Project.Version, nil
+}